@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -47,6 +48,13 @@ Perfect for:
 
 	// Execute
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", exitErr.Err)
+			}
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -56,7 +64,7 @@ Perfect for:
 func showBanner() {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	bold := color.New(color.Bold).SprintFunc()
-	
+
 	fmt.Println()
 	fmt.Println(cyan(`  ____                 _             _                ____ _     ___ `))
 	fmt.Println(cyan(` |  _ \ _ __ __ _  ___| |_ ___  _ __(_) __ _ _ __    / ___| |   |_ _|`))
@@ -93,10 +101,10 @@ Examples:
 			fmt.Printf("📁 Config: %s\n", configPath)
 			fmt.Printf("📤 Output: %s\n", output)
 			fmt.Printf("🚀 Pipeline mode: %v\n", pipeline)
-			
+
 			// TODO: Implement validation logic
 			fmt.Println("✅ Validation completed successfully!")
-			
+
 			return nil
 		},
 	}
@@ -129,10 +137,10 @@ Examples:
 			fmt.Printf("📁 Config: %s\n", configPath)
 			fmt.Printf("🔍 Type: %s\n", auditType)
 			fmt.Printf("📤 Output: %s\n", output)
-			
+
 			// TODO: Implement audit logic
 			fmt.Println("✅ Audit completed successfully!")
-			
+
 			return nil
 		},
 	}
@@ -162,10 +170,10 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Println("🚀 Initializing Praetorian configuration...")
 			fmt.Printf("🛡️  DevSecOps mode: %v\n", devsecops)
-			
+
 			// TODO: Implement init logic
 			fmt.Println("✅ Configuration initialized successfully!")
-			
+
 			return nil
 		},
 	}
@@ -0,0 +1,194 @@
+package loaders
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// LoadConfig reads a praetorian.yaml configuration file with its presets and extended
+// files applied over the defaults of models.DefaultConfig. Paths in extended files are
+// relative to the directory of filename.
+func LoadConfig(filename string) (*models.PraetorianConfig, error) {
+	// Guard clause: validate filename
+	if filename == "" {
+		return nil, fmt.Errorf("config path cannot be empty")
+	}
+
+	tree, sources, err := resolveConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := fromTree(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", filename, err)
+	}
+	config.Sources = sources
+	return config, nil
+}
+
+// ResolveEnvironments returns the files of every environment of a configuration. Each
+// entry of an environment names a file, a directory (its files) or a glob, relative to
+// baseDir; entries that match nothing resolve to no files. The configuration files
+// themselves are never resolved.
+func ResolveEnvironments(config *models.PraetorianConfig, baseDir string) (map[string][]string, error) {
+	environments := make(map[string][]string, len(config.Environments))
+	for name, entries := range config.Environments {
+		var files []string
+		for _, entry := range entries {
+			matches, err := expandPattern(baseDir, entry)
+			if err != nil {
+				return nil, fmt.Errorf("environment %s: %w", name, err)
+			}
+			files = append(files, matches...)
+		}
+		environments[name] = excludeFiles(files, baseDir, config)
+	}
+	return environments, nil
+}
+
+// ResolveFiles returns the sorted files selected by a configuration: the files of every
+// environment and of every include pattern, minus those matching an exclude pattern and
+// the configuration files
+func ResolveFiles(config *models.PraetorianConfig, baseDir string) ([]string, error) {
+	environments, err := ResolveEnvironments(config, baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, files := range environments {
+		for _, file := range files {
+			seen[file] = true
+		}
+	}
+	for _, pattern := range config.Files.Include {
+		files, err := expandPattern(baseDir, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("include %s: %w", pattern, err)
+		}
+		for _, file := range excludeFiles(files, baseDir, config) {
+			seen[file] = true
+		}
+	}
+
+	files := make([]string, 0, len(seen))
+	for file := range seen {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// expandPattern returns the sorted files a file, directory or glob names below baseDir
+func expandPattern(baseDir, pattern string) ([]string, error) {
+	// Guard clause: validate pattern
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	// Plain paths name a file or the files of a directory
+	if !strings.ContainsAny(pattern, "*?[") {
+		target := filepath.Join(baseDir, pattern)
+		stat, err := os.Stat(target)
+		if err != nil {
+			return nil, nil
+		}
+		if !stat.IsDir() {
+			return []string{target}, nil
+		}
+		return directoryFiles(target)
+	}
+
+	// Walk from the longest directory prefix without glob characters
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	static := 0
+	for static < len(segments)-1 && !strings.ContainsAny(segments[static], "*?[") {
+		static++
+	}
+	root := filepath.Join(baseDir, filepath.FromSlash(strings.Join(segments[:static], "/")))
+
+	var files []string
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if file == root {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			if file != root && strings.HasPrefix(entry.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		relative, err := filepath.Rel(baseDir, file)
+		if err != nil {
+			return err
+		}
+		if models.MatchSegments(segments, strings.Split(filepath.ToSlash(relative), "/"), models.MatchSegment) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand %s: %w", pattern, err)
+	}
+	return files, nil
+}
+
+// directoryFiles returns the sorted regular files directly inside a directory
+func directoryFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// excludeFiles removes the configuration files and the files matching an exclude pattern
+// (relative to baseDir)
+func excludeFiles(files []string, baseDir string, config *models.PraetorianConfig) []string {
+	sources := make(map[string]bool, len(config.Sources))
+	for _, source := range config.Sources {
+		sources[source] = true
+	}
+
+	kept := make([]string, 0, len(files))
+	for _, file := range files {
+		if absolute, err := filepath.Abs(file); err == nil && sources[absolute] {
+			continue
+		}
+		relative, err := filepath.Rel(baseDir, file)
+		if err != nil {
+			relative = file
+		}
+
+		excluded := false
+		for _, pattern := range config.Files.Exclude {
+			if models.MatchGlob(pattern, relative) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
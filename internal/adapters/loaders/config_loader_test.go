@@ -0,0 +1,133 @@
+package loaders
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLoadConfigFiles tests both forms of the files section
+func TestLoadConfigFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		include []string
+		exclude []string
+	}{
+		{name: "list", content: "files:\n  - \"config/*.yaml\"\n", include: []string{"config/*.yaml"}},
+		{
+			name:    "mapping",
+			content: "files:\n  include: [\"*.json\"]\n  exclude: [\"*.local.*\"]\n",
+			include: []string{"*.json"},
+			exclude: []string{"*.local.*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "praetorian.yaml")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(filename)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(config.Files.Include, tt.include) || !reflect.DeepEqual(config.Files.Exclude, tt.exclude) {
+				t.Errorf("LoadConfig() files = %+v, want include %v exclude %v", config.Files, tt.include, tt.exclude)
+			}
+		})
+	}
+}
+
+// TestResolveFiles tests resolving environments and include/exclude patterns
+func TestResolveFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"config/dev/app.yaml", "config/dev/.hidden", "config/prod.yaml", "config/prod.local.yaml", "deploy/k8s/values.yaml", "other.txt"} {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("a: 1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config, err := LoadConfig(writeConfig(t, dir, `
+files:
+  include: ["deploy/**/*.yaml"]
+  exclude: ["*.local.*"]
+environments:
+  dev: config/dev
+  prod: ["config/prod*.yaml", "config/prod"]
+  staging: config/staging
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	environments, err := ResolveEnvironments(config, dir)
+	if err != nil {
+		t.Fatalf("ResolveEnvironments() error = %v", err)
+	}
+	expected := map[string][]string{
+		"dev":     {filepath.Join(dir, "config/dev/app.yaml")},
+		"prod":    {filepath.Join(dir, "config/prod.yaml")},
+		"staging": nil,
+	}
+	for name, files := range expected {
+		if len(files) != len(environments[name]) || (len(files) > 0 && !reflect.DeepEqual(files, environments[name])) {
+			t.Errorf("ResolveEnvironments()[%s] = %v, want %v", name, environments[name], files)
+		}
+	}
+
+	files, err := ResolveFiles(config, dir)
+	if err != nil {
+		t.Fatalf("ResolveFiles() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "config/dev/app.yaml"),
+		filepath.Join(dir, "config/prod.yaml"),
+		filepath.Join(dir, "deploy/k8s/values.yaml"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ResolveFiles() = %v, want %v", files, want)
+	}
+}
+
+// TestResolveFilesExcludesConfig tests that the configuration and the files it extends
+// are never resolved, even when an include pattern matches them
+func TestResolveFilesExcludesConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "base.yaml"), []byte("version: \"1.0\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("a: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(writeConfig(t, dir, "extends: base.yaml\nfiles:\n  include: [\"*.yaml\"]\nenvironments:\n  dev: [\"*.yaml\"]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ResolveFiles(config, dir)
+	if err != nil {
+		t.Fatalf("ResolveFiles() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "app.yaml")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ResolveFiles() = %v, want %v", files, want)
+	}
+}
+
+// writeConfig writes a praetorian.yaml into a directory
+func writeConfig(t *testing.T, dir, content string) string {
+	filename := filepath.Join(dir, "praetorian.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
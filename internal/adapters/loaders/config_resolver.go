@@ -0,0 +1,205 @@
+package loaders
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// presets are built-in configuration fragments selected with presets: [name]
+var presets = map[string]string{
+	"devsecops": `
+rules:
+  security:
+    secret_detection: true
+    vulnerability_scan: true
+    permission_check: true
+`,
+	"dotnet": `
+formats:
+  - pattern: "appsettings*.json"
+    format: jsonc
+`,
+	"spring": `
+formats:
+  - pattern: "application*.properties"
+    format: properties
+    options:
+      expand_keys: true
+type_inference:
+  formats: [properties]
+`,
+	"dotenv": `
+type_inference:
+  formats: [env]
+`,
+}
+
+// PresetNames returns the sorted names of the built-in presets
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// projectDefaults are the sections of models.DefaultConfig that describe a sample project
+// (its files, environments and key rules) rather than settings, so they are not merged
+// into configurations that leave them out
+var projectDefaults = [][]string{
+	{"files"},
+	{"environments"},
+	{"rules", "structure", "required_keys"},
+	{"rules", "structure", "forbidden_keys"},
+	{"rules", "structure", "ignore_keys"},
+}
+
+// resolveConfig builds the configuration tree of a file: the settings of
+// models.DefaultConfig, then the presets and extended files of the file, then the file
+// itself. Mappings are merged key by key while lists and values replace earlier ones. It
+// also returns the absolute paths of the file and of the files it extends.
+func resolveConfig(filename string) (map[string]interface{}, []string, error) {
+	defaults, err := toTree(models.DefaultConfig())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, section := range projectDefaults {
+		deleteTreeKey(defaults, section)
+	}
+
+	var sources []string
+	tree, err := resolveLayers(filename, map[string]bool{}, &sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	delete(tree, "extends")
+	delete(tree, "presets")
+	return mergeTrees(defaults, tree), sources, nil
+}
+
+// resolveLayers merges the presets and extended files of a file below the file itself,
+// recording the absolute path of every file read in sources
+func resolveLayers(filename string, visiting map[string]bool, sources *[]string) (map[string]interface{}, error) {
+	absolute, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", filename, err)
+	}
+
+	// Guard clause: extends cycles
+	if visiting[absolute] {
+		return nil, fmt.Errorf("config %s is extended in a cycle", filename)
+	}
+	visiting[absolute] = true
+	defer delete(visiting, absolute)
+	*sources = append(*sources, absolute)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", filename, err)
+	}
+
+	tree := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", filename, err)
+	}
+
+	header := struct {
+		Extends models.StringList `yaml:"extends"`
+		Presets models.StringList `yaml:"presets"`
+	}{}
+	if err := yaml.Unmarshal(content, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", filename, err)
+	}
+
+	resolved := map[string]interface{}{}
+	for _, name := range header.Presets {
+		preset, ok := presets[name]
+		if !ok {
+			return nil, fmt.Errorf("config %s: unknown preset %q (available: %v)", filename, name, PresetNames())
+		}
+		layer := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(preset), &layer); err != nil {
+			return nil, fmt.Errorf("preset %s: %w", name, err)
+		}
+		resolved = mergeTrees(resolved, layer)
+	}
+
+	for _, base := range header.Extends {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(filename), base)
+		}
+		layer, err := resolveLayers(base, visiting, sources)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+		resolved = mergeTrees(resolved, layer)
+	}
+
+	return mergeTrees(resolved, tree), nil
+}
+
+// mergeTrees merges override into base: mappings are merged recursively, anything else
+// in override replaces the value in base
+func mergeTrees(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range override {
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		if overrideIsMap && baseIsMap {
+			merged[key] = mergeTrees(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// toTree converts a configuration into its YAML tree
+func toTree(config *models.PraetorianConfig) (map[string]interface{}, error) {
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	tree := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &tree); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	return tree, nil
+}
+
+// fromTree decodes a YAML tree into a configuration
+func fromTree(tree map[string]interface{}) (*models.PraetorianConfig, error) {
+	content, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	config := &models.PraetorianConfig{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// deleteTreeKey removes the value at a key path of a configuration tree, if present
+func deleteTreeKey(tree map[string]interface{}, keyPath []string) {
+	for _, key := range keyPath[:len(keyPath)-1] {
+		child, ok := tree[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		tree = child
+	}
+	delete(tree, keyPath[len(keyPath)-1])
+}
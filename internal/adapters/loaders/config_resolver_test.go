@@ -0,0 +1,107 @@
+package loaders
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLoadConfigResolution tests defaults, presets and extends
+func TestLoadConfigResolution(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "shared", "base.yaml"), []byte(`
+presets: dotnet
+rules:
+  structure:
+    required_keys: [database.host]
+    forbidden_keys: ["*.debug"]
+  security:
+    secret_detection: false
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(writeConfig(t, dir, `
+extends: shared/base.yaml
+presets: [devsecops]
+rules:
+  structure:
+    required_keys: [app.name]
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	// Lists of the file replace the extended lists, mappings are merged
+	if !reflect.DeepEqual(config.Rules.Structure.RequiredKeys, []string{"app.name"}) {
+		t.Errorf("RequiredKeys = %v, want [app.name]", config.Rules.Structure.RequiredKeys)
+	}
+	if !reflect.DeepEqual(config.Rules.Structure.ForbiddenKeys, []string{"*.debug"}) {
+		t.Errorf("ForbiddenKeys = %v, want [*.debug]", config.Rules.Structure.ForbiddenKeys)
+	}
+	// The extended file overrides the devsecops preset of the file
+	if config.Rules.Security.SecretDetection || !config.Rules.Security.VulnerabilityScan {
+		t.Errorf("Security = %+v, want extended secret_detection over the preset", config.Rules.Security)
+	}
+	// Presets of extended files apply
+	if len(config.Formats) != 1 || config.Formats[0].Format != "jsonc" {
+		t.Errorf("Formats = %+v, want the dotnet preset", config.Formats)
+	}
+	// Defaults fill the settings no file sets
+	if config.Version != "2.0" || config.Performance.MaxWorkers != 4 || config.Rules.Structure.Placeholder != "CHANGE_ME" {
+		t.Errorf("LoadConfig() = %+v, want the defaults", config)
+	}
+	if len(config.Extends) != 0 || len(config.Presets) != 0 {
+		t.Errorf("LoadConfig() kept extends %v and presets %v", config.Extends, config.Presets)
+	}
+	// The sample project of the defaults is not merged in
+	if len(config.Environments) != 0 || len(config.Files.Include) != 0 || len(config.Rules.Structure.IgnoreKeys) != 0 {
+		t.Errorf("LoadConfig() = %+v, want no default files, environments or ignored keys", config)
+	}
+}
+
+// TestLoadConfigResolutionErrors tests extends cycles, missing files and unknown presets
+func TestLoadConfigResolutionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "cycle",
+			files:   map[string]string{"praetorian.yaml": "extends: a.yaml\n", "a.yaml": "extends: praetorian.yaml\n"},
+			wantErr: "cycle",
+		},
+		{
+			name:    "missing extends",
+			files:   map[string]string{"praetorian.yaml": "extends: missing.yaml\n"},
+			wantErr: "missing.yaml",
+		},
+		{
+			name:    "unknown preset",
+			files:   map[string]string{"praetorian.yaml": "presets: [rails]\n"},
+			wantErr: `unknown preset "rails"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := LoadConfig(filepath.Join(dir, "praetorian.yaml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
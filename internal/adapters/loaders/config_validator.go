@@ -0,0 +1,479 @@
+package loaders
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/adapters/parsers"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// supportedVersions are the configuration versions praetorian understands
+var supportedVersions = map[string]bool{"": true, "1.0": true, "2.0": true}
+
+// yamlLinePrefix matches the line of yaml.v3 error messages ("line 3: ...")
+var yamlLinePrefix = regexp.MustCompile(`line (\d+): `)
+
+// configSource is a parsed configuration file, the validated file or one it extends
+type configSource struct {
+	filename string
+	root     *yaml.Node
+}
+
+// configValidator collects the issues of a configuration file and the files it extends
+type configValidator struct {
+	filename string
+	sources  []configSource
+	result   models.ValidationResult
+	// unresolvable is set by issues that prevent resolving the configuration
+	unresolvable bool
+}
+
+// ValidateConfigFile checks a praetorian.yaml configuration: syntax, unknown keys (with
+// suggestions for typos), value types, extends and presets, glob patterns, regexes, key
+// paths, environments whose files do not exist and parser plugin commands that cannot be
+// run. Relative paths are resolved from the directory of filename.
+func ValidateConfigFile(filename string) models.ValidationResult {
+	v := &configValidator{
+		filename: filename,
+		result:   models.ValidationResult{Success: true, Timestamp: time.Now()},
+	}
+	start := time.Now()
+
+	v.checkSource(filename, map[string]bool{})
+	if !v.unresolvable {
+		v.checkResolved()
+	}
+
+	v.result.Success = len(v.result.Errors) == 0
+	v.result.Duration = time.Since(start)
+	return v.result
+}
+
+// checkSource checks the syntax and schema of a file and then the files it extends
+func (v *configValidator) checkSource(filename string, visiting map[string]bool) {
+	absolute, err := filepath.Abs(filename)
+	if err == nil && visiting[absolute] {
+		v.addError(v.filename, 0, 0, "CONFIG_EXTENDS_CYCLE", "extends", fmt.Sprintf("%s is extended in a cycle", filename))
+		v.unresolvable = true
+		return
+	}
+	visiting[absolute] = true
+	defer delete(visiting, absolute)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		v.addError(filename, 0, 0, "CONFIG_MISSING_FILE", "", fmt.Sprintf("cannot read config: %v", err))
+		v.unresolvable = true
+		return
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		line := 0
+		if match := yamlLinePrefix.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		v.addError(filename, line, 0, "CONFIG_SYNTAX", "", err.Error())
+		v.unresolvable = true
+		return
+	}
+
+	// Guard clause: empty file
+	if len(document.Content) == 0 {
+		return
+	}
+	root := document.Content[0]
+	v.sources = append(v.sources, configSource{filename: filename, root: root})
+
+	v.checkSchema(filename, root, reflect.TypeOf(models.PraetorianConfig{}), "")
+	v.checkTypes(filename, root)
+
+	header := struct {
+		Extends models.StringList `yaml:"extends"`
+		Presets models.StringList `yaml:"presets"`
+	}{}
+	if err := root.Decode(&header); err != nil {
+		// Invalid types are reported by checkTypes
+		return
+	}
+
+	for i, name := range header.Presets {
+		if _, ok := presets[name]; ok {
+			continue
+		}
+		v.unresolvable = true
+		node := lookupNode(root, []string{"presets", strconv.Itoa(i)})
+		v.addError(filename, node.Line, node.Column, "CONFIG_UNKNOWN_PRESET", "presets",
+			fmt.Sprintf("unknown preset %q (available: %s)", name, strings.Join(PresetNames(), ", ")))
+	}
+
+	for i, base := range header.Extends {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(filename), base)
+		}
+		if _, err := os.Stat(base); err != nil {
+			v.unresolvable = true
+			node := lookupNode(root, []string{"extends", strconv.Itoa(i)})
+			v.addError(filename, node.Line, node.Column, "CONFIG_MISSING_FILE", "extends",
+				fmt.Sprintf("extended config %s does not exist", base))
+			continue
+		}
+		v.checkSource(base, visiting)
+	}
+}
+
+// checkSchema reports mapping keys that match no field of the configuration type
+func (v *configValidator) checkSchema(filename string, node *yaml.Node, t reflect.Type, key string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		// Guard clause: other node kinds are list forms or invalid types
+		if node.Kind != yaml.MappingNode || t == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, value := node.Content[i], node.Content[i+1]
+			childKey := models.JoinPath(key, name.Value)
+			field, ok := fields[name.Value]
+			if !ok {
+				message := fmt.Sprintf("unknown key %q", childKey)
+				if suggestion := suggestKey(name.Value, fields); suggestion != "" {
+					message += fmt.Sprintf(", did you mean %q?", suggestion)
+				}
+				v.addError(filename, name.Line, name.Column, "CONFIG_UNKNOWN_KEY", childKey, message)
+				continue
+			}
+			v.checkSchema(filename, value, field, childKey)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.checkSchema(filename, node.Content[i+1], t.Elem(), models.JoinPath(key, node.Content[i].Value))
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			v.checkSchema(filename, item, t.Elem(), fmt.Sprintf("%s[%d]", key, i))
+		}
+	}
+}
+
+// checkTypes decodes a file into the configuration and reports values of the wrong type
+func (v *configValidator) checkTypes(filename string, root *yaml.Node) {
+	var config models.PraetorianConfig
+	err := root.Decode(&config)
+
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		for _, message := range typeError.Errors {
+			line := 0
+			if match := yamlLinePrefix.FindStringSubmatch(message); match != nil {
+				line, _ = strconv.Atoi(match[1])
+				message = strings.TrimPrefix(message, match[0])
+			}
+			v.addError(filename, line, 0, "CONFIG_INVALID_VALUE", "", message)
+		}
+		v.unresolvable = true
+		return
+	}
+	if err != nil {
+		v.addError(filename, root.Line, root.Column, "CONFIG_INVALID_VALUE", "", err.Error())
+		v.unresolvable = true
+	}
+}
+
+// checkResolved checks the patterns, paths and environments of the resolved configuration
+func (v *configValidator) checkResolved() {
+	config, err := LoadConfig(v.filename)
+	if err != nil {
+		v.addError(v.filename, 0, 0, "CONFIG_RESOLVE", "", err.Error())
+		return
+	}
+	baseDir := filepath.Dir(v.filename)
+
+	if !supportedVersions[config.Version] {
+		v.addWarning("CONFIG_UNKNOWN_VERSION", []string{"version"},
+			fmt.Sprintf("unknown config version %q (supported: 1.0, 2.0)", config.Version))
+	}
+
+	for i, pattern := range config.Files.Include {
+		keyPath := []string{"files", "include", strconv.Itoa(i)}
+		if !v.checkGlob(pattern, keyPath) {
+			continue
+		}
+		if matches, err := expandPattern(baseDir, pattern); err == nil && len(matches) == 0 {
+			v.addWarning("CONFIG_NO_MATCH", keyPath,
+				fmt.Sprintf("files.include pattern %q matches no files", pattern))
+		}
+	}
+	for i, pattern := range config.Files.Exclude {
+		v.checkGlob(pattern, []string{"files", "exclude", strconv.Itoa(i)})
+	}
+
+	for _, name := range sortedKeys(config.Environments) {
+		entries := config.Environments[name]
+		for i, entry := range entries {
+			keyPath := []string{"environments", name, strconv.Itoa(i)}
+			if !v.checkGlob(entry, keyPath) {
+				continue
+			}
+			matches, err := expandPattern(baseDir, entry)
+			if err != nil || len(matches) > 0 {
+				continue
+			}
+			message := fmt.Sprintf("environment %s: %s does not exist", name, entry)
+			if strings.ContainsAny(entry, "*?[") {
+				message = fmt.Sprintf("environment %s: %s matches no files", name, entry)
+			}
+			v.addResolvedError("CONFIG_MISSING_FILE", keyPath, message)
+		}
+	}
+
+	structure := config.Rules.Structure
+	for i, pattern := range structure.ForbiddenKeys {
+		v.checkGlob(pattern, []string{"rules", "structure", "forbidden_keys", strconv.Itoa(i)})
+	}
+	for i, pattern := range structure.BooleanKeys {
+		v.checkGlob(pattern, []string{"rules", "structure", "boolean_keys", strconv.Itoa(i)})
+	}
+	for i, pattern := range structure.IgnoreKeys {
+		v.checkGlob(pattern, []string{"rules", "structure", "ignore_keys", strconv.Itoa(i)})
+	}
+	for i, key := range structure.RequiredKeys {
+		v.checkKeyPath(key, []string{"rules", "structure", "required_keys", strconv.Itoa(i)})
+	}
+	for _, key := range sortedKeys(structure.DeprecatedKeys) {
+		keyPath := []string{"rules", "structure", "deprecated_keys", key}
+		v.checkKeyPath(key, keyPath)
+		v.checkKeyPath(structure.DeprecatedKeys[key], keyPath)
+	}
+
+	for i, pattern := range config.Rules.Security.CustomPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.addResolvedError("CONFIG_INVALID_REGEX", []string{"rules", "security", "custom_patterns", strconv.Itoa(i)},
+				fmt.Sprintf("invalid regex %q: %v", pattern, err))
+		}
+	}
+
+	registry := parsers.NewParserRegistry()
+	if err := registry.ApplyFormatRules(config.Formats); err != nil {
+		v.addResolvedError("CONFIG_INVALID_FORMAT", []string{"formats"}, err.Error())
+	}
+	if config.Inference != nil {
+		if err := registry.ApplyTypeInference(*config.Inference); err != nil {
+			v.addResolvedError("CONFIG_INVALID_TYPE_INFERENCE", []string{"type_inference"}, err.Error())
+		}
+	}
+	if err := registry.ApplyParserPlugins(config.Parsers, baseDir); err != nil {
+		v.addResolvedError("CONFIG_INVALID_PARSER", []string{"parsers"}, err.Error())
+	}
+	for i, plugin := range config.Parsers {
+		// Guard clause: empty commands are invalid parsers
+		if len(plugin.Command) == 0 || plugin.Command[0] == "" {
+			continue
+		}
+		if _, err := exec.LookPath(parsers.ResolvePluginCommand(plugin.Command[0], baseDir)); err != nil {
+			v.addResolvedError("CONFIG_MISSING_COMMAND", []string{"parsers", strconv.Itoa(i), "command"},
+				fmt.Sprintf("parser plugin %s: command %s cannot be run: %v", plugin.Name, plugin.Command[0], err))
+		}
+	}
+}
+
+// checkGlob reports an invalid glob pattern and returns whether the pattern is valid
+func (v *configValidator) checkGlob(pattern string, keyPath []string) bool {
+	if _, err := path.Match(pattern, ""); err != nil {
+		v.addResolvedError("CONFIG_INVALID_GLOB", keyPath, fmt.Sprintf("invalid glob pattern %q: %v", pattern, err))
+		return false
+	}
+	return true
+}
+
+// checkKeyPath reports a key path that cannot be parsed
+func (v *configValidator) checkKeyPath(key string, keyPath []string) {
+	if _, err := models.ParsePath(key); err != nil {
+		v.addResolvedError("CONFIG_INVALID_KEY", keyPath, fmt.Sprintf("invalid key path %q: %v", key, err))
+	}
+}
+
+// addResolvedError reports an error of the resolved configuration at the file that
+// defines keyPath most precisely
+func (v *configValidator) addResolvedError(code string, keyPath []string, message string) {
+	filename, node := v.locate(keyPath)
+	v.addError(filename, node.Line, node.Column, code, strings.Join(keyPath, "."), message)
+}
+
+// addWarning reports a warning of the resolved configuration
+func (v *configValidator) addWarning(code string, keyPath []string, message string) {
+	filename, node := v.locate(keyPath)
+	v.result.Warnings = append(v.result.Warnings, models.ValidationWarning{
+		Code:     code,
+		Message:  message,
+		Key:      strings.Join(keyPath, "."),
+		Severity: models.SeverityLow,
+		File:     filename,
+		Line:     node.Line,
+		Column:   node.Column,
+	})
+}
+
+// addError reports an error
+func (v *configValidator) addError(filename string, line, column int, code, key, message string) {
+	v.result.Errors = append(v.result.Errors, models.ValidationError{
+		Code:     code,
+		Message:  message,
+		Key:      key,
+		Severity: models.SeverityHigh,
+		File:     filename,
+		Line:     line,
+		Column:   column,
+	})
+}
+
+// locate finds the file and node that define keyPath most precisely, preferring the
+// validated file over the files it extends
+func (v *configValidator) locate(keyPath []string) (string, *yaml.Node) {
+	filename, best, bestDepth := v.filename, &yaml.Node{}, -1
+	for _, source := range v.sources {
+		node, depth := lookupDepth(source.root, keyPath)
+		if depth > bestDepth {
+			filename, best, bestDepth = source.filename, node, depth
+		}
+	}
+	if bestDepth <= 0 {
+		return v.filename, &yaml.Node{}
+	}
+	return filename, best
+}
+
+// lookupNode returns the node at keyPath, or the deepest node found on the way
+func lookupNode(root *yaml.Node, keyPath []string) *yaml.Node {
+	node, _ := lookupDepth(root, keyPath)
+	return node
+}
+
+// lookupDepth walks keyPath (mapping keys and sequence indexes) from root and returns the
+// deepest node found with the number of segments matched. Mapping entries resolve to their
+// key node, which is where an editor should point.
+func lookupDepth(root *yaml.Node, keyPath []string) (*yaml.Node, int) {
+	node, found := root, root
+	for depth, segment := range keyPath {
+		var next, position *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					next, position = node.Content[i+1], node.Content[i]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(node.Content) {
+				next, position = node.Content[index], node.Content[index]
+			}
+		case yaml.ScalarNode:
+			// A single string stands for a one-entry list
+			if segment == "0" {
+				return node, depth + 1
+			}
+		}
+		if next == nil {
+			return found, depth
+		}
+		node, found = next, position
+	}
+	return found, len(keyPath)
+}
+
+// yamlFields maps the YAML names of the fields of a struct type to their types
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// suggestKey returns the known key closest to an unknown key, if it is close enough to
+// be a typo
+func suggestKey(key string, fields map[string]reflect.Type) string {
+	best, bestDistance := "", 3
+	for _, name := range sortedKeys(fields) {
+		if distance := editDistance(key, name); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance of two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// minInt returns the smallest of its arguments
+func minInt(values ...int) int {
+	smallest := values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+	return smallest
+}
+
+// sortedKeys returns the sorted keys of a map with string keys
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package loaders
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestValidateConfigFile tests the issues reported for praetorian.yaml configurations
+func TestValidateConfigFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		code     string
+		line     int
+		message  string
+		warnings int
+	}{
+		{
+			name:    "valid",
+			content: "version: \"2.0\"\nenvironments:\n  dev: config/dev.yaml\n",
+		},
+		{
+			name:    "unknown key with suggestion",
+			content: "rules:\n  structure:\n    ignore_key: [x]\n",
+			code:    "CONFIG_UNKNOWN_KEY",
+			line:    3,
+			message: `did you mean "ignore_keys"?`,
+		},
+		{
+			name:    "invalid value",
+			content: "performance:\n  max_workers: lots\n",
+			code:    "CONFIG_INVALID_VALUE",
+			line:    2,
+			message: "lots",
+		},
+		{
+			name:    "syntax error",
+			content: "rules: [\n",
+			code:    "CONFIG_SYNTAX",
+		},
+		{
+			name:    "invalid glob",
+			content: "rules:\n  structure:\n    forbidden_keys: [\"[debug\"]\n",
+			code:    "CONFIG_INVALID_GLOB",
+			line:    3,
+			message: `"[debug"`,
+		},
+		{
+			name:    "invalid regex",
+			content: "rules:\n  security:\n    custom_patterns:\n      - \"(unclosed\"\n",
+			code:    "CONFIG_INVALID_REGEX",
+			line:    4,
+		},
+		{
+			name:    "missing environment file",
+			content: "environments:\n  dev: config/dev.yaml\n  prod:\n    - config/prod.yaml\n",
+			code:    "CONFIG_MISSING_FILE",
+			line:    4,
+			message: "environment prod: config/prod.yaml does not exist",
+		},
+		{
+			name:    "unknown preset",
+			content: "presets: [rails]\n",
+			code:    "CONFIG_UNKNOWN_PRESET",
+			line:    1,
+		},
+		{
+			name:    "missing parser plugin command",
+			content: "parsers:\n  - name: mycfg\n    patterns: [\"*.mycfg\"]\n    command: [\"./bin/mycfg-parse\"]\n",
+			code:    "CONFIG_MISSING_COMMAND",
+			line:    4,
+			message: "parser plugin mycfg: command ./bin/mycfg-parse cannot be run",
+		},
+		{
+			name:     "include without matches",
+			content:  "version: \"3.0\"\nfiles:\n  include: [\"deploy/*.yaml\"]\n",
+			warnings: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(dir, "config"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "config", "dev.yaml"), []byte("a: 1\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			result := ValidateConfigFile(writeConfig(t, dir, tt.content))
+			if len(result.Warnings) != tt.warnings {
+				t.Errorf("ValidateConfigFile() warnings = %+v, want %d", result.Warnings, tt.warnings)
+			}
+
+			if tt.code == "" {
+				if !result.Success || len(result.Errors) != 0 {
+					t.Errorf("ValidateConfigFile() errors = %+v, want none", result.Errors)
+				}
+				return
+			}
+
+			if result.Success || len(result.Errors) != 1 {
+				t.Fatalf("ValidateConfigFile() errors = %+v, want one %s", result.Errors, tt.code)
+			}
+			issue := result.Errors[0]
+			if issue.Code != tt.code || !strings.Contains(issue.Message, tt.message) {
+				t.Errorf("ValidateConfigFile() error = %+v, want %s containing %q", issue, tt.code, tt.message)
+			}
+			if tt.line != 0 && issue.Line != tt.line {
+				t.Errorf("ValidateConfigFile() line = %d, want %d", issue.Line, tt.line)
+			}
+		})
+	}
+}
+
+// TestValidateConfigFileExtends tests that extended files are validated with their own
+// positions
+func TestValidateConfigFileExtends(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	if err := os.WriteFile(base, []byte("rules:\n  structur:\n    required_keys: [a]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := ValidateConfigFile(writeConfig(t, dir, "extends: base.yaml\n"))
+	if len(result.Errors) != 1 {
+		t.Fatalf("ValidateConfigFile() errors = %+v, want one", result.Errors)
+	}
+	issue := result.Errors[0]
+	if issue.File != base || issue.Line != 2 || !strings.Contains(issue.Message, `did you mean "structure"?`) {
+		t.Errorf("ValidateConfigFile() error = %+v, want the typo in base.yaml", issue)
+	}
+}
+
+// TestValidateConfigFileShippedExamples tests that the configurations of the repository
+// and its examples are valid
+func TestValidateConfigFileShippedExamples(t *testing.T) {
+	configs, err := filepath.Glob("../../../examples/validation/*/praetorian.yaml")
+	if err != nil || len(configs) == 0 {
+		t.Fatalf("no example configurations found: %v", err)
+	}
+	configs = append(configs, "../../../praetorian.yaml")
+
+	for _, config := range configs {
+		t.Run(config, func(t *testing.T) {
+			result := ValidateConfigFile(config)
+			if !result.Success || len(result.Warnings) != 0 {
+				t.Errorf("ValidateConfigFile() errors = %+v, warnings = %+v, want none", result.Errors, result.Warnings)
+			}
+		})
+	}
+}
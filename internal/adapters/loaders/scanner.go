@@ -0,0 +1,48 @@
+package loaders
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// scanSkippedDirs are directories that hold dependencies, build output or test fixtures
+// rather than project configuration
+var scanSkippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+	"fixtures":     true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+}
+
+// ScanFiles walks root and returns the sorted files accepted by accept, skipping hidden
+// directories and directories of dependencies, build output and test fixtures
+func ScanFiles(root string, accept func(filename string) bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if file != root && (strings.HasPrefix(entry.Name(), ".") || scanSkippedDirs[entry.Name()]) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() && accept(file) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
@@ -10,6 +10,17 @@ import (
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
+// LineError reports a syntax error at a specific line of a file
+type LineError struct {
+	Line    int
+	Message string
+}
+
+// Error implements the error interface
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
 // ValidateFilenameAndExtension validates filename and checks extension
 func ValidateFilenameAndExtension(filename string, supportedExtensions []string) bool {
 	// Guard clause: empty filename
@@ -64,14 +75,15 @@ func copyExtensions(extensions []string) []string {
 	return result
 }
 
-// createConfigData creates ConfigData from parsed content
-func createConfigData(filename, format string, data map[string]interface{}) *models.ConfigData {
+// createConfigData creates ConfigData from parsed content and key positions
+func createConfigData(filename, format string, data map[string]interface{}, positions map[string]models.Position) *models.ConfigData {
 	return &models.ConfigData{
 		Filename:  filename,
 		Format:    format,
 		Data:      data,
 		Metadata:  createMetadata(filename, format, data),
 		Timestamp: time.Now(),
+		Positions: positions,
 	}
 }
 
@@ -187,41 +199,3 @@ func extractKeyValue(line string, separatorIndex int) (string, string) {
 func isValidKey(key string) bool {
 	return key != ""
 }
-
-// parseKeyValueContent parses key-value content with comment detection
-func parseKeyValueContent(content []byte, isComment func(string) bool) (map[string]interface{}, error) {
-	// Guard clause: empty content
-	if isEmptyContent(content) {
-		return createEmptyResult(), nil
-	}
-
-	result := createEmptyResult()
-	lines := splitLines(content)
-
-	for _, line := range lines {
-		trimmedLine := trimLine(line)
-
-		// Skip empty lines and comments
-		if isEmptyLine(trimmedLine) || isComment(trimmedLine) {
-			continue
-		}
-
-		// Parse key-value pair
-		if key, value, ok := parseKeyValue(trimmedLine); ok {
-			result[key] = removeQuotes(value)
-		}
-	}
-
-	return result, nil
-}
-
-// parseKeyValue parses a key-value pair from a line
-func parseKeyValue(line string) (string, string, bool) {
-	separatorIndex := findKeyValueSeparator(line)
-	if separatorIndex == -1 {
-		return "", "", false
-	}
-
-	key, value := extractKeyValue(line, separatorIndex)
-	return key, value, isValidKey(key)
-}
@@ -0,0 +1,247 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// commentPrefixes are the line comment markers of formats without a comment-aware parser
+var commentPrefixes = map[string][]string{
+	"toml":   {"#"},
+	"hcl":    {"#", "//"},
+	"tfvars": {"#", "//"},
+	"hocon":  {"#", "//"},
+}
+
+// SourceLosses lists what converting a parsed file to another format cannot keep from its
+// source: comments, duplicate keys (only the last value is kept) and non-string keys
+// (they become strings).
+func SourceLosses(data *models.ConfigData, content []byte) []string {
+	normalized, _, err := models.NormalizeEncoding(content)
+	if err != nil {
+		return nil
+	}
+
+	var losses []string
+	comments, duplicates, nonStringKeys := 0, []string(nil), []string(nil)
+	switch format := normalizeFormat(data.Format); format {
+	case "yaml":
+		comments, nonStringKeys = yamlSourceFeatures(normalized)
+	case "json", "jsonc", "json5":
+		comments = len(data.Comments)
+	case "env":
+		parser := &envParser{lines: splitLines(normalized), values: createEmptyResult(), positions: createEmptyPositions()}
+		_ = parser.parse()
+		duplicates = parser.duplicates
+		comments = countCommentLines(normalized, isENVComment)
+	case "properties":
+		entries, _ := readPropertiesEntries(splitLines(normalized))
+		seen := make(map[string]bool)
+		for _, entry := range entries {
+			if seen[entry.key] {
+				duplicates = append(duplicates, entry.key)
+			}
+			seen[entry.key] = true
+		}
+		comments = countCommentLines(normalized, isPropertiesComment)
+	case "ini":
+		comments = countCommentLines(normalized, isINIComment)
+	default:
+		prefixes := commentPrefixes[format]
+		comments = countCommentLines(normalized, func(line string) bool {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(line, prefix) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if comments > 0 {
+		losses = append(losses, fmt.Sprintf("%d comments are dropped", comments))
+	}
+	for _, key := range duplicates {
+		losses = append(losses, fmt.Sprintf("duplicate key %s: only the last value is kept", key))
+	}
+	for _, key := range nonStringKeys {
+		losses = append(losses, fmt.Sprintf("non-string key %s becomes a string", key))
+	}
+	return losses
+}
+
+// TargetLosses lists the values of a tree that a format cannot represent exactly
+func TargetLosses(format string, data map[string]interface{}) []string {
+	name := normalizeFormat(format)
+
+	var losses []string
+	renamed, example := 0, ""
+	names := make(map[string]string)
+	tree := &models.ConfigData{Data: data}
+	_ = tree.Walk(func(keyPath models.Path, value interface{}) error {
+		// Guard clause: the root is always a map
+		if len(keyPath) == 0 {
+			return nil
+		}
+		key := keyPath.String()
+
+		switch name {
+		case "toml":
+			if value == nil {
+				losses = append(losses, fmt.Sprintf("%s: null values cannot be written in TOML and are dropped", key))
+			}
+		case "env", "properties":
+			if !isLeafValue(value) {
+				return nil
+			}
+			if isEmptyContainer(value) {
+				losses = append(losses, fmt.Sprintf("%s: empty maps and lists are written as empty values", key))
+			}
+			if name == "properties" && value == nil {
+				losses = append(losses, fmt.Sprintf("%s: properties have no null, the value is written empty", key))
+			}
+			if name == "env" {
+				envName := EnvName(keyPath)
+				if !envKeyPattern.MatchString(envName) {
+					losses = append(losses, fmt.Sprintf("%s: %s is not a valid variable name, the value is dropped", key, envName))
+					return nil
+				}
+				if other, ok := names[envName]; ok {
+					losses = append(losses, fmt.Sprintf("%s and %s are both written as %s, only one is kept", other, key, envName))
+				}
+				names[envName] = key
+				if envName != key {
+					if renamed == 0 {
+						example = fmt.Sprintf("%s is %s", key, envName)
+					}
+					renamed++
+				}
+			}
+		case "ini":
+			losses = append(losses, iniLosses(keyPath, value)...)
+		}
+		return nil
+	})
+
+	if renamed > 0 {
+		losses = append(losses, fmt.Sprintf("%d keys are renamed to variable names (%s); compare with diff --ignore-case --separators _", renamed, example))
+	}
+	return losses
+}
+
+// iniLosses lists why a value cannot be written exactly in an INI file
+func iniLosses(keyPath models.Path, value interface{}) []string {
+	key := keyPath.String()
+	last := keyPath[len(keyPath)-1]
+
+	var losses []string
+	if !last.IsIndex && ((strings.ContainsAny(last.Key, "=:;#") || strings.HasPrefix(last.Key, "[")) || strings.Contains(last.Key, ".") && isMap(value)) {
+		losses = append(losses, fmt.Sprintf("%s: the key cannot be written in INI", key))
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) < 2 {
+			losses = append(losses, fmt.Sprintf("%s: lists with fewer than two items are read back as single values", key))
+		}
+		if len(keyPath) > 1 && keyPath[len(keyPath)-2].IsIndex {
+			losses = append(losses, fmt.Sprintf("%s: nested lists cannot be written in INI and are dropped", key))
+		}
+	case map[string]interface{}:
+		if last.IsIndex {
+			losses = append(losses, fmt.Sprintf("%s: maps in lists cannot be written in INI and are dropped", key))
+		}
+	case string:
+		if strings.ContainsAny(v, "\n\r") {
+			losses = append(losses, fmt.Sprintf("%s: multi-line values are written as continuation lines, losing indentation and blank lines", key))
+		}
+		if strings.Contains(v, `"`) && strings.Contains(v, "'") {
+			losses = append(losses, fmt.Sprintf("%s: values with both quote characters cannot be quoted", key))
+		}
+	}
+	return losses
+}
+
+// yamlSourceFeatures counts the comments of YAML content and lists its non-string keys
+func yamlSourceFeatures(content []byte) (int, []string) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return 0, nil
+	}
+
+	comments := 0
+	var nonStringKeys []string
+	var visit func(node *yaml.Node, path string)
+	visit = func(node *yaml.Node, path string) {
+		for _, comment := range []string{node.HeadComment, node.LineComment, node.FootComment} {
+			if comment != "" {
+				comments += strings.Count(comment, "\n") + 1
+			}
+		}
+
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				visit(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				keyPath := models.JoinPath(path, key.Value)
+				if key.Tag != "!!str" && key.Tag != yamlMergeTag {
+					nonStringKeys = append(nonStringKeys, fmt.Sprintf("%s (%s)", keyPath, key.Tag))
+				}
+				visit(key, keyPath)
+				visit(value, keyPath)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				visit(child, models.IndexPath(path, i))
+			}
+		}
+	}
+	visit(&document, "")
+	return comments, nonStringKeys
+}
+
+// countCommentLines counts the lines of content that are comments
+func countCommentLines(content []byte, isComment func(line string) bool) int {
+	count := 0
+	for _, line := range splitLines(content) {
+		if trimmed := trimLine(line); trimmed != "" && isComment(trimmed) {
+			count++
+		}
+	}
+	return count
+}
+
+// isLeafValue reports whether a value is written as a single line by flat formats
+func isLeafValue(value interface{}) bool {
+	return !isMap(value) && !isList(value) || isEmptyContainer(value)
+}
+
+// isEmptyContainer reports whether a value is an empty map or list
+func isEmptyContainer(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// isMap reports whether a value is a map
+func isMap(value interface{}) bool {
+	_, ok := value.(map[string]interface{})
+	return ok
+}
+
+// isList reports whether a value is a list
+func isList(value interface{}) bool {
+	_, ok := value.([]interface{})
+	return ok
+}
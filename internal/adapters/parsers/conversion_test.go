@@ -0,0 +1,121 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestSourceLosses tests the comments, duplicate keys and non-string keys reported for a source
+func TestSourceLosses(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "properties comments and duplicates",
+			format:   "properties",
+			content:  "# server\n! legacy\nport=8080\nport=9090\n",
+			expected: []string{"2 comments are dropped", "duplicate key port: only the last value is kept"},
+		},
+		{
+			name:     "env duplicates",
+			format:   "env",
+			content:  "HOST=a\nHOST=b\n",
+			expected: []string{"duplicate key HOST: only the last value is kept"},
+		},
+		{
+			name:     "yaml comments and non-string keys",
+			format:   "yaml",
+			content:  "# codes\ncodes:\n  404: missing # not found\n",
+			expected: []string{"2 comments are dropped", "non-string key codes.404 (!!int) becomes a string"},
+		},
+		{
+			name:    "nothing lost",
+			format:  "json",
+			content: `{"port": 8080}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewProcessorForFormat(tt.format, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := models.ProcessNormalized(context.Background(), processor, "source", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			losses := SourceLosses(data, []byte(tt.content))
+			if !reflect.DeepEqual(losses, tt.expected) {
+				t.Errorf("SourceLosses() = %q, want %q", losses, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTargetLosses tests the values a target format cannot represent exactly
+func TestTargetLosses(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		data     map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "toml nulls",
+			format:   "toml",
+			data:     map[string]interface{}{"a": nil, "b": 1},
+			expected: []string{"a: null values cannot be written in TOML and are dropped"},
+		},
+		{
+			name:     "properties empty containers and nulls",
+			format:   "properties",
+			data:     map[string]interface{}{"list": []interface{}{}, "unset": nil},
+			expected: []string{"list: empty maps and lists are written as empty values", "unset: properties have no null, the value is written empty"},
+		},
+		{
+			name:   "env name collisions",
+			format: "env",
+			data:   map[string]interface{}{"a": map[string]interface{}{"b": 1}, "a_b": 2},
+			expected: []string{
+				"a.b and a_b are both written as A_B, only one is kept",
+				"2 keys are renamed to variable names (a.b is A_B); compare with diff --ignore-case --separators _",
+			},
+		},
+		{
+			name:   "env invalid names",
+			format: "env",
+			data:   map[string]interface{}{"1": "one", "PORT": 80},
+			expected: []string{
+				"1: 1 is not a valid variable name, the value is dropped",
+			},
+		},
+		{
+			name:     "ini short lists",
+			format:   "ini",
+			data:     map[string]interface{}{"hosts": []interface{}{"a"}},
+			expected: []string{"hosts: lists with fewer than two items are read back as single values"},
+		},
+		{
+			name:   "yaml keeps everything",
+			format: "yaml",
+			data:   map[string]interface{}{"a": nil, "b": []interface{}{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			losses := TargetLosses(tt.format, tt.data)
+			if !reflect.DeepEqual(losses, tt.expected) {
+				t.Errorf("TargetLosses() = %q, want %q", losses, tt.expected)
+			}
+		})
+	}
+}
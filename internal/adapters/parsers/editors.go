@@ -0,0 +1,235 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// editorFactories create format-preserving editors by format name
+var editorFactories = map[string]func(content []byte) (models.ConfigEditor, error){
+	"yaml":       newYAMLEditor,
+	"json":       newJSONEditor,
+	"jsonc":      newJSONEditor,
+	"json5":      newJSONEditor,
+	"toml":       newTOMLEditor,
+	"ini":        newINIEditor,
+	"env":        newENVEditor,
+	"properties": newPropertiesEditor,
+}
+
+// NewEditorForFormat creates a format-preserving editor for file content of a format.
+// The content keeps its encoding, byte order mark and line endings.
+func NewEditorForFormat(format string, content []byte) (models.ConfigEditor, error) {
+	name := normalizeFormat(format)
+
+	factory, ok := editorFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("editing %s files is not supported (supported: %s)", format, strings.Join(EditableFormats(), ", "))
+	}
+
+	normalized, info, err := models.NormalizeEncoding(content)
+	if err != nil {
+		return nil, err
+	}
+
+	editor, err := factory(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s content: %w", name, err)
+	}
+
+	return &encodingEditor{ConfigEditor: editor, info: info}, nil
+}
+
+// EditableFormats returns the sorted names of the formats that can be edited
+func EditableFormats() []string {
+	formats := make([]string, 0, len(editorFactories))
+	for format := range editorFactories {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// GetEditor returns a format-preserving editor for a file, detecting its format like
+// DetectProcessor
+func (r *ParserRegistry) GetEditor(filename string, content []byte) (models.ConfigEditor, error) {
+	processor, err := r.DetectProcessor(filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	configData, err := models.ProcessNormalized(context.Background(), processor, filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEditorForFormat(configData.Format, content)
+}
+
+// encodingEditor restores the original encoding of edited content
+type encodingEditor struct {
+	models.ConfigEditor
+	info models.EncodingInfo
+}
+
+// Bytes returns the edited content in its original encoding
+func (e *encodingEditor) Bytes() ([]byte, error) {
+	content, err := e.ConfigEditor.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return models.EncodeContent(content, e.info)
+}
+
+// pathNotFound reports a missing key path
+func pathNotFound(path string) error {
+	return fmt.Errorf("%w: %s", models.ErrPathNotFound, path)
+}
+
+// parseEditPath parses a key path for an edit; the empty path is rejected
+func parseEditPath(path string) (models.Path, error) {
+	parsed, err := models.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	return parsed, nil
+}
+
+// flatEditKey converts a key path into the key of a flat format (env, properties):
+// key segments are joined with dots and array indexes are rejected
+func flatEditKey(path string) (string, error) {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, len(parsed))
+	for i, segment := range parsed {
+		if segment.IsIndex {
+			return "", fmt.Errorf("path %s: flat formats have no arrays", path)
+		}
+		keys[i] = segment.Key
+	}
+	return strings.Join(keys, "."), nil
+}
+
+// renamedFlatKey returns the flat key of path after renaming its last segment to newKey
+func renamedFlatKey(path, newKey string) (string, error) {
+	// Guard clause: keys cannot be empty
+	if newKey == "" {
+		return "", fmt.Errorf("new key cannot be empty")
+	}
+
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return "", err
+	}
+	parsed = append(parsed[:len(parsed)-1:len(parsed)-1], models.KeySegment(newKey))
+	return flatEditKey(parsed.String())
+}
+
+// scalarText formats a scalar value as plain text for string-only formats
+func scalarText(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Duration:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("cannot write %T value in a string-only format", value)
+}
+
+// textLines holds file content as lines for line-based editors
+type textLines struct {
+	lines           []string
+	trailingNewline bool
+}
+
+// newTextLines splits content into lines
+func newTextLines(content []byte) *textLines {
+	text := string(content)
+
+	// Guard clause: new files end with a newline
+	if text == "" {
+		return &textLines{trailingNewline: true}
+	}
+
+	return &textLines{
+		lines:           strings.Split(strings.TrimSuffix(text, "\n"), "\n"),
+		trailingNewline: strings.HasSuffix(text, "\n"),
+	}
+}
+
+// bytes joins the lines back into content
+func (t *textLines) bytes() []byte {
+	text := strings.Join(t.lines, "\n")
+	if t.trailingNewline && len(t.lines) > 0 {
+		text += "\n"
+	}
+	return []byte(text)
+}
+
+// replace replaces the lines [from, to) with new lines
+func (t *textLines) replace(from, to int, lines ...string) {
+	updated := make([]string, 0, len(t.lines)-(to-from)+len(lines))
+	updated = append(updated, t.lines[:from]...)
+	updated = append(updated, lines...)
+	updated = append(updated, t.lines[to:]...)
+	t.lines = updated
+}
+
+// insert inserts lines before line index at
+func (t *textLines) insert(at int, lines ...string) {
+	t.replace(at, at, lines...)
+}
+
+// remove removes line ranges [from, to); ranges may overlap
+func (t *textLines) remove(ranges [][2]int) {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	kept := make([]string, 0, len(t.lines))
+	next := 0
+	for _, r := range ranges {
+		if r[0] > next {
+			kept = append(kept, t.lines[next:r[0]]...)
+		}
+		next = max(next, r[1])
+	}
+	t.lines = append(kept, t.lines[next:]...)
+}
+
+// lastContentLine returns the index after the last non-blank line in [from, to), or from
+func (t *textLines) lastContentLine(from, to int) int {
+	for i := to; i > from; i-- {
+		if strings.TrimSpace(t.lines[i-1]) != "" {
+			return i
+		}
+	}
+	return from
+}
+
+// leadingWhitespace returns the indentation of a line
+func leadingWhitespace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
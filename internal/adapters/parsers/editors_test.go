@@ -0,0 +1,321 @@
+package parsers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// editorCase describes one edit and the content it should produce
+type editorCase struct {
+	name     string
+	format   string
+	content  string
+	edit     func(editor models.ConfigEditor) error
+	expected string
+}
+
+// set, remove and rename build edits for editor test cases
+func set(path string, value interface{}) func(models.ConfigEditor) error {
+	return func(editor models.ConfigEditor) error { return editor.Set(path, value) }
+}
+
+func remove(path string) func(models.ConfigEditor) error {
+	return func(editor models.ConfigEditor) error { return editor.Delete(path) }
+}
+
+func rename(path, newKey string) func(models.ConfigEditor) error {
+	return func(editor models.ConfigEditor) error { return editor.Rename(path, newKey) }
+}
+
+// runEditorCases applies every edit and compares the edited content
+func runEditorCases(t *testing.T, tests []editorCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, err := NewEditorForFormat(tt.format, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("NewEditorForFormat() error = %v", err)
+			}
+			if err := tt.edit(editor); err != nil {
+				t.Fatalf("edit error = %v", err)
+			}
+
+			content, err := editor.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("Bytes() =\n%s\nwant\n%s", content, tt.expected)
+			}
+		})
+	}
+}
+
+// TestYAMLEditor tests that YAML edits keep comments, order and quoting
+func TestYAMLEditor(t *testing.T) {
+	content := "# service\nname: \"api\" # quoted\nport: 8080\ndatabase:\n  host: db\n  pool: 5\n"
+
+	runEditorCases(t, []editorCase{
+		{name: "set keeps comments and quotes", format: "yaml", content: content, edit: set("name", "web"),
+			expected: "# service\nname: \"web\" # quoted\nport: 8080\ndatabase:\n  host: db\n  pool: 5\n"},
+		{name: "add nested key", format: "yaml", content: content, edit: set("database.tls.enabled", true),
+			expected: "# service\nname: \"api\" # quoted\nport: 8080\ndatabase:\n  host: db\n  pool: 5\n  tls:\n    enabled: true\n"},
+		{name: "delete", format: "yaml", content: content, edit: remove("database.pool"),
+			expected: "# service\nname: \"api\" # quoted\nport: 8080\ndatabase:\n  host: db\n"},
+		{name: "rename", format: "yaml", content: content, edit: rename("port", "listen_port"),
+			expected: "# service\nname: \"api\" # quoted\nlisten_port: 8080\ndatabase:\n  host: db\n  pool: 5\n"},
+		{name: "append item", format: "yaml", content: "hosts:\n  - a\n", edit: set("hosts[1]", "b"),
+			expected: "hosts:\n  - a\n  - b\n"},
+		{name: "edits the document with a value", format: "yaml", content: "---\n---\na: 1\n", edit: set("a", 2),
+			expected: "---\n---\na: 2\n"},
+		{name: "set in sequence item", format: "yaml", content: "hosts:\n  - name: a\n    port: 1\n", edit: set("hosts[0].port", 2),
+			expected: "hosts:\n  - name: a\n    port: 2\n"},
+		{name: "delete first key of sequence item", format: "yaml", content: "hosts:\n  - name: a\n    port: 1\n", edit: remove("hosts[0].name"),
+			expected: "hosts:\n  - port: 1\n"},
+		{name: "add to flow mapping", format: "yaml", content: "a: 1\nlimits: {cpu: 1}\n", edit: set("limits.memory", "1Gi"),
+			expected: "a: 1\nlimits: {cpu: 1, memory: 1Gi}\n"},
+		{name: "set block scalar", format: "yaml", content: "script: |\n  echo a\n  echo b\nname: x\n", edit: set("script", "run"),
+			expected: "script: run\nname: x\n"},
+		{name: "set keeps anchor", format: "yaml", content: "base: &port 8080\nother: *port\n", edit: set("base", 9090),
+			expected: "base: &port 9090\nother: *port\n"},
+	})
+}
+
+// TestYAMLEditorKeepsLayout tests that YAML edits leave every other line byte for byte
+func TestYAMLEditorKeepsLayout(t *testing.T) {
+	content := "# service\n\nname: api\n\n\ndatabase:\n    host: db   # primary\n\n    pool: 5\n\nhosts: [a, b]\n\n# trailing notes\n#   keep me\n"
+	original := strings.Split(content, "\n")
+
+	tests := []struct {
+		name    string
+		edit    func(editor models.ConfigEditor) error
+		changed map[int]string
+	}{
+		{name: "set", edit: set("database.pool", 10), changed: map[int]string{8: "    pool: 10"}},
+		{name: "set keeps line comment", edit: set("database.host", "db2"), changed: map[int]string{6: "    host: db2   # primary"}},
+		{name: "rename", edit: rename("name", "service"), changed: map[int]string{2: "service: api"}},
+		{name: "set flow item", edit: set("hosts[1]", "c"), changed: map[int]string{10: "hosts: [a, c]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, err := NewEditorForFormat("yaml", []byte(content))
+			if err != nil {
+				t.Fatalf("NewEditorForFormat() error = %v", err)
+			}
+			if err := tt.edit(editor); err != nil {
+				t.Fatalf("edit error = %v", err)
+			}
+
+			edited, err := editor.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+
+			lines := strings.Split(string(edited), "\n")
+			if len(lines) != len(original) {
+				t.Fatalf("Bytes() has %d lines, want %d:\n%s", len(lines), len(original), edited)
+			}
+			for i, line := range lines {
+				want := original[i]
+				if changed, ok := tt.changed[i]; ok {
+					want = changed
+				}
+				if line != want {
+					t.Errorf("line %d = %q, want %q", i+1, line, want)
+				}
+			}
+		})
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		editor, err := NewEditorForFormat("yaml", []byte(content))
+		if err != nil {
+			t.Fatalf("NewEditorForFormat() error = %v", err)
+		}
+		if err := editor.Delete("database.host"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		edited, err := editor.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes() error = %v", err)
+		}
+		expected := strings.Replace(content, "    host: db   # primary\n", "", 1)
+		if string(edited) != expected {
+			t.Errorf("Bytes() =\n%q\nwant\n%q", edited, expected)
+		}
+	})
+}
+
+// TestJSONEditor tests that JSON edits only touch the edited values
+func TestJSONEditor(t *testing.T) {
+	content := "{\n  // service\n  \"name\": \"api\",\n  \"port\": 8080, // listen\n  \"tags\": [\"a\"]\n}\n"
+
+	runEditorCases(t, []editorCase{
+		{name: "set", format: "jsonc", content: content, edit: set("port", 9090),
+			expected: "{\n  // service\n  \"name\": \"api\",\n  \"port\": 9090, // listen\n  \"tags\": [\"a\"]\n}\n"},
+		{name: "add nested key", format: "jsonc", content: content, edit: set("database.host", "db"),
+			expected: "{\n  // service\n  \"name\": \"api\",\n  \"port\": 8080, // listen\n  \"tags\": [\"a\"],\n  \"database\": {\n    \"host\": \"db\"\n  }\n}\n"},
+		{name: "append item", format: "json", content: content, edit: set("tags[1]", "b"),
+			expected: "{\n  // service\n  \"name\": \"api\",\n  \"port\": 8080, // listen\n  \"tags\": [\"a\", \"b\"]\n}\n"},
+		{name: "delete middle", format: "json", content: content, edit: remove("port"),
+			expected: "{\n  // service\n  \"name\": \"api\",\n  \"tags\": [\"a\"]\n}\n"},
+		{name: "delete last", format: "json", content: content, edit: remove("tags"),
+			expected: "{\n  // service\n  \"name\": \"api\",\n  \"port\": 8080 // listen\n}\n"},
+		{name: "rename", format: "json", content: content, edit: rename("name", "service"),
+			expected: "{\n  // service\n  \"service\": \"api\",\n  \"port\": 8080, // listen\n  \"tags\": [\"a\"]\n}\n"},
+		{name: "add to empty object", format: "json", content: "{}\n", edit: set("a", 1),
+			expected: "{\n  \"a\": 1\n}\n"},
+		{name: "json5 trailing comma", format: "json5", content: "{\n  a: 1,\n}\n", edit: set("b", "x"),
+			expected: "{\n  a: 1,\n  \"b\": \"x\",\n}\n"},
+	})
+}
+
+// TestTOMLEditor tests that TOML edits keep comments, spacing and tables
+func TestTOMLEditor(t *testing.T) {
+	content := "title = \"app\" # name\n\n[server]\nhost   = \"localhost\"\nport = 8080\n\n[[workers]]\nname = \"a\"\n\n[[workers]]\nname = \"b\"\n"
+
+	runEditorCases(t, []editorCase{
+		{name: "set keeps spacing and comment", format: "toml", content: "title = \"app\" # name\n", edit: set("title", "api"),
+			expected: "title = \"api\" # name\n"},
+		{name: "set in array table", format: "toml", content: content, edit: set("workers[1].name", "c"),
+			expected: "title = \"app\" # name\n\n[server]\nhost   = \"localhost\"\nport = 8080\n\n[[workers]]\nname = \"a\"\n\n[[workers]]\nname = \"c\"\n"},
+		{name: "add key to table", format: "toml", content: content, edit: set("server.timeout", 1.5),
+			expected: "title = \"app\" # name\n\n[server]\nhost   = \"localhost\"\nport = 8080\ntimeout = 1.5\n\n[[workers]]\nname = \"a\"\n\n[[workers]]\nname = \"b\"\n"},
+		{name: "add table", format: "toml", content: "[server]\nport = 8080\n", edit: set("database", map[string]interface{}{"host": "db", "pool": 5}),
+			expected: "[server]\nport = 8080\n\n[database]\nhost = \"db\"\npool = 5\n"},
+		{name: "delete table", format: "toml", content: content, edit: remove("server"),
+			expected: "title = \"app\" # name\n\n[[workers]]\nname = \"a\"\n\n[[workers]]\nname = \"b\"\n"},
+		{name: "rename table", format: "toml", content: "[server]\nport = 8080\n\n[server.tls]\nenabled = true\n", edit: rename("server", "http"),
+			expected: "[http]\nport = 8080\n\n[http.tls]\nenabled = true\n"},
+		{name: "rename dotted key", format: "toml", content: "a.b = 1\n", edit: rename("a.b", "c"),
+			expected: "a.c = 1\n"},
+	})
+}
+
+// TestINIEditor tests that INI edits keep sections, separators and comments
+func TestINIEditor(t *testing.T) {
+	content := "; settings\n[server]\nhost: localhost ; default\nport = 8080\n\n[remote \"origin\"]\nurl = a\n"
+
+	runEditorCases(t, []editorCase{
+		{name: "set keeps separator and comment", format: "ini", content: content, edit: set("server.host", "0.0.0.0"),
+			expected: "; settings\n[server]\nhost: 0.0.0.0 ; default\nport = 8080\n\n[remote \"origin\"]\nurl = a\n"},
+		{name: "add key", format: "ini", content: content, edit: set("server.debug", true),
+			expected: "; settings\n[server]\nhost: localhost ; default\nport = 8080\ndebug = true\n\n[remote \"origin\"]\nurl = a\n"},
+		{name: "add section", format: "ini", content: content, edit: set("cache.ttl", 60),
+			expected: "; settings\n[server]\nhost: localhost ; default\nport = 8080\n\n[remote \"origin\"]\nurl = a\n\n[cache]\nttl = 60\n"},
+		{name: "delete key", format: "ini", content: content, edit: remove("server.port"),
+			expected: "; settings\n[server]\nhost: localhost ; default\n\n[remote \"origin\"]\nurl = a\n"},
+		{name: "rename subsection", format: "ini", content: content, edit: rename("remote.origin", "upstream"),
+			expected: "; settings\n[server]\nhost: localhost ; default\nport = 8080\n\n[remote \"upstream\"]\nurl = a\n"},
+		{name: "set repeated key", format: "ini", content: "[a]\nx = 1\nx = 2\n", edit: set("a.x[1]", 3),
+			expected: "[a]\nx = 1\nx = 3\n"},
+	})
+}
+
+// TestFlatEditors tests the dotenv and properties editors
+func TestFlatEditors(t *testing.T) {
+	runEditorCases(t, []editorCase{
+		{name: "env set keeps export and comment", format: "env", content: "# app\nexport PORT=8080 # listen\n", edit: set("PORT", 9090),
+			expected: "# app\nexport PORT=9090 # listen\n"},
+		{name: "env quotes values", format: "env", content: "A=1\n", edit: set("B", "hello world"),
+			expected: "A=1\nB=\"hello world\"\n"},
+		{name: "env delete", format: "env", content: "A=1\nB=2\n", edit: remove("A"),
+			expected: "B=2\n"},
+		{name: "env rename", format: "env", content: "export A=1\n", edit: rename("A", "B"),
+			expected: "export B=1\n"},
+		{name: "properties set keeps separator", format: "properties", content: "! app\nserver.port : 8080\n", edit: set("server.port", 9090),
+			expected: "! app\nserver.port : 9090\n"},
+		{name: "properties add", format: "properties", content: "a: 1\n", edit: set("b.c", "x y"),
+			expected: "a: 1\nb.c: x y\n"},
+		{name: "properties continuation", format: "properties", content: "a = one \\\n    two\nb = 2\n", edit: set("a", "x"),
+			expected: "a = x\nb = 2\n"},
+		{name: "properties rename", format: "properties", content: "server.port=1\n", edit: rename("server.port", "listen"),
+			expected: "server.listen=1\n"},
+	})
+}
+
+// TestEditorErrors tests missing paths, unsupported formats and invalid edits
+func TestEditorErrors(t *testing.T) {
+	contents := map[string]string{
+		"yaml":       "a: 1\n",
+		"json":       "{\"a\": 1}\n",
+		"toml":       "a = 1\n",
+		"ini":        "a = 1\n",
+		"env":        "A=1\n",
+		"properties": "a=1\n",
+	}
+
+	for format, content := range contents {
+		editor, err := NewEditorForFormat(format, []byte(content))
+		if err != nil {
+			t.Fatalf("NewEditorForFormat(%s) error = %v", format, err)
+		}
+		if err := editor.Delete("missing"); !errors.Is(err, models.ErrPathNotFound) {
+			t.Errorf("%s Delete() error = %v, want ErrPathNotFound", format, err)
+		}
+		if err := editor.Rename("missing", "other"); !errors.Is(err, models.ErrPathNotFound) {
+			t.Errorf("%s Rename() error = %v, want ErrPathNotFound", format, err)
+		}
+	}
+
+	if _, err := NewEditorForFormat("xml", []byte("<a/>")); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("NewEditorForFormat(xml) error = %v, want unsupported", err)
+	}
+	if _, err := NewEditorForFormat("yaml", []byte("a: 1\n---\nb: 2\n")); err == nil || !strings.Contains(err.Error(), "2 documents") {
+		t.Errorf("NewEditorForFormat() with two YAML documents error = %v, want an error", err)
+	}
+	if _, err := NewEditorForFormat("json", []byte("{")); err == nil {
+		t.Error("NewEditorForFormat() with invalid content should fail")
+	}
+
+	editor, _ := NewEditorForFormat("toml", []byte("a = { b = 1 }\n"))
+	if err := editor.Set("a.b", 2); err == nil || !strings.Contains(err.Error(), "inline value") {
+		t.Errorf("TOML Set() inside inline table error = %v", err)
+	}
+	if err := editor.Set("c", nil); err == nil {
+		t.Error("TOML Set() with nil should fail")
+	}
+}
+
+// TestEditorEncoding tests that edits keep the byte order mark and line endings
+func TestEditorEncoding(t *testing.T) {
+	content := "\xEF\xBB\xBFa = 1\r\nb = 2\r\n"
+
+	editor, err := NewEditorForFormat("toml", []byte(content))
+	if err != nil {
+		t.Fatalf("NewEditorForFormat() error = %v", err)
+	}
+	if err := editor.Set("b", 3); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	edited, err := editor.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if expected := "\xEF\xBB\xBFa = 1\r\nb = 3\r\n"; string(edited) != expected {
+		t.Errorf("Bytes() = %q, want %q", edited, expected)
+	}
+}
+
+// TestRegistryGetEditor tests that editors are selected by detected format
+func TestRegistryGetEditor(t *testing.T) {
+	registry := NewParserRegistry()
+
+	editor, err := registry.GetEditor("settings.ini", []byte("[server]\nport = 8080\n"))
+	if err != nil {
+		t.Fatalf("GetEditor() error = %v", err)
+	}
+	if err := editor.Set("server.port", 9090); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if content, _ := editor.Bytes(); !strings.Contains(string(content), "9090") {
+		t.Errorf("Bytes() = %s, want the edited port", content)
+	}
+}
@@ -0,0 +1,207 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// envEditor edits dotenv files line by line, keeping comments, "export" prefixes and
+// inline comments
+type envEditor struct {
+	text *textLines
+}
+
+// envEntry locates an assignment: lines [start, end), the text before its value and,
+// for single-line values, the inline comment after it
+type envEntry struct {
+	key     string
+	start   int
+	end     int
+	prefix  string
+	comment string
+	bare    bool
+
+	keyOffset int
+}
+
+// newENVEditor creates an editor for dotenv content
+func newENVEditor(content []byte) (models.ConfigEditor, error) {
+	if _, _, err := parseENVContent(content, nil); err != nil {
+		return nil, err
+	}
+	return &envEditor{text: newTextLines(content)}, nil
+}
+
+// Set replaces the value of a variable (every assignment of it), or appends it
+func (e *envEditor) Set(path string, value interface{}) error {
+	key, err := flatEditKey(path)
+	if err != nil {
+		return err
+	}
+	if !envKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid variable name %q", key)
+	}
+
+	text, err := formatENVValue(value)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	entries := e.find(key)
+	if len(entries) == 0 {
+		e.text.insert(len(e.text.lines), formatENVLine(key+"=", value, text, ""))
+		return nil
+	}
+
+	// Later assignments win, so every assignment is updated (last first to keep indexes valid)
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		prefix := entry.prefix
+		if entry.bare {
+			prefix += "="
+		}
+		e.text.replace(entry.start, entry.end, formatENVLine(prefix, value, text, entry.comment))
+	}
+	return nil
+}
+
+// Delete removes every assignment of a variable
+func (e *envEditor) Delete(path string) error {
+	key, err := flatEditKey(path)
+	if err != nil {
+		return err
+	}
+
+	entries := e.find(key)
+	if len(entries) == 0 {
+		return pathNotFound(path)
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e.text.replace(entries[i].start, entries[i].end)
+	}
+	return nil
+}
+
+// Rename renames every assignment of a variable
+func (e *envEditor) Rename(path, newKey string) error {
+	key, err := flatEditKey(path)
+	if err != nil {
+		return err
+	}
+	if !envKeyPattern.MatchString(newKey) {
+		return fmt.Errorf("invalid variable name %q", newKey)
+	}
+
+	entries := e.find(key)
+	if len(entries) == 0 {
+		return pathNotFound(path)
+	}
+	if len(e.find(newKey)) > 0 {
+		return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+	}
+
+	for _, entry := range entries {
+		line := e.text.lines[entry.start]
+		e.text.lines[entry.start] = line[:entry.keyOffset] + newKey + line[entry.keyOffset+len(key):]
+	}
+	return nil
+}
+
+// Bytes returns the edited content
+func (e *envEditor) Bytes() ([]byte, error) {
+	return e.text.bytes(), nil
+}
+
+// find returns the assignments of a variable in source order
+func (e *envEditor) find(key string) []envEntry {
+	var entries []envEntry
+	lines := e.text.lines
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || isENVComment(trimmed) {
+			continue
+		}
+
+		indent := leadingWhitespace(line)
+		assignment := trimmed
+		if rest := strings.TrimPrefix(assignment, "export"); rest != assignment && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			assignment = strings.TrimLeft(rest, " \t")
+		}
+		exportPrefix := trimmed[:len(trimmed)-len(assignment)]
+
+		separator := strings.IndexByte(assignment, '=')
+		entry := envEntry{start: i, end: i + 1, keyOffset: len(indent) + len(exportPrefix)}
+		if separator == -1 {
+			entry.key = assignment
+			entry.prefix = indent + exportPrefix + assignment
+			entry.bare = true
+		} else {
+			entry.key = strings.TrimSpace(assignment[:separator])
+			rawValue := assignment[separator+1:]
+			valueStart := len(rawValue) - len(strings.TrimLeft(rawValue, " \t"))
+			entry.prefix = indent + exportPrefix + assignment[:separator+1] + rawValue[:valueStart]
+			entry.end, entry.comment = envValueExtent(lines, i, rawValue[valueStart:])
+		}
+
+		if entry.key == key {
+			entries = append(entries, entry)
+		}
+		i = entry.end - 1
+	}
+
+	return entries
+}
+
+// envValueExtent returns the end line of a value starting on line i and, for values on a
+// single line, the inline comment that follows it
+func envValueExtent(lines []string, i int, rawValue string) (int, string) {
+	// Unquoted values end at an inline comment
+	if rawValue == "" || (rawValue[0] != '"' && rawValue[0] != '\'') {
+		value := strings.TrimRight(stripENVInlineComment(rawValue), " \t")
+		return i + 1, rawValue[len(value):]
+	}
+
+	quote := rawValue[0]
+	body := rawValue[1:]
+	last := i
+	closing := findClosingQuote(body, quote)
+	for closing == -1 && last+1 < len(lines) {
+		last++
+		body += "\n" + lines[last]
+		closing = findClosingQuote(body, quote)
+	}
+
+	if closing == -1 || last != i {
+		return last + 1, ""
+	}
+	return i + 1, body[closing+1:]
+}
+
+// formatENVLine builds an assignment line
+func formatENVLine(prefix string, value interface{}, text, comment string) string {
+	// A nil value declares the variable without a value
+	if value == nil {
+		return strings.TrimRight(strings.TrimSuffix(strings.TrimRight(prefix, " \t"), "="), " \t") + comment
+	}
+	return prefix + text + comment
+}
+
+// formatENVValue formats a value, double-quoting it when it is not a plain word
+func formatENVValue(value interface{}) (string, error) {
+	text, err := scalarText(value)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.ContainsAny(text, " \t\n\r#\"'\\$") {
+		return text, nil
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "$", `\$`)
+	return `"` + replacer.Replace(text) + `"`, nil
+}
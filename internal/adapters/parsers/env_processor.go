@@ -2,21 +2,37 @@ package parsers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
+// ENVOptions configures how ENV files are parsed
+type ENVOptions struct {
+	// LookupEnv resolves references to variables the file does not define (e.g.
+	// os.LookupEnv). Without it they are unset, so a file parses the same on every machine.
+	LookupEnv func(name string) (string, bool)
+}
+
 // ENVProcessor implements FileProcessor for ENV files
 type ENVProcessor struct {
 	supportedExtensions []string
+	options             ENVOptions
 }
 
 // NewENVProcessor creates a new ENV processor
 func NewENVProcessor() *ENVProcessor {
+	return NewENVProcessorWithOptions(ENVOptions{})
+}
+
+// NewENVProcessorWithOptions creates a new ENV processor with the given options
+func NewENVProcessorWithOptions(options ENVOptions) *ENVProcessor {
 	return &ENVProcessor{
 		supportedExtensions: []string{"env"},
+		options:             options,
 	}
 }
 
@@ -31,12 +47,12 @@ func (p *ENVProcessor) Process(ctx context.Context, filename string, content []b
 		return nil, err
 	}
 
-	data, err := parseENVContent(content)
+	data, positions, err := parseENVContent(content, p.options.LookupEnv)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ENV: %w", err)
 	}
 
-	return createConfigData(filename, "env", data), nil
+	return createConfigData(filename, "env", data, positions), nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -44,14 +60,315 @@ func (p *ENVProcessor) GetSupportedExtensions() []string {
 	return copyExtensions(p.supportedExtensions)
 }
 
-// parseENVContent parses ENV content
-func parseENVContent(content []byte) (map[string]interface{}, error) {
+// envKeyPattern matches valid variable names (dots and dashes are accepted like docker compose)
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-]*$`)
+
+// parseENVContent parses dotenv content with docker compose compatible semantics:
+// optional "export" prefixes, inline comments after unquoted values, literal single-quoted
+// values, escape sequences in double-quoted values, multi-line quoted values and
+// ${VAR} expansion. References to variables the file does not define are resolved with
+// lookupEnv, when given. Malformed lines are reported with their line numbers.
+func parseENVContent(content []byte, lookupEnv func(string) (string, bool)) (map[string]interface{}, map[string]models.Position, error) {
 	// Guard clause: empty content
 	if isEmptyContent(content) {
-		return createEmptyResult(), nil
+		return createEmptyResult(), createEmptyPositions(), nil
+	}
+
+	parser := &envParser{
+		lines:     splitLines(content),
+		values:    createEmptyResult(),
+		positions: createEmptyPositions(),
+		lookupEnv: lookupEnv,
+	}
+
+	return parser.values, parser.positions, parser.parse()
+}
+
+// envParser parses dotenv lines, keeping track of variables defined so far
+type envParser struct {
+	lines      []string
+	values     map[string]interface{}
+	positions  map[string]models.Position
+	duplicates []string
+	lookupEnv  func(string) (string, bool)
+}
+
+// parse parses every line, collecting errors for malformed lines
+func (p *envParser) parse() error {
+	var errs []error
+
+	for i := 0; i < len(p.lines); i++ {
+		next, err := p.parseLine(i)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		i = next
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseLine parses the assignment starting at line index i and returns the index of
+// the last line it consumed (quoted values may span several lines)
+func (p *envParser) parseLine(i int) (int, error) {
+	line := p.lines[i]
+	trimmedLine := trimLine(line)
+
+	// Skip empty lines and comments
+	if trimmedLine == "" || isENVComment(trimmedLine) {
+		return i, nil
+	}
+
+	assignment := trimmedLine
+	if rest := strings.TrimPrefix(assignment, "export"); rest != assignment && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+		assignment = strings.TrimLeft(rest, " \t")
+	}
+	position := linePosition(i+1, line)
+	position.Column += len(trimmedLine) - len(assignment)
+
+	separator := strings.IndexByte(assignment, '=')
+	key := strings.TrimSpace(assignment)
+	if separator != -1 {
+		key = strings.TrimSpace(assignment[:separator])
+	}
+
+	if !envKeyPattern.MatchString(key) {
+		return i, &LineError{Line: i + 1, Message: fmt.Sprintf("invalid variable name %q", key)}
+	}
+
+	// A bare variable name declares the variable without a value
+	if separator == -1 {
+		p.set(key, nil, position)
+		return i, nil
+	}
+
+	rawValue := strings.TrimLeft(assignment[separator+1:], " \t")
+	value, last, err := p.parseValue(rawValue, i)
+	if err != nil {
+		return last, err
+	}
+
+	p.set(key, value, position)
+	return last, nil
+}
+
+// parseValue parses the value of an assignment starting at line index i
+func (p *envParser) parseValue(rawValue string, i int) (string, int, error) {
+	// Guard clause: unquoted values end at an inline comment
+	if rawValue == "" || (rawValue[0] != '"' && rawValue[0] != '\'') {
+		value := strings.TrimSpace(stripENVInlineComment(rawValue))
+		expanded, err := expandENVValue(value, p.lookup)
+		if err != nil {
+			return "", i, &LineError{Line: i + 1, Message: err.Error()}
+		}
+		return expanded, i, nil
+	}
+
+	quote := rawValue[0]
+	body := rawValue[1:]
+	last := i
+
+	// Quoted values continue on the following lines until the closing quote
+	closing := findClosingQuote(body, quote)
+	for closing == -1 {
+		if last+1 >= len(p.lines) {
+			return "", last, &LineError{Line: i + 1, Message: fmt.Sprintf("unterminated %c-quoted value", quote)}
+		}
+		last++
+		body += "\n" + p.lines[last]
+		closing = findClosingQuote(body, quote)
+	}
+
+	trailing := strings.TrimSpace(body[closing+1:])
+	if trailing != "" && !isENVComment(trailing) {
+		return "", last, &LineError{Line: last + 1, Message: fmt.Sprintf("unexpected characters after quoted value: %q", trailing)}
+	}
+
+	// Single-quoted values are literal
+	if quote == '\'' {
+		return body[:closing], last, nil
+	}
+
+	expanded, err := expandENVValue(unescapeENVValue(body[:closing]), p.lookup)
+	if err != nil {
+		return "", last, &LineError{Line: i + 1, Message: err.Error()}
+	}
+	return expanded, last, nil
+}
+
+// set stores a variable and its position
+func (p *envParser) set(key string, value interface{}, position models.Position) {
+	if _, exists := p.values[key]; exists {
+		p.duplicates = append(p.duplicates, key)
+	}
+	p.values[key] = value
+	p.positions[models.JoinPath("", key)] = position
+}
+
+// lookup resolves a variable from the file first, then with lookupEnv
+func (p *envParser) lookup(name string) (string, bool) {
+	if value, ok := p.values[name].(string); ok {
+		return value, true
+	}
+	if p.lookupEnv == nil {
+		return "", false
+	}
+	return p.lookupEnv(name)
+}
+
+// findClosingQuote returns the index of the closing quote, skipping escaped double quotes
+func findClosingQuote(body string, quote byte) int {
+	for i := 0; i < len(body); i++ {
+		if quote == '"' && body[i] == '\\' {
+			i++
+			continue
+		}
+		if body[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+// stripENVInlineComment removes a " #" comment from an unquoted value
+func stripENVInlineComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return value[:i]
+		}
+	}
+	return value
+}
+
+// envEscapes maps the escape sequences supported in double-quoted values.
+// \$ is kept escaped until expansion so that it produces a literal dollar sign.
+var envEscapes = map[byte]string{'n': "\n", 'r': "\r", 't': "\t", '"': `"`, '\\': `\`, '$': "$$"}
+
+// unescapeENVValue interprets escape sequences of a double-quoted value
+func unescapeENVValue(value string) string {
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			if replacement, ok := envEscapes[value[i+1]]; ok {
+				result.WriteString(replacement)
+				i++
+				continue
+			}
+		}
+		result.WriteByte(value[i])
+	}
+	return result.String()
+}
+
+// expandENVValue expands $VAR, ${VAR}, ${VAR:-default}, ${VAR-default}, ${VAR:+alt},
+// ${VAR+alt}, ${VAR:?error} and ${VAR?error}; $$ produces a literal dollar sign
+func expandENVValue(value string, lookup func(string) (string, bool)) (string, error) {
+	var result strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		switch next := value[i+1]; {
+		case next == '$':
+			result.WriteByte('$')
+			i++
+		case next == '{':
+			end := findClosingBrace(value[i:])
+			if end == -1 {
+				return "", fmt.Errorf("unterminated variable reference in %q", value)
+			}
+			expanded, err := expandENVReference(value[i+2:i+end], lookup)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(expanded)
+			i += end
+		case next == '_' || isASCIILetter(next):
+			end := i + 1
+			for end < len(value) && (value[end] == '_' || isASCIILetter(value[end]) || (value[end] >= '0' && value[end] <= '9')) {
+				end++
+			}
+			resolved, _ := lookup(value[i+1 : end])
+			result.WriteString(resolved)
+			i = end - 1
+		default:
+			result.WriteByte('$')
+		}
+	}
+
+	return result.String(), nil
+}
+
+// findClosingBrace returns the index of the brace closing the ${ at the start of value,
+// skipping nested braces (${A:-${B}}), or -1
+func findClosingBrace(value string) int {
+	depth := 0
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
 	}
+	return -1
+}
+
+// expandENVReference expands the inside of a ${...} reference. The operator is the first
+// one after the variable name, so that nested references may use operators too.
+func expandENVReference(reference string, lookup func(string) (string, bool)) (string, error) {
+	for index := 1; index < len(reference); index++ {
+		operator := envOperatorAt(reference, index)
+		if operator == "" {
+			continue
+		}
+
+		name, argument := reference[:index], reference[index+len(operator):]
+		value, set := lookup(name)
+		usable := set && (value != "" || !strings.HasPrefix(operator, ":"))
+
+		switch strings.TrimPrefix(operator, ":") {
+		case "-":
+			if usable {
+				return value, nil
+			}
+			return expandENVValue(argument, lookup)
+		case "+":
+			if usable {
+				return expandENVValue(argument, lookup)
+			}
+			return "", nil
+		default:
+			if usable {
+				return value, nil
+			}
+			return "", fmt.Errorf("required variable %s is not set: %s", name, argument)
+		}
+	}
+
+	value, _ := lookup(reference)
+	return value, nil
+}
+
+// envOperatorAt returns the expansion operator (:-, :+, :?, -, + or ?) at index, or ""
+func envOperatorAt(reference string, index int) string {
+	for _, operator := range []string{":-", ":+", ":?", "-", "+", "?"} {
+		if strings.HasPrefix(reference[index:], operator) {
+			return operator
+		}
+	}
+	return ""
+}
 
-	return parseKeyValueContent(content, isENVComment)
+// isASCIILetter checks if a byte is an ASCII letter
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
 }
 
 // isENVComment checks if a line is a comment in ENV format
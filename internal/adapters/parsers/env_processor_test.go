@@ -0,0 +1,151 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestENVProcessor tests dotenv quoting, comments, exports and expansion
+func TestENVProcessor(t *testing.T) {
+	environment := map[string]string{"PRAETORIAN_ENV_HOME": "/home/app"}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := environment[name]
+		return value, ok
+	}
+
+	content := []byte(`# Database settings
+export DB_HOST=localhost
+DB_PORT = 5432 # inline comment
+DB_URL=postgres://${DB_HOST}:${DB_PORT}/app
+PASSWORD=abc#123
+LITERAL='${DB_HOST} stays # as is'
+MESSAGE="line one\nline \"two\"\tend"
+CERT="-----BEGIN-----
+abc
+-----END-----" # trailing comment
+HOME_DIR=$PRAETORIAN_ENV_HOME/data
+FALLBACK=${PRAETORIAN_ENV_UNSET:-default}
+ALTERNATE=${DB_HOST:+set}
+NESTED=${PRAETORIAN_ENV_UNSET:-${DB_HOST:-none}}/db
+OPTIONAL=${DB_HOST:+${PRAETORIAN_ENV_UNSET-{unset}}}
+PRICE="\$5 and $$6"
+EMPTY=
+DECLARED
+`)
+
+	configData, err := NewENVProcessorWithOptions(ENVOptions{LookupEnv: lookupEnv}).Process(context.Background(), "app.env", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"DB_HOST":   "localhost",
+		"DB_PORT":   "5432",
+		"DB_URL":    "postgres://localhost:5432/app",
+		"PASSWORD":  "abc#123",
+		"LITERAL":   "${DB_HOST} stays # as is",
+		"MESSAGE":   "line one\nline \"two\"\tend",
+		"CERT":      "-----BEGIN-----\nabc\n-----END-----",
+		"HOME_DIR":  "/home/app/data",
+		"FALLBACK":  "default",
+		"ALTERNATE": "set",
+		"NESTED":    "localhost/db",
+		"OPTIONAL":  "{unset}",
+		"PRICE":     "$5 and $6",
+		"EMPTY":     "",
+		"DECLARED":  nil,
+	}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+
+	if position, ok := configData.PositionOf("DB_HOST"); !ok || position.Line != 2 || position.Column != 8 {
+		t.Errorf("PositionOf(DB_HOST) = %v, %v, want line 2 column 8", position, ok)
+	}
+	if position, ok := configData.PositionOf("HOME_DIR"); !ok || position.Line != 11 {
+		t.Errorf("PositionOf(HOME_DIR) = %v, %v, want line 11", position, ok)
+	}
+}
+
+// TestENVProcessorEnvironment tests that the process environment is only used when enabled
+func TestENVProcessorEnvironment(t *testing.T) {
+	t.Setenv("PRAETORIAN_ENV_HOME", "/home/app")
+	content := []byte("HOME_DIR=${PRAETORIAN_ENV_HOME:-/nowhere}/data\n")
+
+	tests := []struct {
+		name     string
+		options  map[string]interface{}
+		expected string
+	}{
+		{"ignores the environment by default", nil, "/nowhere/data"},
+		{"expands from the environment when enabled", map[string]interface{}{"expand_environment": true}, "/home/app/data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewProcessorForFormat("env", tt.options)
+			if err != nil {
+				t.Fatalf("NewProcessorForFormat() error = %v", err)
+			}
+			configData, err := processor.Process(context.Background(), "app.env", content)
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if configData.Data["HOME_DIR"] != tt.expected {
+				t.Errorf("HOME_DIR = %v, want %q", configData.Data["HOME_DIR"], tt.expected)
+			}
+		})
+	}
+}
+
+// TestENVProcessorMalformedLines tests that every malformed line is reported with its line number
+func TestENVProcessorMalformedLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "invalid names",
+			content:  "GOOD=1\n1BAD=2\nALSO BAD=3\n",
+			expected: []string{"line 2: invalid variable name", "line 3: invalid variable name"},
+		},
+		{
+			name:     "unterminated quote",
+			content:  "A=1\nB=\"open\nC=2\n",
+			expected: []string{"line 2: unterminated \"-quoted value"},
+		},
+		{
+			name:     "garbage after quote",
+			content:  "A='value' extra\n",
+			expected: []string{"line 1: unexpected characters after quoted value"},
+		},
+		{
+			name:     "required variable",
+			content:  "A=${PRAETORIAN_ENV_UNSET:?must be set}\n",
+			expected: []string{"line 1: required variable PRAETORIAN_ENV_UNSET is not set: must be set"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewENVProcessor().Process(context.Background(), "bad.env", []byte(tt.content))
+			if err == nil {
+				t.Fatal("Process() expected error")
+			}
+
+			var lineErr *LineError
+			if !errors.As(err, &lineErr) {
+				t.Errorf("Process() error = %v, want a LineError", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("Process() error = %q, want it to contain %q", err, expected)
+				}
+			}
+		})
+	}
+}
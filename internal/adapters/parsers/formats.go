@@ -0,0 +1,286 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// formatFactories creates processors by format name (as used in praetorian.yaml)
+var formatFactories = map[string]func(options formatOptions) (models.FileProcessor, error){
+	"yaml": func(options formatOptions) (models.FileProcessor, error) { return NewYAMLProcessor(), options.done() },
+	"json": func(options formatOptions) (models.FileProcessor, error) { return NewJSONProcessor(), options.done() },
+	"jsonc": func(options formatOptions) (models.FileProcessor, error) {
+		return NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSONC}), options.done()
+	},
+	"json5": func(options formatOptions) (models.FileProcessor, error) {
+		return NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSON5}), options.done()
+	},
+	"toml":       func(options formatOptions) (models.FileProcessor, error) { return NewTOMLProcessor(), options.done() },
+	"hcl":        func(options formatOptions) (models.FileProcessor, error) { return NewHCLProcessor(), options.done() },
+	"xml":        func(options formatOptions) (models.FileProcessor, error) { return NewXMLProcessor(), options.done() },
+	"env":        newENVProcessorFromOptions,
+	"hocon":      func(options formatOptions) (models.FileProcessor, error) { return NewHOCONProcessor(), options.done() },
+	"properties": newPropertiesProcessorFromOptions,
+	"tfvars":     newTFVarsProcessorFromOptions,
+	"ini":        newINIProcessorFromOptions,
+}
+
+// formatAliases maps alternative format names to their canonical name
+var formatAliases = map[string]string{
+	"yml":       "yaml",
+	"dotenv":    "env",
+	"terraform": "hcl",
+	"tf":        "hcl",
+	"conf":      "hocon",
+}
+
+// templateSuffixes are stripped from filenames before looking up the extension
+// (application-prod.yml.j2 is processed as YAML)
+var templateSuffixes = []string{"j2", "jinja", "jinja2", "tmpl", "tpl", "template", "dist", "example", "sample", "in"}
+
+// builtinFilenamePatterns map well-known extensionless or oddly named files to formats.
+// They are consulted after the extension lookup, so env.json is still JSON.
+var builtinFilenamePatterns = []struct {
+	pattern string
+	format  string
+}{
+	{pattern: ".env.*", format: "env"},
+	{pattern: "*.env.*", format: "env"},
+	{pattern: "env.*", format: "env"},
+	{pattern: ".env", format: "env"},
+}
+
+// NewProcessorForFormat creates a processor for a format name with format-specific options.
+// Supported options: properties "expand_keys" (bool); ini "duplicate_sections" ("merge" or
+// "error") and "case_insensitive" (bool); tfvars "variables" (path to variables.tf); env
+// "expand_environment" (bool).
+func NewProcessorForFormat(format string, options map[string]interface{}) (models.FileProcessor, error) {
+	name := normalizeFormat(format)
+
+	factory, ok := formatFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (supported: %s)", format, strings.Join(SupportedFormats(), ", "))
+	}
+
+	processor, err := factory(formatOptions{format: name, values: options, used: make(map[string]bool)})
+	if err != nil {
+		return nil, err
+	}
+
+	return processor, nil
+}
+
+// SupportedFormats returns the sorted names of all formats
+func SupportedFormats() []string {
+	formats := make([]string, 0, len(formatFactories))
+	for format := range formatFactories {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// normalizeFormat lowercases a format name and resolves aliases
+func normalizeFormat(format string) string {
+	name := strings.ToLower(strings.TrimSpace(format))
+	if canonical, ok := formatAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// newPropertiesProcessorFromOptions creates a Properties processor from format options
+func newPropertiesProcessorFromOptions(options formatOptions) (models.FileProcessor, error) {
+	expandKeys, err := options.bool("expand_keys")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPropertiesProcessorWithOptions(PropertiesOptions{ExpandKeys: expandKeys}), options.done()
+}
+
+// newENVProcessorFromOptions creates an ENV processor from format options
+func newENVProcessorFromOptions(options formatOptions) (models.FileProcessor, error) {
+	expandEnvironment, err := options.bool("expand_environment")
+	if err != nil {
+		return nil, err
+	}
+
+	envOptions := ENVOptions{}
+	if expandEnvironment {
+		envOptions.LookupEnv = os.LookupEnv
+	}
+	return NewENVProcessorWithOptions(envOptions), options.done()
+}
+
+// newTFVarsProcessorFromOptions creates a Terraform variable file processor from format options
+func newTFVarsProcessorFromOptions(options formatOptions) (models.FileProcessor, error) {
+	variables, err := options.string("variables")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTFVarsProcessorWithOptions(TFVarsOptions{Variables: variables}), options.done()
+}
+
+// newINIProcessorFromOptions creates an INI processor from format options
+func newINIProcessorFromOptions(options formatOptions) (models.FileProcessor, error) {
+	caseInsensitive, err := options.bool("case_insensitive")
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates, err := options.string("duplicate_sections")
+	if err != nil {
+		return nil, err
+	}
+
+	mode := INIDuplicateSections(duplicates)
+	if mode != "" && mode != INIDuplicateSectionsMerge && mode != INIDuplicateSectionsError {
+		return nil, fmt.Errorf("invalid duplicate_sections %q for format ini (expected merge or error)", duplicates)
+	}
+
+	return NewINIProcessorWithOptions(INIOptions{DuplicateSections: mode, CaseInsensitive: caseInsensitive}), options.done()
+}
+
+// formatOptions reads typed format options, tracking which ones were consumed
+type formatOptions struct {
+	format string
+	values map[string]interface{}
+	used   map[string]bool
+}
+
+// bool reads a boolean option (false when absent)
+func (o formatOptions) bool(name string) (bool, error) {
+	o.used[name] = true
+	value, ok := o.values[name]
+	if !ok {
+		return false, nil
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("option %s for format %s must be a boolean", name, o.format)
+	}
+	return b, nil
+}
+
+// string reads a string option (empty when absent)
+func (o formatOptions) string(name string) (string, error) {
+	o.used[name] = true
+	value, ok := o.values[name]
+	if !ok {
+		return "", nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("option %s for format %s must be a string", name, o.format)
+	}
+	return s, nil
+}
+
+// done reports options that are not supported by the format
+func (o formatOptions) done() error {
+	var unknown []string
+	for name := range o.values {
+		if !o.used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	// Guard clause: every option was consumed
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown options for format %s: %s", o.format, strings.Join(unknown, ", "))
+}
+
+// stripTemplateSuffix removes a known template suffix from a filename
+func stripTemplateSuffix(filename string) (string, bool) {
+	ext := GetFileExtension(filename)
+	for _, suffix := range templateSuffixes {
+		if ext == suffix {
+			return strings.TrimSuffix(filename, filepath.Ext(filename)), true
+		}
+	}
+	return filename, false
+}
+
+// Line shapes used by content sniffing
+var (
+	sniffSectionPattern = regexp.MustCompile(`^\[\[?[^\]=]+\]\]?\s*([;#].*)?$`)
+	sniffENVPattern     = regexp.MustCompile(`^(export\s+)?[A-Z_][A-Z0-9_]*=`)
+	sniffYAMLPattern    = regexp.MustCompile(`^(---|-\s|[\w.\-"']+:(\s|$))`)
+	sniffBlockPattern   = regexp.MustCompile(`^[\w\-]+(\s+"[^"]*")*\s*\{\s*$`)
+	sniffAssignPattern  = regexp.MustCompile(`^[\w.\-]+\s*[=:]`)
+)
+
+// SniffFormat guesses the format of content without a usable filename.
+// It returns an empty string when the content does not look like any supported format.
+func SniffFormat(content []byte) string {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(content, []byte("\uFEFF")))
+
+	// Guard clause: nothing to sniff
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch {
+	case trimmed[0] == '<':
+		return "xml"
+	case (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed):
+		return "json"
+	}
+
+	var sections, env, yamlLines, blocks, assignments int
+	for _, line := range splitLines(trimmed) {
+		line = trimLine(line)
+		if line == "" || isEmptyLine(line) || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case sniffSectionPattern.MatchString(line):
+			sections++
+		case sniffBlockPattern.MatchString(line):
+			blocks++
+		case sniffENVPattern.MatchString(line):
+			env++
+		case sniffYAMLPattern.MatchString(line):
+			yamlLines++
+		case sniffAssignPattern.MatchString(line):
+			assignments++
+		}
+	}
+
+	switch {
+	case sections > 0:
+		var decoded map[string]interface{}
+		if toml.Unmarshal(trimmed, &decoded) == nil {
+			return "toml"
+		}
+		return "ini"
+	case blocks > 0:
+		return "hcl"
+	case yamlLines > 0 && env == 0 && assignments == 0:
+		return "yaml"
+	case env > 0 && assignments == 0 && yamlLines == 0:
+		return "env"
+	case env+assignments > 0:
+		return "properties"
+	}
+
+	return ""
+}
@@ -3,8 +3,13 @@ package parsers
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 
-	"github.com/hashicorp/hcl/v2/hclsimple"
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
@@ -16,7 +21,7 @@ type HCLProcessor struct {
 // NewHCLProcessor creates a new HCL processor
 func NewHCLProcessor() *HCLProcessor {
 	return &HCLProcessor{
-		supportedExtensions: []string{"hcl"},
+		supportedExtensions: []string{"hcl", "tf", "nomad"},
 	}
 }
 
@@ -31,12 +36,12 @@ func (p *HCLProcessor) Process(ctx context.Context, filename string, content []b
 		return nil, err
 	}
 
-	data, err := parseHCLContent(content)
+	data, positions, err := parseHCLContent(filename, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HCL: %w", err)
 	}
 
-	return createConfigData(filename, "hcl", data), nil
+	return createConfigData(filename, "hcl", data, positions), nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -44,18 +49,231 @@ func (p *HCLProcessor) GetSupportedExtensions() []string {
 	return copyExtensions(p.supportedExtensions)
 }
 
-// parseHCLContent parses HCL content
-func parseHCLContent(content []byte) (map[string]interface{}, error) {
+// parseHCLContent parses HCL native syntax by walking the syntax tree. Labelled blocks
+// become nested keys (resource "aws_s3_bucket" "logs" -> resource.aws_s3_bucket.logs),
+// repeated blocks become arrays, literal expressions are evaluated and any other
+// expression (references, function calls, interpolations) is kept as its source text.
+func parseHCLContent(filename string, content []byte) (map[string]interface{}, map[string]models.Position, error) {
 	// Guard clause: empty content
 	if isEmptyContent(content) {
-		return createEmptyResult(), nil
+		return createEmptyResult(), createEmptyPositions(), nil
 	}
 
-	var result map[string]interface{}
-	// hclsimple.Decode requires a filename for context, even if it's a dummy
-	if err := hclsimple.Decode("config.hcl", content, nil, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse HCL: %w", err)
+	file, diags := hclsyntax.ParseConfig(content, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported HCL body type %T", file.Body)
+	}
+
+	walker := &hclWalker{content: content, positions: createEmptyPositions()}
+	return walker.walkBody(body, ""), walker.positions, nil
+}
+
+// hclWalker converts an HCL syntax tree into plain data, recording positions
+type hclWalker struct {
+	content   []byte
+	positions map[string]models.Position
+}
+
+// walkBody converts the attributes and blocks of a body
+func (w *hclWalker) walkBody(body *hclsyntax.Body, path string) map[string]interface{} {
+	result := createEmptyResult()
+
+	for name, attribute := range body.Attributes {
+		attributePath := models.JoinPath(path, name)
+		recordPosition(w.positions, attributePath, hclPosition(attribute.NameRange))
+		result[name] = w.walkExpression(attribute.Expr, attributePath)
+	}
+
+	// Blocks sharing the same type and labels are collected into arrays, as are all the
+	// blocks of a type used both with and without labels
+	occurrences := make(map[string]int)
+	labelled, unlabelled := make(map[string]bool), make(map[string]bool)
+	for _, block := range body.Blocks {
+		occurrences[hclBlockKey(block)]++
+		if len(block.Labels) > 0 {
+			labelled[block.Type] = true
+		} else {
+			unlabelled[block.Type] = true
+		}
+	}
+
+	seen := make(map[string]int)
+	for _, block := range body.Blocks {
+		if labelled[block.Type] && unlabelled[block.Type] {
+			w.addMixedBlock(result, block, path, seen[block.Type])
+			seen[block.Type]++
+			continue
+		}
+
+		key := hclBlockKey(block)
+		segments := append([]string{block.Type}, block.Labels...)
+		w.addBlock(result, block, segments, path, occurrences[key] > 1, seen[key])
+		seen[key]++
+	}
+
+	return result
+}
+
+// addBlock inserts a block under its segments (its type and labels)
+func (w *hclWalker) addBlock(result map[string]interface{}, block *hclsyntax.Block, segments []string, path string, repeated bool, index int) {
+	parent := result
+	blockPath := path
+
+	for i, segment := range segments {
+		blockPath = models.JoinPath(blockPath, segment)
+		recordPosition(w.positions, blockPath, hclPosition(block.TypeRange))
+
+		// Intermediate segments are nested objects
+		if i < len(segments)-1 {
+			child, ok := parent[segment].(map[string]interface{})
+			if !ok {
+				child = createEmptyResult()
+				parent[segment] = child
+			}
+			parent = child
+			continue
+		}
+
+		if !repeated {
+			parent[segment] = w.walkBody(block.Body, blockPath)
+			return
+		}
+
+		itemPath := models.IndexPath(blockPath, index)
+		recordPosition(w.positions, itemPath, hclPosition(block.TypeRange))
+		items, _ := parent[segment].([]interface{})
+		parent[segment] = append(items, w.walkBody(block.Body, itemPath))
+	}
+}
+
+// addMixedBlock appends a block to the array of its type, for types used both with and
+// without labels. Labelled blocks become items nested by their labels
+// (provisioner "local-exec" -> provisioner[0].local-exec).
+func (w *hclWalker) addMixedBlock(result map[string]interface{}, block *hclsyntax.Block, path string, index int) {
+	typePath := models.JoinPath(path, block.Type)
+	itemPath := models.IndexPath(typePath, index)
+	recordPosition(w.positions, typePath, hclPosition(block.TypeRange))
+	recordPosition(w.positions, itemPath, hclPosition(block.TypeRange))
+
+	var item map[string]interface{}
+	if len(block.Labels) == 0 {
+		item = w.walkBody(block.Body, itemPath)
+	} else {
+		item = createEmptyResult()
+		w.addBlock(item, block, block.Labels, itemPath, false, 0)
 	}
 
-	return result, nil
+	items, _ := result[block.Type].([]interface{})
+	result[block.Type] = append(items, item)
+}
+
+// walkExpression converts an expression to a value
+func (w *hclWalker) walkExpression(expr hclsyntax.Expression, path string) interface{} {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		result := createEmptyResult()
+		for _, item := range e.Items {
+			key, ok := hclObjectKey(item.KeyExpr)
+			if !ok {
+				// Computed keys cannot be addressed, keep the whole object as source
+				return w.sourceText(expr)
+			}
+			itemPath := models.JoinPath(path, key)
+			recordPosition(w.positions, itemPath, hclPosition(item.KeyExpr.Range()))
+			result[key] = w.walkExpression(item.ValueExpr, itemPath)
+		}
+		return result
+	case *hclsyntax.TupleConsExpr:
+		items := make([]interface{}, 0, len(e.Exprs))
+		for i, item := range e.Exprs {
+			itemPath := models.IndexPath(path, i)
+			recordPosition(w.positions, itemPath, hclPosition(item.Range()))
+			items = append(items, w.walkExpression(item, itemPath))
+		}
+		return items
+	}
+
+	// Literals evaluate without context; anything else is kept as source text
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		return w.sourceText(expr)
+	}
+
+	return ctyToInterface(value)
+}
+
+// sourceText returns the source text of an expression
+func (w *hclWalker) sourceText(expr hclsyntax.Expression) string {
+	return strings.TrimSpace(string(expr.Range().SliceBytes(w.content)))
+}
+
+// hclBlockKey identifies a block by its type and labels
+func hclBlockKey(block *hclsyntax.Block) string {
+	return strings.Join(append([]string{block.Type}, block.Labels...), "\x00")
+}
+
+// hclObjectKey returns the literal key of an object item (bare identifier or string)
+func hclObjectKey(expr hclsyntax.Expression) (string, bool) {
+	if keyword := hcl.ExprAsKeyword(expr); keyword != "" {
+		return keyword, true
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", false
+	}
+
+	return value.AsString(), true
+}
+
+// ctyToInterface converts a known cty value into plain Go values
+func ctyToInterface(value cty.Value) interface{} {
+	if value.IsNull() {
+		return nil
+	}
+
+	valueType := value.Type()
+	switch {
+	case valueType == cty.String:
+		return value.AsString()
+	case valueType == cty.Bool:
+		return value.True()
+	case valueType == cty.Number:
+		return ctyNumber(value.AsBigFloat())
+	case valueType.IsObjectType() || valueType.IsMapType():
+		result := createEmptyResult()
+		for key, element := range value.AsValueMap() {
+			result[key] = ctyToInterface(element)
+		}
+		return result
+	case valueType.IsTupleType() || valueType.IsListType() || valueType.IsSetType():
+		items := make([]interface{}, 0, value.LengthInt())
+		for _, element := range value.AsValueSlice() {
+			items = append(items, ctyToInterface(element))
+		}
+		return items
+	}
+
+	return value.GoString()
+}
+
+// ctyNumber converts a number to int64 when it is integral, float64 otherwise
+func ctyNumber(number *big.Float) interface{} {
+	if number.IsInt() {
+		if integer, accuracy := number.Int64(); accuracy == big.Exact {
+			return integer
+		}
+	}
+	float, _ := number.Float64()
+	return float
+}
+
+// hclPosition converts an HCL source range to a position
+func hclPosition(rng hcl.Range) models.Position {
+	return models.Position{Line: rng.Start.Line, Column: rng.Start.Column}
 }
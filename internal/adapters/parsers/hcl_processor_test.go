@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestHCLProcessorBlocksAndExpressions tests labelled blocks and unevaluated expressions
+func TestHCLProcessorBlocksAndExpressions(t *testing.T) {
+	content := []byte(`
+variable "region" {
+  default = "eu-west-1"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "${var.prefix}-logs"
+  acl    = "private"
+  tags   = { team = "ops", size = 3 }
+
+  lifecycle_rule {
+    enabled = true
+  }
+  lifecycle_rule {
+    enabled = false
+  }
+}
+
+region  = var.region
+ports   = [80, 443]
+timeout = 2.5
+`)
+
+	configData, err := NewHCLProcessor().Process(context.Background(), "main.tf.hcl", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"variable": map[string]interface{}{
+			"region": map[string]interface{}{"default": "eu-west-1"},
+		},
+		"resource": map[string]interface{}{
+			"aws_s3_bucket": map[string]interface{}{
+				"logs": map[string]interface{}{
+					"bucket": `"${var.prefix}-logs"`,
+					"acl":    "private",
+					"tags":   map[string]interface{}{"team": "ops", "size": int64(3)},
+					"lifecycle_rule": []interface{}{
+						map[string]interface{}{"enabled": true},
+						map[string]interface{}{"enabled": false},
+					},
+				},
+			},
+		},
+		"region":  "var.region",
+		"ports":   []interface{}{int64(80), int64(443)},
+		"timeout": 2.5,
+	}
+
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Data = %#v, want %#v", configData.Data, expected)
+	}
+
+	position, ok := configData.PositionOf("resource.aws_s3_bucket.logs.lifecycle_rule[1].enabled")
+	if !ok || position.Line != 15 {
+		t.Errorf("PositionOf(lifecycle_rule[1].enabled) = %+v, %v, want line 15", position, ok)
+	}
+}
+
+// TestHCLProcessorMixedBlocks tests that blocks of a type used with and without labels
+// are all kept
+func TestHCLProcessorMixedBlocks(t *testing.T) {
+	content := []byte(`provisioner "local-exec" {
+  command = "echo start"
+}
+provisioner {
+  when = "destroy"
+}
+provisioner "local-exec" {
+  command = "echo end"
+}
+`)
+
+	configData, err := NewHCLProcessor().Process(context.Background(), "main.hcl", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"provisioner": []interface{}{
+			map[string]interface{}{"local-exec": map[string]interface{}{"command": "echo start"}},
+			map[string]interface{}{"when": "destroy"},
+			map[string]interface{}{"local-exec": map[string]interface{}{"command": "echo end"}},
+		},
+	}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Data = %#v, want %#v", configData.Data, expected)
+	}
+
+	position, ok := configData.PositionOf("provisioner[2].local-exec.command")
+	if !ok || position.Line != 8 {
+		t.Errorf("PositionOf(provisioner[2].local-exec.command) = %+v, %v, want line 8", position, ok)
+	}
+}
@@ -0,0 +1,1093 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// maxHOCONIncludeDepth guards against include cycles
+const maxHOCONIncludeDepth = 16
+
+// HOCONProcessor implements FileProcessor for HOCON files (Akka, Play, Lightbend config)
+type HOCONProcessor struct {
+	supportedExtensions []string
+}
+
+// NewHOCONProcessor creates a new HOCON processor
+func NewHOCONProcessor() *HOCONProcessor {
+	return &HOCONProcessor{
+		supportedExtensions: []string{"conf", "hocon"},
+	}
+}
+
+// CanProcess checks if this processor can handle the given filename
+func (p *HOCONProcessor) CanProcess(filename string) bool {
+	return ValidateFilenameAndExtension(filename, p.supportedExtensions)
+}
+
+// Process processes a HOCON file. Includes are resolved relative to the file's directory.
+func (p *HOCONProcessor) Process(ctx context.Context, filename string, content []byte) (*models.ConfigData, error) {
+	if err := ValidateContextAndInput(ctx, filename, content); err != nil {
+		return nil, err
+	}
+
+	data, positions, err := parseHOCONContent(filename, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HOCON: %w", err)
+	}
+
+	return createConfigData(filename, "hocon", data, positions), nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (p *HOCONProcessor) GetSupportedExtensions() []string {
+	return copyExtensions(p.supportedExtensions)
+}
+
+// parseHOCONContent parses HOCON content and resolves its substitutions
+func parseHOCONContent(filename string, content []byte) (map[string]interface{}, map[string]models.Position, error) {
+	positions := createEmptyPositions()
+
+	// Guard clause: empty content
+	if isEmptyContent(content) {
+		return createEmptyResult(), positions, nil
+	}
+
+	parser := newHOCONParser(filename, content, nil, 0)
+	parser.positions = positions
+
+	root, err := parser.parseRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolver := &hoconResolver{root: root, resolving: make(map[string]bool)}
+	if err := resolver.resolveObject(root); err != nil {
+		return nil, nil, err
+	}
+
+	return root, positions, nil
+}
+
+// hoconSubstitution is an unresolved ${path} or ${?path} reference
+type hoconSubstitution struct {
+	path     []string
+	optional bool
+	prefix   []string // path of the include point, tried first
+	envOnly  bool     // self-references without previous value only consult the environment
+	position models.Position
+}
+
+// hoconConcat is an unresolved concatenation of values (strings, arrays or objects)
+type hoconConcat struct {
+	parts []interface{}
+}
+
+// hoconWhitespace is whitespace between concatenated values
+type hoconWhitespace string
+
+// hoconFallback is a value that may resolve to nothing, such as ${?path}, set over a
+// previous value of the field that is kept in that case
+type hoconFallback struct {
+	value    interface{}
+	previous interface{}
+}
+
+// hoconParser is a recursive-descent HOCON parser
+type hoconParser struct {
+	filename  string
+	src       []rune
+	pos       int
+	line      int
+	column    int
+	prefix    []string
+	depth     int
+	positions map[string]models.Position
+}
+
+// newHOCONParser creates a parser; prefix is the include point of included files
+func newHOCONParser(filename string, content []byte, prefix []string, depth int) *hoconParser {
+	return &hoconParser{
+		filename: filename,
+		src:      []rune(string(content)),
+		line:     1,
+		column:   1,
+		prefix:   prefix,
+		depth:    depth,
+	}
+}
+
+// parseRoot parses the root object, with or without braces
+func (p *hoconParser) parseRoot() (map[string]interface{}, error) {
+	p.skipBlank(true)
+
+	root := createEmptyResult()
+	if p.peek() == '{' {
+		p.next()
+		if err := p.parseFields(root, nil, '}'); err != nil {
+			return nil, err
+		}
+		p.next()
+		p.skipBlank(true)
+		if !p.eof() {
+			return nil, p.errorf("unexpected content after root object")
+		}
+		return root, nil
+	}
+
+	if err := p.parseFields(root, nil, 0); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// parseFields parses fields into object until the closing rune (0 for end of input)
+func (p *hoconParser) parseFields(object map[string]interface{}, path []string, closing rune) error {
+	for {
+		p.skipSeparators()
+
+		if p.eof() {
+			if closing != 0 {
+				return p.errorf("expected '%c' before end of input", closing)
+			}
+			return nil
+		}
+		if p.peek() == closing {
+			return nil
+		}
+
+		if p.atInclude() {
+			if err := p.parseInclude(object, path); err != nil {
+				return err
+			}
+		} else if err := p.parseField(object, path); err != nil {
+			return err
+		}
+
+		// Fields end with a comma, a newline, a comment or the closing rune
+		p.skipBlank(false)
+		if !p.eof() && p.peek() != ',' && p.peek() != '\n' && p.peek() != closing {
+			return p.errorf("expected ',' or newline after field, found '%c'", p.peek())
+		}
+	}
+}
+
+// parseField parses a single key/value field
+func (p *hoconParser) parseField(object map[string]interface{}, path []string) error {
+	position := p.position()
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	fullPath := append(append([]string{}, path...), keys...)
+	p.recordKeyPositions(path, keys, position)
+
+	p.skipBlank(false)
+	appending := false
+	switch {
+	case p.peek() == '{':
+		// Separator is optional before an object
+	case p.peek() == ':' || p.peek() == '=':
+		p.next()
+	case p.peek() == '+' && p.peekAt(1) == '=':
+		p.next()
+		p.next()
+		appending = true
+	default:
+		return p.errorf("expected ':', '=' or '+=' after key %q", strings.Join(keys, "."))
+	}
+
+	p.skipBlank(false)
+	value, err := p.parseValue(fullPath)
+	if err != nil {
+		return err
+	}
+
+	setHOCONField(object, keys, fullPath, value, appending)
+	return nil
+}
+
+// parseKey parses a path expression (a.b."c.d") into its segments
+func (p *hoconParser) parseKey() ([]string, error) {
+	segments := make([]string, 0, 1)
+	var current strings.Builder
+	started := false
+
+	for !p.eof() {
+		r := p.peek()
+		switch {
+		case r == '"':
+			text, err := p.parseQuotedString()
+			if err != nil {
+				return nil, err
+			}
+			current.WriteString(text)
+			started = true
+		case r == '.':
+			p.next()
+			segments = append(segments, current.String())
+			current.Reset()
+			started = false
+		case isHOCONUnquotedRune(r) && !p.atComment():
+			current.WriteRune(p.next())
+			started = true
+		default:
+			if !started && len(segments) == 0 {
+				return nil, p.errorf("expected key, found '%c'", r)
+			}
+			return append(segments, current.String()), nil
+		}
+	}
+
+	return nil, p.errorf("unexpected end of input in key")
+}
+
+// parseValue parses a value, which may be a concatenation of several values
+func (p *hoconParser) parseValue(path []string) (interface{}, error) {
+	parts := make([]interface{}, 0, 1)
+	quoted := false
+
+	for !p.eof() && !p.atValueEnd() {
+		r := p.peek()
+		switch {
+		case r == ' ' || r == '\t':
+			var whitespace strings.Builder
+			for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+				whitespace.WriteRune(p.next())
+			}
+			parts = append(parts, hoconWhitespace(whitespace.String()))
+		case r == '{':
+			p.next()
+			object := createEmptyResult()
+			if err := p.parseFields(object, path, '}'); err != nil {
+				return nil, err
+			}
+			p.next()
+			parts = append(parts, object)
+		case r == '[':
+			array, err := p.parseArray(path)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, array)
+		case r == '"':
+			text, err := p.parseQuotedString()
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, text)
+			quoted = true
+		case r == '$' && p.peekAt(1) == '{':
+			substitution, err := p.parseSubstitution()
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, substitution)
+		case isHOCONUnquotedRune(r) || r == '.':
+			var text strings.Builder
+			for !p.eof() && (isHOCONUnquotedRune(p.peek()) || p.peek() == '.') && !p.atComment() {
+				text.WriteRune(p.next())
+			}
+			parts = append(parts, text.String())
+		default:
+			return nil, p.errorf("unexpected character '%c' in value", r)
+		}
+	}
+
+	parts = trimHOCONWhitespace(parts)
+	switch {
+	case len(parts) == 0:
+		return nil, p.errorf("expected value")
+	case len(parts) == 1:
+		if text, ok := parts[0].(string); ok && !quoted {
+			return parseHOCONLiteral(text), nil
+		}
+		return parts[0], nil
+	}
+
+	return &hoconConcat{parts: parts}, nil
+}
+
+// parseArray parses an array; elements are separated by commas or newlines
+func (p *hoconParser) parseArray(path []string) ([]interface{}, error) {
+	p.next()
+	items := make([]interface{}, 0)
+
+	for {
+		p.skipSeparators()
+		if p.eof() {
+			return nil, p.errorf("expected ']' before end of input")
+		}
+		if p.peek() == ']' {
+			p.next()
+			return items, nil
+		}
+
+		itemPath := append(append([]string{}, path...), fmt.Sprintf("[%d]", len(items)))
+		value, err := p.parseValue(itemPath)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+
+		p.skipBlank(false)
+		if !p.eof() && p.peek() != ',' && p.peek() != '\n' && p.peek() != ']' {
+			return nil, p.errorf("expected ',' or newline after array element, found '%c'", p.peek())
+		}
+	}
+}
+
+// parseSubstitution parses ${path} or ${?path}
+func (p *hoconParser) parseSubstitution() (*hoconSubstitution, error) {
+	position := p.position()
+	p.next()
+	p.next()
+
+	optional := false
+	if p.peek() == '?' {
+		p.next()
+		optional = true
+	}
+
+	p.skipBlank(false)
+	keys, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank(false)
+
+	if p.peek() != '}' {
+		return nil, p.errorf("expected '}' to close substitution")
+	}
+	p.next()
+
+	return &hoconSubstitution{path: keys, optional: optional, prefix: p.prefix, position: position}, nil
+}
+
+// parseQuotedString parses a "quoted" or """triple-quoted""" string
+func (p *hoconParser) parseQuotedString() (string, error) {
+	if p.peekAt(1) == '"' && p.peekAt(2) == '"' {
+		return p.parseTripleQuotedString()
+	}
+
+	p.next()
+	var text strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated quoted string")
+		}
+
+		r := p.next()
+		if r == '"' {
+			return text.String(), nil
+		}
+		if r != '\\' {
+			text.WriteRune(r)
+			continue
+		}
+
+		escaped, err := p.parseEscape()
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(escaped)
+	}
+}
+
+// parseEscape parses the escape sequence following a backslash
+func (p *hoconParser) parseEscape() (string, error) {
+	if p.eof() {
+		return "", p.errorf("unterminated escape sequence")
+	}
+
+	r := p.next()
+	switch r {
+	case '"', '\\', '/':
+		return string(r), nil
+	case 'b':
+		return "\b", nil
+	case 'f':
+		return "\f", nil
+	case 'n':
+		return "\n", nil
+	case 'r':
+		return "\r", nil
+	case 't':
+		return "\t", nil
+	case 'u':
+		if p.pos+4 > len(p.src) {
+			return "", p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(string(p.src[p.pos:p.pos+4]), 16, 32)
+		if err != nil {
+			return "", p.errorf("invalid unicode escape")
+		}
+		for i := 0; i < 4; i++ {
+			p.next()
+		}
+		return string(rune(code)), nil
+	}
+
+	return "", p.errorf("invalid escape sequence '\\%c'", r)
+}
+
+// parseTripleQuotedString parses a raw """string"""; extra closing quotes belong to the string
+func (p *hoconParser) parseTripleQuotedString() (string, error) {
+	for i := 0; i < 3; i++ {
+		p.next()
+	}
+
+	var text strings.Builder
+	for !p.eof() {
+		if p.peek() == '"' && p.peekAt(1) == '"' && p.peekAt(2) == '"' {
+			for p.peekAt(3) == '"' {
+				text.WriteRune(p.next())
+			}
+			for i := 0; i < 3; i++ {
+				p.next()
+			}
+			return text.String(), nil
+		}
+		text.WriteRune(p.next())
+	}
+
+	return "", p.errorf("unterminated triple-quoted string")
+}
+
+// parseInclude parses an include directive and merges the included object
+func (p *hoconParser) parseInclude(object map[string]interface{}, path []string) error {
+	for i := 0; i < len("include"); i++ {
+		p.next()
+	}
+	p.skipBlank(false)
+
+	required := false
+	if p.consumeWord("required(") {
+		required = true
+		p.skipBlank(false)
+	}
+
+	wrapped := false
+	switch {
+	case p.consumeWord("file("):
+		wrapped = true
+	case p.consumeWord("url("), p.consumeWord("classpath("):
+		return p.errorf("only file includes are supported")
+	}
+	p.skipBlank(false)
+
+	if p.peek() != '"' {
+		return p.errorf("expected quoted include target")
+	}
+	target, err := p.parseQuotedString()
+	if err != nil {
+		return err
+	}
+
+	for _, closed := range []bool{wrapped, required} {
+		if !closed {
+			continue
+		}
+		p.skipBlank(false)
+		if p.peek() != ')' {
+			return p.errorf("expected ')' to close include")
+		}
+		p.next()
+	}
+
+	included, err := p.loadInclude(target, path, required)
+	if err != nil {
+		return err
+	}
+
+	mergeHOCONObjects(object, included)
+	return nil
+}
+
+// loadInclude reads and parses an included file relative to the including file
+func (p *hoconParser) loadInclude(target string, path []string, required bool) (map[string]interface{}, error) {
+	// Guard clause: include cycles
+	if p.depth >= maxHOCONIncludeDepth {
+		return nil, p.errorf("includes nested deeper than %d levels", maxHOCONIncludeDepth)
+	}
+
+	filename := target
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(filepath.Dir(p.filename), target)
+	}
+
+	// Includes without extension default to .conf
+	candidates := []string{filename}
+	if filepath.Ext(filename) == "" {
+		candidates = append(candidates, filename+".conf")
+	}
+
+	for _, candidate := range candidates {
+		content, err := os.ReadFile(candidate)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, p.errorf("failed to read include %q: %v", target, err)
+		}
+
+		prefix := append(append([]string{}, p.prefix...), path...)
+		parser := newHOCONParser(candidate, content, prefix, p.depth+1)
+		included, err := parser.parseRoot()
+		if err != nil {
+			return nil, fmt.Errorf("in include %q: %w", target, err)
+		}
+		return included, nil
+	}
+
+	// Missing includes are ignored unless required
+	if required {
+		return nil, p.errorf("required include %q not found", target)
+	}
+	return createEmptyResult(), nil
+}
+
+// recordKeyPositions records the position of every segment of a key
+func (p *hoconParser) recordKeyPositions(path, keys []string, position models.Position) {
+	// Included files do not record positions (they point into another file)
+	if p.positions == nil {
+		return
+	}
+
+	keyPath := hoconKeyPath(path)
+	for _, key := range keys {
+		keyPath = models.JoinPath(keyPath, key)
+		recordPosition(p.positions, keyPath, position)
+	}
+}
+
+// skipSeparators skips whitespace, newlines, comments and commas
+func (p *hoconParser) skipSeparators() {
+	for {
+		p.skipBlank(true)
+		if p.peek() != ',' {
+			return
+		}
+		p.next()
+	}
+}
+
+// skipBlank skips spaces and comments, and newlines when requested
+func (p *hoconParser) skipBlank(newlines bool) {
+	for !p.eof() {
+		r := p.peek()
+		switch {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\uFEFF':
+			p.next()
+		case r == '\n' && newlines:
+			p.next()
+		case p.atComment():
+			for !p.eof() && p.peek() != '\n' {
+				p.next()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// atComment reports whether a # or // comment starts at the current position
+func (p *hoconParser) atComment() bool {
+	return p.peek() == '#' || (p.peek() == '/' && p.peekAt(1) == '/')
+}
+
+// atValueEnd reports whether the current value ends here
+func (p *hoconParser) atValueEnd() bool {
+	switch p.peek() {
+	case '\n', '\r', ',', '}', ']':
+		return true
+	}
+	return p.atComment()
+}
+
+// atInclude reports whether an include directive starts here
+func (p *hoconParser) atInclude() bool {
+	if !p.hasPrefix("include") {
+		return false
+	}
+	next := p.peekAt(len("include"))
+	return next == ' ' || next == '\t'
+}
+
+// consumeWord consumes word if the input continues with it
+func (p *hoconParser) consumeWord(word string) bool {
+	if !p.hasPrefix(word) {
+		return false
+	}
+	for range word {
+		p.next()
+	}
+	return true
+}
+
+// hasPrefix reports whether the remaining input starts with text
+func (p *hoconParser) hasPrefix(text string) bool {
+	i := 0
+	for _, r := range text {
+		if p.peekAt(i) != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// peek returns the current rune, or 0 at end of input
+func (p *hoconParser) peek() rune {
+	return p.peekAt(0)
+}
+
+// peekAt returns the rune at offset from the current position, or 0
+func (p *hoconParser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+offset]
+}
+
+// next consumes and returns the current rune, tracking line and column
+func (p *hoconParser) next() rune {
+	r := p.src[p.pos]
+	p.pos++
+	if r == '\n' {
+		p.line++
+		p.column = 1
+	} else {
+		p.column++
+	}
+	return r
+}
+
+// eof reports whether the input is exhausted
+func (p *hoconParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+// position returns the current position
+func (p *hoconParser) position() models.Position {
+	return models.Position{Line: p.line, Column: p.column}
+}
+
+// errorf creates an error annotated with the current location
+func (p *hoconParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d:%d: %s", filepath.Base(p.filename), p.line, p.column, fmt.Sprintf(format, args...))
+}
+
+// isHOCONUnquotedRune reports whether r may appear in an unquoted string or key
+func isHOCONUnquotedRune(r rune) bool {
+	if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == 0 {
+		return false
+	}
+	return !strings.ContainsRune("$\"{}[]:=,+#`^?!@*&\\.", r)
+}
+
+// parseHOCONLiteral converts an unquoted single value to a boolean, null, number or string
+func parseHOCONLiteral(text string) interface{} {
+	switch text {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+
+	if integer, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return integer
+	}
+	if float, err := strconv.ParseFloat(text, 64); err == nil && strings.ContainsAny(text, "0123456789") {
+		return float
+	}
+
+	return text
+}
+
+// trimHOCONWhitespace removes leading and trailing whitespace parts
+func trimHOCONWhitespace(parts []interface{}) []interface{} {
+	for len(parts) > 0 {
+		if _, ok := parts[0].(hoconWhitespace); !ok {
+			break
+		}
+		parts = parts[1:]
+	}
+	for len(parts) > 0 {
+		if _, ok := parts[len(parts)-1].(hoconWhitespace); !ok {
+			break
+		}
+		parts = parts[:len(parts)-1]
+	}
+	return parts
+}
+
+// setHOCONField sets a (possibly dotted) field, merging objects and appending for +=
+func setHOCONField(object map[string]interface{}, keys, fullPath []string, value interface{}, appending bool) {
+	parent := object
+	for _, key := range keys[:len(keys)-1] {
+		existing := parent[key]
+		child, ok := existing.(map[string]interface{})
+		if fallback, isFallback := existing.(*hoconFallback); isFallback {
+			child, ok = fallback.value.(map[string]interface{})
+		}
+		if !ok {
+			// A field set below a substitution (a = ${b}, a.x = 1) merges with it
+			child = createEmptyResult()
+			parent[key] = child
+			if isUnresolvedHOCONValue(existing) {
+				parent[key] = &hoconFallback{value: child, previous: existing}
+			}
+		}
+		parent = child
+	}
+
+	key := keys[len(keys)-1]
+	existing, exists := parent[key]
+
+	// a += b is shorthand for a = ${?a} [b]
+	if appending {
+		value = &hoconConcat{parts: []interface{}{
+			&hoconSubstitution{path: fullPath, optional: true},
+			[]interface{}{value},
+		}}
+	}
+
+	// Self-references (a = ${a} ...) refer to the previous value of the field
+	value = replaceHOCONSelfReferences(value, fullPath, existing, exists)
+
+	// Values that are only known once substitutions are resolved decide then whether they
+	// merge with (objects), replace or, for undefined optional substitutions, keep the
+	// previous value
+	if exists && (isUnresolvedHOCONValue(value) || isUnresolvedHOCONValue(existing)) {
+		value = &hoconFallback{value: value, previous: existing}
+	}
+
+	existingObject, existingIsObject := existing.(map[string]interface{})
+	valueObject, valueIsObject := value.(map[string]interface{})
+	if existingIsObject && valueIsObject {
+		mergeHOCONObjects(existingObject, valueObject)
+		return
+	}
+
+	parent[key] = value
+}
+
+// isUnresolvedHOCONValue reports whether a value depends on substitutions
+func isUnresolvedHOCONValue(value interface{}) bool {
+	switch value.(type) {
+	case *hoconSubstitution, *hoconConcat, *hoconFallback:
+		return true
+	}
+	return false
+}
+
+// replaceHOCONSelfReferences substitutes references to path with its previous value
+func replaceHOCONSelfReferences(value interface{}, path []string, previous interface{}, exists bool) interface{} {
+	switch v := value.(type) {
+	case *hoconSubstitution:
+		if strings.Join(v.path, ".") != strings.Join(path, ".") {
+			return v
+		}
+		if exists {
+			return previous
+		}
+		envOnly := *v
+		envOnly.envOnly = true
+		return &envOnly
+	case *hoconConcat:
+		parts := make([]interface{}, 0, len(v.parts))
+		for _, part := range v.parts {
+			parts = append(parts, replaceHOCONSelfReferences(part, path, previous, exists))
+		}
+		return &hoconConcat{parts: parts}
+	}
+	return value
+}
+
+// mergeHOCONObjects merges source into target; nested objects are merged recursively
+func mergeHOCONObjects(target, source map[string]interface{}) {
+	for key, value := range source {
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		sourceObject, sourceIsObject := value.(map[string]interface{})
+		if targetIsObject && sourceIsObject {
+			mergeHOCONObjects(targetObject, sourceObject)
+			continue
+		}
+		target[key] = value
+	}
+}
+
+// mergedHOCONObjects returns a new object merging objects in order, leaving them unchanged,
+// so that substituted objects are not modified through the objects they are merged into
+func mergedHOCONObjects(objects ...map[string]interface{}) map[string]interface{} {
+	merged := createEmptyResult()
+	for _, object := range objects {
+		for key, value := range object {
+			sourceObject, sourceIsObject := value.(map[string]interface{})
+			if !sourceIsObject {
+				merged[key] = value
+				continue
+			}
+			if targetObject, targetIsObject := merged[key].(map[string]interface{}); targetIsObject {
+				merged[key] = mergedHOCONObjects(targetObject, sourceObject)
+				continue
+			}
+			merged[key] = mergedHOCONObjects(sourceObject)
+		}
+	}
+	return merged
+}
+
+// hoconKeyPath converts parser path segments (including [n] array markers) to a key path
+func hoconKeyPath(segments []string) string {
+	path := ""
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]") {
+			if index, err := strconv.Atoi(segment[1 : len(segment)-1]); err == nil {
+				path = models.IndexPath(path, index)
+				continue
+			}
+		}
+		path = models.JoinPath(path, segment)
+	}
+	return path
+}
+
+// hoconResolver resolves substitutions and concatenations against the root object
+type hoconResolver struct {
+	root      map[string]interface{}
+	resolving map[string]bool
+}
+
+// resolveObject resolves every field of an object in place, dropping missing optionals
+func (r *hoconResolver) resolveObject(object map[string]interface{}) error {
+	for key, value := range object {
+		resolved, present, err := r.resolveValue(value)
+		if err != nil {
+			return err
+		}
+		if !present {
+			delete(object, key)
+			continue
+		}
+		object[key] = resolved
+	}
+	return nil
+}
+
+// resolveValue resolves a value; present is false for missing optional substitutions
+func (r *hoconResolver) resolveValue(value interface{}) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true, r.resolveObject(v)
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			resolved, present, err := r.resolveValue(item)
+			if err != nil {
+				return nil, false, err
+			}
+			if present {
+				items = append(items, resolved)
+			}
+		}
+		return items, true, nil
+	case *hoconSubstitution:
+		return r.resolveSubstitution(v)
+	case *hoconConcat:
+		return r.resolveConcat(v)
+	case *hoconFallback:
+		return r.resolveFallback(v)
+	case hoconWhitespace:
+		return string(v), true, nil
+	}
+	return value, true, nil
+}
+
+// resolveFallback resolves a value set over a previous value of its field. An object
+// merges with a previous object, any other value replaces it, and a missing optional
+// substitution keeps it.
+func (r *hoconResolver) resolveFallback(fallback *hoconFallback) (interface{}, bool, error) {
+	resolved, present, err := r.resolveValue(fallback.value)
+	if err != nil {
+		return nil, false, err
+	}
+	if !present {
+		return r.resolveValue(fallback.previous)
+	}
+
+	object, ok := resolved.(map[string]interface{})
+	if !ok {
+		return resolved, true, nil
+	}
+
+	previous, present, err := r.resolveValue(fallback.previous)
+	if err != nil {
+		return nil, false, err
+	}
+	previousObject, ok := previous.(map[string]interface{})
+	if !present || !ok {
+		return object, true, nil
+	}
+	return mergedHOCONObjects(previousObject, object), true, nil
+}
+
+// resolveSubstitution looks a path up in the configuration, then in the environment
+func (r *hoconResolver) resolveSubstitution(substitution *hoconSubstitution) (interface{}, bool, error) {
+	candidates := [][]string{substitution.path}
+	if substitution.envOnly {
+		candidates = nil
+	} else if len(substitution.prefix) > 0 {
+		prefixed := append(append([]string{}, substitution.prefix...), substitution.path...)
+		candidates = [][]string{prefixed, substitution.path}
+	}
+
+	for _, candidate := range candidates {
+		value, found, err := r.lookup(candidate)
+		if err != nil {
+			return nil, false, err
+		}
+		if found {
+			return value, true, nil
+		}
+	}
+
+	if value, ok := os.LookupEnv(strings.Join(substitution.path, ".")); ok {
+		return value, true, nil
+	}
+
+	if substitution.optional {
+		return nil, false, nil
+	}
+
+	return nil, false, fmt.Errorf("line %d: could not resolve substitution ${%s}",
+		substitution.position.Line, strings.Join(substitution.path, "."))
+}
+
+// lookup finds and resolves the value at path within the root object
+func (r *hoconResolver) lookup(path []string) (interface{}, bool, error) {
+	key := strings.Join(path, ".")
+	if r.resolving[key] {
+		return nil, false, fmt.Errorf("substitution cycle detected at ${%s}", key)
+	}
+	r.resolving[key] = true
+	defer delete(r.resolving, key)
+
+	parent := r.root
+	for i, segment := range path {
+		value, exists := parent[segment]
+		if !exists {
+			return nil, false, nil
+		}
+
+		// Descend into intermediate objects without resolving their siblings
+		if child, ok := value.(map[string]interface{}); ok && i < len(path)-1 {
+			parent = child
+			continue
+		}
+
+		resolved, present, err := r.resolveValue(value)
+		if err != nil {
+			return nil, false, err
+		}
+		if !present {
+			delete(parent, segment)
+			return nil, false, nil
+		}
+		parent[segment] = resolved
+
+		if i == len(path)-1 {
+			return resolved, true, nil
+		}
+
+		child, ok := resolved.(map[string]interface{})
+		if !ok {
+			return nil, false, nil
+		}
+		parent = child
+	}
+
+	return nil, false, nil
+}
+
+// resolveConcat resolves a concatenation into a merged object, a joined array or a string
+func (r *hoconResolver) resolveConcat(concat *hoconConcat) (interface{}, bool, error) {
+	parts := make([]interface{}, 0, len(concat.parts))
+	for _, part := range concat.parts {
+		// Whitespace is kept as is, so that only unquoted whitespace is trimmed
+		if whitespace, ok := part.(hoconWhitespace); ok {
+			parts = append(parts, whitespace)
+			continue
+		}
+		resolved, present, err := r.resolveValue(part)
+		if err != nil {
+			return nil, false, err
+		}
+		if present {
+			parts = append(parts, resolved)
+		}
+	}
+	parts = trimHOCONWhitespace(parts)
+
+	values := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		if _, ok := part.(hoconWhitespace); ok {
+			continue
+		}
+		values = append(values, part)
+	}
+
+	// Guard clause: only missing optional substitutions
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+
+	switch values[0].(type) {
+	case map[string]interface{}:
+		objects := make([]map[string]interface{}, 0, len(values))
+		for _, value := range values {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false, fmt.Errorf("cannot concatenate object with %T", value)
+			}
+			objects = append(objects, object)
+		}
+		return mergedHOCONObjects(objects...), true, nil
+	case []interface{}:
+		joined := make([]interface{}, 0)
+		for _, value := range values {
+			array, ok := value.([]interface{})
+			if !ok {
+				return nil, false, fmt.Errorf("cannot concatenate array with %T", value)
+			}
+			joined = append(joined, array...)
+		}
+		return joined, true, nil
+	}
+
+	var text strings.Builder
+	for _, part := range parts {
+		switch v := part.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, false, fmt.Errorf("cannot concatenate string with %T", part)
+		case nil:
+			continue
+		case hoconWhitespace:
+			text.WriteString(string(v))
+			continue
+		}
+		text.WriteString(fmt.Sprint(part))
+	}
+
+	return text.String(), true, nil
+}
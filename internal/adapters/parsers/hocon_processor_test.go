@@ -0,0 +1,111 @@
+package parsers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestHOCONProcessor tests includes, substitutions, merging and string forms
+func TestHOCONProcessor(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "defaults.conf"), []byte("pool { size = 5, timeout = 30s }\n"), 0644); err != nil {
+		t.Fatalf("failed to write include: %v", err)
+	}
+
+	t.Setenv("PRAETORIAN_DB_HOST", "db.internal")
+
+	content := []byte(`
+# Akka style configuration
+akka.loglevel = "INFO"
+akka {
+  actor.provider = cluster
+}
+
+database {
+  include "defaults.conf"
+  host = localhost
+  host = ${?PRAETORIAN_DB_HOST}
+  port: 5432
+  url = "jdbc:postgresql://"${database.host}":"${database.port}
+  pool.size = 10
+}
+
+paths = [/usr/bin]
+paths += /opt/bin
+greeting = hello world
+query = """SELECT "name"
+FROM users"""
+missing = ${?PRAETORIAN_UNSET_VARIABLE}
+port = 8080
+port = ${?PRAETORIAN_UNSET_VARIABLE}
+padded = "  x "${?PRAETORIAN_UNSET_VARIABLE}
+base = { a = 1 }
+derived = ${base} { b = 2 }
+merged { x = 1 }
+merged = ${base}
+merged.y = 3
+replaced { x = 1 }
+replaced = ${port}
+`)
+
+	configData, err := NewHOCONProcessor().Process(context.Background(), filepath.Join(dir, "application.conf"), content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"akka": map[string]interface{}{
+			"loglevel": "INFO",
+			"actor":    map[string]interface{}{"provider": "cluster"},
+		},
+		"database": map[string]interface{}{
+			"pool": map[string]interface{}{"size": int64(10), "timeout": "30s"},
+			"host": "db.internal",
+			"port": int64(5432),
+			"url":  "jdbc:postgresql://db.internal:5432",
+		},
+		"paths":    []interface{}{"/usr/bin", "/opt/bin"},
+		"greeting": "hello world",
+		"port":     int64(8080),
+		"padded":   "  x ",
+		"query":    "SELECT \"name\"\nFROM users",
+		"base":     map[string]interface{}{"a": int64(1)},
+		"derived":  map[string]interface{}{"a": int64(1), "b": int64(2)},
+		"merged":   map[string]interface{}{"x": int64(1), "a": int64(1), "y": int64(3)},
+		"replaced": int64(8080),
+	}
+
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Data = %#v, want %#v", configData.Data, expected)
+	}
+
+	position, ok := configData.PositionOf("database.port")
+	if !ok || position.Line != 12 || position.Column != 3 {
+		t.Errorf("PositionOf(database.port) = %+v, %v, want 12:3", position, ok)
+	}
+}
+
+// TestHOCONProcessorErrors tests that invalid HOCON is reported
+func TestHOCONProcessorErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unresolved substitution", "a = ${does.not.exist}"},
+		{"unterminated object", "a { b = 1"},
+		{"substitution cycle", "a = ${b}\nb = ${a}"},
+		{"missing required include", `include required("nope.conf")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHOCONProcessor().Process(context.Background(), filepath.Join(t.TempDir(), "app.conf"), []byte(tt.content))
+			if err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// Types recognised by type inference
+const (
+	InferBool     = "bool"
+	InferInt      = "int"
+	InferFloat    = "float"
+	InferDuration = "duration"
+	InferNull     = "null"
+)
+
+// defaultInferenceFormats are the formats whose values are always strings
+var defaultInferenceFormats = []string{"env", "properties", "ini"}
+
+// inferenceBooleans maps boolean literals (compared case-insensitively) to their value
+var inferenceBooleans = map[string]bool{
+	"true": true, "yes": true, "on": true,
+	"false": false, "no": false, "off": false,
+}
+
+// Number shapes accepted by type inference. Integers with leading zeros (zip codes,
+// octal-looking ids) are kept as strings.
+var (
+	inferIntPattern   = regexp.MustCompile(`^[-+]?(0|[1-9][0-9]*)$`)
+	inferFloatPattern = regexp.MustCompile(`^[-+]?(0|[1-9][0-9]*)?(\.[0-9]+)([eE][-+]?[0-9]+)?$|^[-+]?(0|[1-9][0-9]*)[eE][-+]?[0-9]+$`)
+)
+
+// TypeInference converts string values of string-only formats into booleans, numbers,
+// durations and nulls. The original strings are kept in the "raw_values" metadata,
+// keyed by key path (see RawValue).
+type TypeInference struct {
+	formats     map[string]bool
+	types       map[string]bool
+	keepStrings []string
+}
+
+// NewTypeInference creates type inference from its configuration
+func NewTypeInference(config models.TypeInferenceConfig) (*TypeInference, error) {
+	inference := &TypeInference{
+		formats:     make(map[string]bool),
+		types:       make(map[string]bool),
+		keepStrings: config.KeepStrings,
+	}
+
+	formats := config.Formats
+	if len(formats) == 0 {
+		formats = defaultInferenceFormats
+	}
+	for _, format := range formats {
+		name := normalizeFormat(format)
+		if _, ok := formatFactories[name]; !ok {
+			return nil, fmt.Errorf("unknown format %q in type inference", format)
+		}
+		inference.formats[name] = true
+	}
+
+	types := config.Types
+	if len(types) == 0 {
+		types = []string{InferBool, InferInt, InferFloat, InferDuration, InferNull}
+	}
+	for _, name := range types {
+		switch name {
+		case InferBool, InferInt, InferFloat, InferDuration, InferNull:
+			inference.types[name] = true
+		default:
+			return nil, fmt.Errorf("unknown inferred type %q (expected bool, int, float, duration or null)", name)
+		}
+	}
+
+	for _, pattern := range config.KeepStrings {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid keep_strings pattern %q: %w", pattern, err)
+		}
+	}
+
+	return inference, nil
+}
+
+// Applies reports whether inference applies to config data of the given format
+func (t *TypeInference) Applies(format string) bool {
+	return t.formats[normalizeFormat(format)]
+}
+
+// Apply infers the types of the string values of config data (and of its documents)
+// when its format is subject to inference
+func (t *TypeInference) Apply(data *models.ConfigData) {
+	// Guard clause: nothing to infer
+	if data == nil || !t.Applies(data.Format) {
+		return
+	}
+
+	raw := make(map[string]string)
+	data.Data = t.inferMap(data.Data, "", raw)
+	if len(raw) > 0 {
+		if data.Metadata == nil {
+			data.Metadata = make(map[string]interface{})
+		}
+		data.Metadata["raw_values"] = raw
+	}
+
+	for _, document := range data.Documents {
+		t.Apply(document)
+	}
+}
+
+// Infer converts a single string value, returning the value unchanged when no enabled
+// type matches
+func (t *TypeInference) Infer(value string) interface{} {
+	trimmed := strings.TrimSpace(value)
+
+	if t.types[InferNull] && (strings.EqualFold(trimmed, "null") || trimmed == "~") {
+		return nil
+	}
+	if b, ok := inferenceBooleans[strings.ToLower(trimmed)]; ok && t.types[InferBool] {
+		return b
+	}
+	if t.types[InferInt] && inferIntPattern.MatchString(trimmed) {
+		if i, err := strconv.Atoi(trimmed); err == nil {
+			return i
+		}
+	}
+	if t.types[InferFloat] && inferFloatPattern.MatchString(trimmed) {
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil && !math.IsInf(f, 0) {
+			return f
+		}
+	}
+	if t.types[InferDuration] && trimmed != "" && !inferIntPattern.MatchString(trimmed) {
+		if d, err := time.ParseDuration(trimmed); err == nil {
+			return d
+		}
+	}
+
+	return value
+}
+
+// inferMap infers the values of a map, recording replaced strings in raw
+func (t *TypeInference) inferMap(values map[string]interface{}, parent string, raw map[string]string) map[string]interface{} {
+	for key, value := range values {
+		values[key] = t.inferValue(value, models.JoinPath(parent, key), key, raw)
+	}
+	return values
+}
+
+// inferValue infers a value at a key path
+func (t *TypeInference) inferValue(value interface{}, keyPath, key string, raw map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return t.inferMap(v, keyPath, raw)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = t.inferValue(item, models.IndexPath(keyPath, i), key, raw)
+		}
+		return v
+	case string:
+		if t.keepString(keyPath, key) {
+			return v
+		}
+		inferred := t.Infer(v)
+		if _, unchanged := inferred.(string); !unchanged {
+			raw[keyPath] = v
+		}
+		return inferred
+	}
+	return value
+}
+
+// keepString reports whether a key path matches a keep_strings pattern (by full path or key name)
+func (t *TypeInference) keepString(keyPath, key string) bool {
+	for _, pattern := range t.keepStrings {
+		if matched, _ := path.Match(pattern, keyPath); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RawValue returns the original string of a value converted by type inference
+func RawValue(data *models.ConfigData, keyPath string) (string, bool) {
+	if data == nil || data.Metadata == nil {
+		return "", false
+	}
+	raw, _ := data.Metadata["raw_values"].(map[string]string)
+	value, ok := raw[keyPath]
+	return value, ok
+}
+
+// inferringProcessor applies type inference to the output of a processor
+type inferringProcessor struct {
+	models.FileProcessor
+	inference *TypeInference
+}
+
+// Process processes a file and infers the types of its values
+func (p *inferringProcessor) Process(ctx context.Context, filename string, content []byte) (*models.ConfigData, error) {
+	configData, err := p.FileProcessor.Process(ctx, filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	p.inference.Apply(configData)
+	return configData, nil
+}
@@ -0,0 +1,132 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestTypeInferenceInfer tests the recognised literals
+func TestTypeInferenceInfer(t *testing.T) {
+	inference, err := NewTypeInference(models.TypeInferenceConfig{})
+	if err != nil {
+		t.Fatalf("NewTypeInference() error = %v", err)
+	}
+
+	tests := []struct {
+		value    string
+		expected interface{}
+	}{
+		{value: "true", expected: true},
+		{value: "Yes", expected: true},
+		{value: "OFF", expected: false},
+		{value: "42", expected: 42},
+		{value: "-7", expected: -7},
+		{value: "02134", expected: "02134"},
+		{value: "3.14", expected: 3.14},
+		{value: "1e3", expected: 1000.0},
+		{value: "1.2.3", expected: "1.2.3"},
+		{value: "30s", expected: 30 * time.Second},
+		{value: "1h30m", expected: 90 * time.Minute},
+		{value: "null", expected: nil},
+		{value: "~", expected: nil},
+		{value: "", expected: ""},
+		{value: "hello", expected: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := inference.Infer(tt.value); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Infer(%q) = %#v, want %#v", tt.value, got, tt.expected)
+			}
+		})
+	}
+
+	booleansOnly, err := NewTypeInference(models.TypeInferenceConfig{Types: []string{"bool"}})
+	if err != nil {
+		t.Fatalf("NewTypeInference() error = %v", err)
+	}
+	if got := booleansOnly.Infer("42"); got != "42" {
+		t.Errorf("Infer(42) with booleans only = %#v, want the string", got)
+	}
+
+	invalid := []models.TypeInferenceConfig{
+		{Types: []string{"date"}},
+		{Formats: []string{"csv"}},
+		{KeepStrings: []string{"[a"}},
+	}
+	for _, config := range invalid {
+		if _, err := NewTypeInference(config); err == nil {
+			t.Errorf("NewTypeInference(%+v) should fail", config)
+		}
+	}
+}
+
+// TestRegistryTypeInference tests inference through the registry, keep_strings and raw values
+func TestRegistryTypeInference(t *testing.T) {
+	registry := NewParserRegistry()
+	err := registry.ApplyTypeInference(models.TypeInferenceConfig{KeepStrings: []string{"*ZIP*", "app.version"}})
+	if err != nil {
+		t.Fatalf("ApplyTypeInference() error = %v", err)
+	}
+
+	tests := []struct {
+		filename string
+		content  string
+		expected map[string]interface{}
+		raw      map[string]string
+	}{
+		{
+			filename: ".env",
+			content:  "DEBUG=yes\nPORT=8080\nOFFICE_ZIP=02134\nTIMEOUT=5s\n",
+			expected: map[string]interface{}{"DEBUG": true, "PORT": 8080, "OFFICE_ZIP": "02134", "TIMEOUT": 5 * time.Second},
+			raw:      map[string]string{"DEBUG": "yes", "PORT": "8080", "TIMEOUT": "5s"},
+		},
+		{
+			filename: "app.properties",
+			content:  "app.version=1.10\napp.ratio=1.10\n",
+			expected: map[string]interface{}{"app.version": "1.10", "app.ratio": 1.1},
+			raw:      map[string]string{`app\.ratio`: "1.10"},
+		},
+		{
+			filename: "app.ini",
+			content:  "[server]\nenabled = on\nport = 80\nport = 81\n",
+			expected: map[string]interface{}{"server": map[string]interface{}{"enabled": true, "port": []interface{}{80, 81}}},
+			raw:      map[string]string{"server.enabled": "on", "server.port[0]": "80", "server.port[1]": "81"},
+		},
+		{
+			filename: "app.yaml",
+			content:  "debug: \"yes\"\n",
+			expected: map[string]interface{}{"debug": "yes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			processor, err := registry.GetProcessor(tt.filename)
+			if err != nil {
+				t.Fatalf("GetProcessor() error = %v", err)
+			}
+
+			configData, err := processor.Process(context.Background(), tt.filename, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(configData.Data, tt.expected) {
+				t.Errorf("Process() data = %#v, want %#v", configData.Data, tt.expected)
+			}
+			for path, expected := range tt.raw {
+				if raw, ok := RawValue(configData, path); !ok || raw != expected {
+					t.Errorf("RawValue(%s) = %q, %v, want %q", path, raw, ok, expected)
+				}
+			}
+			if raw, _ := configData.Metadata["raw_values"].(map[string]string); len(raw) != len(tt.raw) {
+				t.Errorf("Process() raw values = %v, want %v", raw, tt.raw)
+			}
+		})
+	}
+}
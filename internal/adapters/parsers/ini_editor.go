@@ -0,0 +1,446 @@
+package parsers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// iniEditor edits INI files line by line, keeping comments, separators and inline comments
+type iniEditor struct {
+	text *textLines
+}
+
+// iniSection is a section header and its body: lines [start, end). The root section has
+// no header and starts at line 0.
+type iniSection struct {
+	segments []string
+	start    int
+	end      int
+	quoted   bool
+	root     bool
+}
+
+// iniEntry locates a key: lines [start, end), the text before its value and the inline
+// comment after a single-line value
+type iniEntry struct {
+	section   *iniSection
+	key       string
+	start     int
+	end       int
+	prefix    string
+	separator string
+	comment   string
+}
+
+// newINIEditor creates an editor for INI content
+func newINIEditor(content []byte) (models.ConfigEditor, error) {
+	if _, _, err := parseINIContent(content, INIOptions{}); err != nil {
+		return nil, err
+	}
+	return &iniEditor{text: newTextLines(content)}, nil
+}
+
+// Set replaces the value of a key, or adds the key (and its section). Repeated keys are
+// addressed by index; setting a repeated key without an index replaces all of its lines.
+func (e *iniEditor) Set(path string, value interface{}) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	// Maps are written as sections, one key at a time
+	if values, ok := value.(map[string]interface{}); ok {
+		for _, key := range sortedMapKeys(values) {
+			if err := e.Set(models.JoinPath(path, key), values[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	sectionPath, key, index, err := splitINIEditPath(parsed)
+	if err != nil {
+		return err
+	}
+
+	sections, entries := e.scan()
+	matches := matchINIEntries(entries, sectionPath, key)
+
+	// Guard clause: an index addresses one existing occurrence of a repeated key
+	if index >= 0 {
+		if index >= len(matches) {
+			return pathNotFound(path)
+		}
+		lines, err := formatINILines(matches[index], value)
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		e.text.replace(matches[index].start, matches[index].end, lines...)
+		return nil
+	}
+
+	if len(matches) > 0 {
+		lines, err := formatINILines(matches[0], value)
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		for i := len(matches) - 1; i > 0; i-- {
+			e.text.replace(matches[i].start, matches[i].end)
+		}
+		e.text.replace(matches[0].start, matches[0].end, lines...)
+		return nil
+	}
+
+	return e.add(path, sections, entries, sectionPath, key, value)
+}
+
+// add inserts a new key into its section, appending the section when it does not exist
+func (e *iniEditor) add(path string, sections []*iniSection, entries []iniEntry, sectionPath []string, key string, value interface{}) error {
+	template := iniEntry{key: key, prefix: key, separator: " = "}
+	if len(entries) > 0 {
+		template.separator = entries[len(entries)-1].separator
+	}
+	template.prefix = key + template.separator
+
+	var section *iniSection
+	for _, candidate := range sections {
+		if equalSegments(candidate.segments, sectionPath) {
+			section = candidate
+		}
+	}
+
+	if section != nil {
+		for _, entry := range entries {
+			if entry.section == section {
+				template.prefix = leadingWhitespace(e.text.lines[entry.start]) + key + template.separator
+			}
+		}
+	}
+
+	lines, err := formatINILines(template, value)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	// Guard clause: new sections are appended at the end of the file
+	if section == nil {
+		at := e.text.lastContentLine(0, len(e.text.lines))
+		header := []string{formatINISectionHeader(sectionPath, false)}
+		if at > 0 {
+			header = append([]string{""}, header...)
+		}
+		e.text.replace(at, len(e.text.lines), append(header, lines...)...)
+		return nil
+	}
+
+	e.text.insert(e.text.lastContentLine(section.start, section.end), lines...)
+	return nil
+}
+
+// Delete removes a key (all of its occurrences unless indexed) or a section with its
+// subsections
+func (e *iniEditor) Delete(path string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	sections, entries := e.scan()
+	var ranges [][2]int
+
+	if sectionPath, key, index, err := splitINIEditPath(parsed); err == nil {
+		matches := matchINIEntries(entries, sectionPath, key)
+		if index >= 0 {
+			if index < len(matches) {
+				ranges = append(ranges, [2]int{matches[index].start, matches[index].end})
+			}
+		} else {
+			for _, match := range matches {
+				ranges = append(ranges, [2]int{match.start, match.end})
+			}
+		}
+	}
+
+	if keys, ok := pathKeys(parsed); ok {
+		for _, section := range sections {
+			if !section.root && hasSegmentPrefix(section.segments, keys) {
+				ranges = append(ranges, [2]int{section.start, section.end})
+			}
+		}
+	}
+
+	if len(ranges) == 0 {
+		return pathNotFound(path)
+	}
+
+	e.text.remove(ranges)
+	return nil
+}
+
+// Rename renames a key or the section named by path
+func (e *iniEditor) Rename(path, newKey string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+	if newKey == "" {
+		return fmt.Errorf("new key cannot be empty")
+	}
+	keys, ok := pathKeys(parsed)
+	if !ok {
+		return fmt.Errorf("cannot rename %s: array items have no key", path)
+	}
+
+	sections, entries := e.scan()
+	sectionPath, key := keys[:len(keys)-1], keys[len(keys)-1]
+	renamed := append(append([]string{}, sectionPath...), newKey)
+
+	if len(matchINIEntries(entries, sectionPath, newKey)) > 0 {
+		return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+	}
+	for _, section := range sections {
+		if !section.root && hasSegmentPrefix(section.segments, renamed) {
+			return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+		}
+	}
+
+	found := false
+	for _, entry := range matchINIEntries(entries, sectionPath, key) {
+		found = true
+		line := e.text.lines[entry.start]
+		offset := len(leadingWhitespace(line))
+		e.text.lines[entry.start] = line[:offset] + newKey + line[offset+len(entry.key):]
+	}
+
+	for _, section := range sections {
+		if section.root || !hasSegmentPrefix(section.segments, keys) {
+			continue
+		}
+		found = true
+		segments := append([]string{}, section.segments...)
+		segments[len(keys)-1] = newKey
+		line := e.text.lines[section.start]
+		closing := strings.IndexByte(line, ']')
+		e.text.lines[section.start] = leadingWhitespace(line) + formatINISectionHeader(segments, section.quoted) + line[closing+1:]
+	}
+
+	if !found {
+		return pathNotFound(path)
+	}
+	return nil
+}
+
+// Bytes returns the edited content
+func (e *iniEditor) Bytes() ([]byte, error) {
+	return e.text.bytes(), nil
+}
+
+// scan locates the sections and keys of the content
+func (e *iniEditor) scan() ([]*iniSection, []iniEntry) {
+	lines := e.text.lines
+	current := &iniSection{root: true, end: len(lines)}
+	sections := []*iniSection{current}
+	var entries []iniEntry
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := trimLine(line)
+		if trimmed == "" || isINIComment(trimmed) {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") {
+			closing := strings.IndexByte(trimmed, ']')
+			segments, err := splitINISectionName(trimmed[1:max(closing, 1)])
+			if closing == -1 || err != nil {
+				continue
+			}
+			current.end = i
+			current = &iniSection{segments: segments, start: i, end: len(lines), quoted: strings.Contains(trimmed[:closing], `"`)}
+			sections = append(sections, current)
+			continue
+		}
+
+		entry := iniEntry{section: current, start: i, end: i + 1}
+		indent := leadingWhitespace(line)
+		separator := strings.IndexAny(trimmed, "=:")
+		if separator == -1 {
+			entry.key = strings.TrimSpace(stripINIInlineComment(trimmed))
+			entry.prefix = indent + entry.key
+			entry.separator = " = "
+			entry.comment = trimmed[len(strings.TrimRight(stripINIInlineComment(trimmed), " \t")):]
+		} else {
+			entry.key = strings.TrimSpace(trimmed[:separator])
+			rawValue := trimmed[separator+1:]
+			valueStart := len(rawValue) - len(strings.TrimLeft(rawValue, " \t"))
+			entry.prefix = indent + trimmed[:separator+1] + rawValue[:valueStart]
+			entry.separator = entry.prefix[len(indent)+len(entry.key):]
+			entry.comment = iniValueComment(rawValue[valueStart:])
+		}
+
+		// Backslash and indented continuation lines belong to the key
+		for j := i; strings.HasSuffix(trimLine(lines[j]), "\\") && j+1 < len(lines); j++ {
+			entry.end = j + 2
+		}
+		if separator != -1 {
+			for entry.end < len(lines) {
+				next := lines[entry.end]
+				if trimLine(next) == "" || len(leadingWhitespace(next)) <= len(indent) {
+					break
+				}
+				entry.end++
+			}
+		}
+		if entry.end > i+1 {
+			entry.comment = ""
+		}
+
+		entries = append(entries, entry)
+		i = entry.end - 1
+	}
+
+	return sections, entries
+}
+
+// iniValueComment returns the inline comment (with its leading whitespace) after a value
+func iniValueComment(rawValue string) string {
+	// Quoted values keep comment characters
+	if len(rawValue) > 1 && (rawValue[0] == '"' || rawValue[0] == '\'') {
+		if closing := strings.IndexByte(rawValue[1:], rawValue[0]); closing != -1 {
+			return rawValue[closing+2:]
+		}
+	}
+
+	value := strings.TrimRight(stripINIInlineComment(rawValue), " \t")
+	return rawValue[len(value):]
+}
+
+// formatINILines formats a key with a value, keeping the prefix and comment of an entry.
+// Lists are written as repeated keys and nil as a bare key.
+func formatINILines(entry iniEntry, value interface{}) ([]string, error) {
+	if values, ok := value.([]interface{}); ok {
+		lines := make([]string, 0, len(values))
+		for _, item := range values {
+			itemLines, err := formatINILines(iniEntry{prefix: entry.prefix, key: entry.key, separator: entry.separator}, item)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, itemLines...)
+		}
+		return lines, nil
+	}
+
+	if value == nil {
+		key := entry.prefix[:len(leadingWhitespace(entry.prefix))+len(entry.key)]
+		return []string{key + entry.comment}, nil
+	}
+
+	text, err := scalarText(value)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := entry.prefix
+	if len(prefix) == len(leadingWhitespace(prefix))+len(entry.key) {
+		prefix += entry.separator
+	}
+
+	// Multi-line values continue on indented lines
+	parts := strings.Split(text, "\n")
+	parts[0] = quoteINIValue(parts[0])
+	lines := []string{prefix + parts[0] + entry.comment}
+	for _, part := range parts[1:] {
+		lines = append(lines, leadingWhitespace(prefix)+"    "+part)
+	}
+	return lines, nil
+}
+
+// quoteINIValue quotes values that would otherwise lose whitespace or comment characters
+func quoteINIValue(text string) string {
+	if text != strings.TrimSpace(text) || stripINIInlineComment(text) != text || (len(text) > 0 && (text[0] == '"' || text[0] == '\'')) {
+		if !strings.Contains(text, `"`) {
+			return `"` + text + `"`
+		}
+		return "'" + text + "'"
+	}
+	return text
+}
+
+// formatINISectionHeader formats a section header; quoted headers keep the git-config
+// subsection style
+func formatINISectionHeader(segments []string, quoted bool) string {
+	if quoted && len(segments) > 1 {
+		return "[" + strings.Join(segments[:len(segments)-1], ".") + ` "` + segments[len(segments)-1] + `"]`
+	}
+	return "[" + strings.Join(segments, ".") + "]"
+}
+
+// splitINIEditPath splits a path into the section segments, the key and an optional
+// occurrence index (-1 when absent)
+func splitINIEditPath(path models.Path) ([]string, string, int, error) {
+	index := -1
+	if last := path[len(path)-1]; last.IsIndex {
+		index = last.Index
+		path = path[:len(path)-1]
+	}
+
+	keys, ok := pathKeys(path)
+	if !ok || len(keys) == 0 {
+		return nil, "", 0, fmt.Errorf("path %s does not name an INI key", path)
+	}
+	return keys[:len(keys)-1], keys[len(keys)-1], index, nil
+}
+
+// matchINIEntries returns the occurrences of a key in the sections named by sectionPath
+func matchINIEntries(entries []iniEntry, sectionPath []string, key string) []iniEntry {
+	var matches []iniEntry
+	for _, entry := range entries {
+		if entry.key == key && equalSegments(entry.section.segments, sectionPath) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// pathKeys returns the keys of a path without array indexes
+func pathKeys(path models.Path) ([]string, bool) {
+	keys := make([]string, len(path))
+	for i, segment := range path {
+		if segment.IsIndex {
+			return nil, false
+		}
+		keys[i] = segment.Key
+	}
+	return keys, true
+}
+
+// equalSegments checks if two segment lists are equal
+func equalSegments(a, b []string) bool {
+	return len(a) == len(b) && hasSegmentPrefix(a, b)
+}
+
+// hasSegmentPrefix checks if segments start with prefix
+func hasSegmentPrefix(segments, prefix []string) bool {
+	if len(prefix) > len(segments) {
+		return false
+	}
+	for i := range prefix {
+		if segments[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedMapKeys returns the keys of a map in sorted order
+func sortedMapKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,21 +2,47 @@ package parsers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
+// INIDuplicateSections selects how repeated section headers are handled
+type INIDuplicateSections string
+
+const (
+	// INIDuplicateSectionsMerge merges the keys of repeated sections (default)
+	INIDuplicateSectionsMerge INIDuplicateSections = "merge"
+	// INIDuplicateSectionsError reports repeated sections as errors
+	INIDuplicateSectionsError INIDuplicateSections = "error"
+)
+
+// INIOptions configures how INI files are mapped
+type INIOptions struct {
+	// DuplicateSections selects how repeated section headers are handled
+	DuplicateSections INIDuplicateSections
+	// CaseInsensitive lowercases section and key names so they match regardless of case
+	CaseInsensitive bool
+}
+
 // INIProcessor implements FileProcessor for INI files
 type INIProcessor struct {
 	supportedExtensions []string
+	options             INIOptions
 }
 
 // NewINIProcessor creates a new INI processor
 func NewINIProcessor() *INIProcessor {
+	return NewINIProcessorWithOptions(INIOptions{})
+}
+
+// NewINIProcessorWithOptions creates a new INI processor with the given options
+func NewINIProcessorWithOptions(options INIOptions) *INIProcessor {
 	return &INIProcessor{
 		supportedExtensions: []string{"ini"},
+		options:             options,
 	}
 }
 
@@ -31,12 +57,12 @@ func (p *INIProcessor) Process(ctx context.Context, filename string, content []b
 		return nil, err
 	}
 
-	data, err := parseINIContent(content)
+	data, positions, err := parseINIContent(content, p.options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse INI: %w", err)
 	}
 
-	return createConfigData(filename, "ini", data), nil
+	return createConfigData(filename, "ini", data, positions), nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -45,80 +71,265 @@ func (p *INIProcessor) GetSupportedExtensions() []string {
 }
 
 // parseINIContent parses INI content
-func parseINIContent(content []byte) (map[string]interface{}, error) {
+func parseINIContent(content []byte, options INIOptions) (map[string]interface{}, map[string]models.Position, error) {
 	// Guard clause: empty content
 	if isEmptyContent(content) {
-		return createEmptyResult(), nil
+		return createEmptyResult(), createEmptyPositions(), nil
 	}
 
-	return parseINIContentWithSections(content), nil
+	return parseINIContentWithSections(content, options)
 }
 
-// parseINIContentWithSections parses INI content with sections
-func parseINIContentWithSections(content []byte) map[string]interface{} {
-	result := createEmptyResult()
-	currentSection := ""
-	lines := splitLines(content)
+// iniParser holds the state of an INI document being parsed
+type iniParser struct {
+	options   INIOptions
+	result    map[string]interface{}
+	positions map[string]models.Position
+
+	// section is the map of the current section and sectionPath its key path
+	section     map[string]interface{}
+	sectionPath string
+	seen        map[string]bool
 
-	for _, line := range lines {
+	// last is the most recent key, which indented lines continue
+	last *iniValue
+}
+
+// iniValue tracks the value continuation lines are appended to
+type iniValue struct {
+	key    string
+	index  int
+	indent int
+}
+
+// parseINIContentWithSections parses INI content with sections, recording key positions.
+// Supported dialect features: nested sections ([a.b] and git-config style [a "b"]),
+// "=" and ":" separators, inline comments, indented and backslash continuation lines
+// and repeated keys as arrays.
+func parseINIContentWithSections(content []byte, options INIOptions) (map[string]interface{}, map[string]models.Position, error) {
+	parser := &iniParser{
+		options:   options,
+		result:    createEmptyResult(),
+		positions: createEmptyPositions(),
+		seen:      make(map[string]bool),
+	}
+	parser.section = parser.result
+
+	var errs []error
+	lines := splitLines(content)
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
 		trimmedLine := trimLine(line)
-		
-		// Skip empty lines and comments
-		if isEmptyLine(trimmedLine) || isINIComment(trimmedLine) {
+
+		// Skip empty lines and comments; they end any continuation
+		if trimmedLine == "" || isINIComment(trimmedLine) {
+			parser.last = nil
 			continue
 		}
 
-		// Check for section header
-		if isINISection(trimmedLine) {
-			currentSection = extractINISection(trimmedLine)
-			result[currentSection] = createEmptyResult()
+		// Indented lines continue the previous value
+		if indent := len(line) - len(strings.TrimLeft(line, " \t")); parser.last != nil && indent > parser.last.indent {
+			parser.appendValue("\n" + stripINIInlineComment(trimmedLine))
 			continue
 		}
 
-		// Parse key-value pair
-		if key, value, ok := parseINIKeyValue(trimmedLine); ok {
-			setINIValue(result, currentSection, key, value)
+		var err error
+		if strings.HasPrefix(trimmedLine, "[") {
+			err = parser.startSection(trimmedLine, i+1, line)
+		} else {
+			// Trailing backslashes join the next line; the key is on the first one
+			start := i
+			for strings.HasSuffix(trimmedLine, "\\") && i+1 < len(lines) {
+				i++
+				trimmedLine = strings.TrimRight(trimmedLine[:len(trimmedLine)-1], " \t") + " " + trimLine(lines[i])
+			}
+			err = parser.setKeyValue(trimmedLine, start+1, line)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	return result
+	return parser.result, parser.positions, errors.Join(errs...)
 }
 
-// isINIComment checks if a line is a comment in INI format
-func isINIComment(line string) bool {
-	return strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#")
+// startSection switches to the section named by a header line, creating nested maps
+func (p *iniParser) startSection(header string, lineNumber int, line string) error {
+	p.last = nil
+
+	closing := strings.IndexByte(header, ']')
+	if closing == -1 {
+		return &LineError{Line: lineNumber, Message: fmt.Sprintf("unterminated section header %q", header)}
+	}
+	if rest := strings.TrimSpace(header[closing+1:]); rest != "" && !isINIComment(rest) {
+		return &LineError{Line: lineNumber, Message: fmt.Sprintf("unexpected characters after section header: %q", rest)}
+	}
+
+	segments, err := splitINISectionName(header[1:closing])
+	if err != nil {
+		return &LineError{Line: lineNumber, Message: err.Error()}
+	}
+
+	section := p.result
+	path := ""
+	for _, segment := range segments {
+		segment = p.normalize(segment)
+		path = models.JoinPath(path, segment)
+
+		switch existing := section[segment].(type) {
+		case nil:
+			child := createEmptyResult()
+			section[segment] = child
+			section = child
+		case map[string]interface{}:
+			section = existing
+		default:
+			return &LineError{Line: lineNumber, Message: fmt.Sprintf("section %q conflicts with key %q", header[1:closing], path)}
+		}
+	}
+
+	if p.seen[path] && p.options.DuplicateSections == INIDuplicateSectionsError {
+		return &LineError{Line: lineNumber, Message: fmt.Sprintf("duplicate section %q", header[1:closing])}
+	}
+	p.seen[path] = true
+
+	recordPosition(p.positions, path, linePosition(lineNumber, line))
+	p.section = section
+	p.sectionPath = path
+	return nil
 }
 
-// isINISection checks if a line is a section header
-func isINISection(line string) bool {
-	return strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]")
+// splitINISectionName splits a section name into nested segments.
+// Dots separate segments; a quoted git-config subsection ([remote "origin"]) is kept whole.
+func splitINISectionName(name string) ([]string, error) {
+	name = strings.TrimSpace(name)
+
+	var subsection string
+	if quote := strings.IndexByte(name, '"'); quote != -1 {
+		if len(name) < quote+2 || name[len(name)-1] != '"' {
+			return nil, fmt.Errorf("malformed subsection in section %q", name)
+		}
+		subsection = name[quote+1 : len(name)-1]
+		name = strings.TrimSpace(name[:quote])
+	}
+
+	segments := strings.Split(name, ".")
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(segment)
+		if segments[i] == "" {
+			return nil, fmt.Errorf("empty segment in section name %q", name)
+		}
+	}
+
+	if subsection != "" {
+		segments = append(segments, subsection)
+	}
+	return segments, nil
+}
+
+// setKeyValue stores a key/value line in the current section; repeated keys become arrays
+func (p *iniParser) setKeyValue(trimmedLine string, lineNumber int, line string) error {
+	key, value, hasValue := splitINIKeyValue(trimmedLine)
+	if !isValidKey(key) {
+		return &LineError{Line: lineNumber, Message: fmt.Sprintf("missing key in %q", trimmedLine)}
+	}
+	key = p.normalize(key)
+	path := models.JoinPath(p.sectionPath, key)
+	position := linePosition(lineNumber, line)
+
+	// A bare key declares the key without a value
+	var parsed interface{}
+	if hasValue {
+		parsed = parseINIValue(value)
+	}
+
+	index := 0
+	switch existing := p.section[key].(type) {
+	case nil:
+		if _, exists := p.section[key]; exists {
+			p.section[key] = []interface{}{nil, parsed}
+			index = 1
+		} else {
+			p.section[key] = parsed
+		}
+	case map[string]interface{}:
+		return &LineError{Line: lineNumber, Message: fmt.Sprintf("key %q conflicts with section %q", key, path)}
+	case []interface{}:
+		index = len(existing)
+		p.section[key] = append(existing, parsed)
+	default:
+		p.section[key] = []interface{}{existing, parsed}
+		index = 1
+	}
+
+	recordPosition(p.positions, path, position)
+	if index > 0 {
+		recordPosition(p.positions, models.IndexPath(path, 0), p.positions[path])
+		recordPosition(p.positions, models.IndexPath(path, index), position)
+	}
+
+	p.last = &iniValue{key: key, index: index, indent: len(line) - len(strings.TrimLeft(line, " \t"))}
+	if !hasValue {
+		p.last = nil
+	}
+	return nil
 }
 
-// extractINISection extracts section name from section header
-func extractINISection(line string) string {
-	return line[1 : len(line)-1]
+// appendValue appends continuation text to the most recent value
+func (p *iniParser) appendValue(text string) {
+	if values, ok := p.section[p.last.key].([]interface{}); ok {
+		values[p.last.index] = fmt.Sprint(values[p.last.index]) + text
+		return
+	}
+	p.section[p.last.key] = fmt.Sprint(p.section[p.last.key]) + text
 }
 
-// parseINIKeyValue parses a key-value pair from a line
-func parseINIKeyValue(line string) (string, string, bool) {
-	separatorIndex := findKeyValueSeparator(line)
+// normalize applies the case sensitivity option to section and key names
+func (p *iniParser) normalize(name string) string {
+	if p.options.CaseInsensitive {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// splitINIKeyValue splits a line at the first "=" or ":" separator
+func splitINIKeyValue(line string) (string, string, bool) {
+	separatorIndex := strings.IndexAny(line, "=:")
 	if separatorIndex == -1 {
-		return "", "", false
+		return strings.TrimSpace(stripINIInlineComment(line)), "", false
 	}
 
 	key, value := extractKeyValue(line, separatorIndex)
-	return key, value, isValidKey(key)
+	return key, value, true
 }
 
-// setINIValue sets a value in the appropriate section
-func setINIValue(result map[string]interface{}, section, key, value string) {
-	cleanValue := removeQuotes(value)
-	
-	if section != "" {
-		if sectionMap, ok := result[section].(map[string]interface{}); ok {
-			sectionMap[key] = cleanValue
+// parseINIValue strips inline comments and surrounding quotes from a value
+func parseINIValue(value string) string {
+	// Quoted values keep comment characters
+	if len(value) > 1 && (value[0] == '"' || value[0] == '\'') {
+		if closing := strings.IndexByte(value[1:], value[0]); closing != -1 {
+			rest := strings.TrimSpace(value[closing+2:])
+			if rest == "" || isINIComment(rest) {
+				return value[1 : closing+1]
+			}
 		}
-	} else {
-		result[key] = cleanValue
 	}
+
+	return removeQuotes(strings.TrimSpace(stripINIInlineComment(value)))
+}
+
+// stripINIInlineComment removes a ";" or "#" comment preceded by whitespace
+func stripINIInlineComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if (value[i] == ';' || value[i] == '#') && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return value[:i]
+		}
+	}
+	return value
+}
+
+// isINIComment checks if a line is a comment in INI format
+func isINIComment(line string) bool {
+	return strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#")
 }
@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestINIProcessorDialects tests nested sections, separators, continuations and repeated keys
+func TestINIProcessorDialects(t *testing.T) {
+	content := []byte(`; global settings
+name = app ; inline comment
+
+[database]
+host: db.local
+password = "secret ; not a comment"
+
+[database.replica]
+host = replica.local
+
+[remote "origin"]
+	url = git@example.com:org/repo.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+	fetch = +refs/tags/*:refs/tags/*
+
+[Service]
+ExecStart=/usr/bin/app \
+  --verbose
+Description = first line
+  second line
+Environment=A=1
+Environment=B=2
+
+[database]
+port = 5432
+`)
+
+	configData, err := NewINIProcessor().Process(context.Background(), "app.ini", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"name": "app",
+		"database": map[string]interface{}{
+			"host":     "db.local",
+			"password": "secret ; not a comment",
+			"replica":  map[string]interface{}{"host": "replica.local"},
+			"port":     "5432",
+		},
+		"remote": map[string]interface{}{
+			"origin": map[string]interface{}{
+				"url":   "git@example.com:org/repo.git",
+				"fetch": []interface{}{"+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"},
+			},
+		},
+		"Service": map[string]interface{}{
+			"ExecStart":   "/usr/bin/app --verbose",
+			"Description": "first line\nsecond line",
+			"Environment": []interface{}{"A=1", "B=2"},
+		},
+	}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+
+	positions := map[string]int{
+		"database.replica.host":  9,
+		"remote.origin.fetch":    13,
+		"remote.origin.fetch[1]": 14,
+		"Service.ExecStart":      17,
+		"database.port":          25,
+	}
+	for path, line := range positions {
+		if position, ok := configData.PositionOf(path); !ok || position.Line != line {
+			t.Errorf("PositionOf(%s) = %v, %v, want line %d", path, position, ok, line)
+		}
+	}
+}
+
+// TestINIProcessorOptions tests duplicate section handling and case-insensitive names
+func TestINIProcessorOptions(t *testing.T) {
+	content := []byte("[Server]\nPort = 80\n[server]\nHOST = example.com\n")
+
+	configData, err := NewINIProcessorWithOptions(INIOptions{CaseInsensitive: true}).Process(context.Background(), "app.ini", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	expected := map[string]interface{}{"server": map[string]interface{}{"port": "80", "host": "example.com"}}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+
+	strict := NewINIProcessorWithOptions(INIOptions{DuplicateSections: INIDuplicateSectionsError, CaseInsensitive: true})
+	_, err = strict.Process(context.Background(), "app.ini", content)
+	if err == nil || !strings.Contains(err.Error(), "line 3: duplicate section") {
+		t.Errorf("Process() error = %v, want duplicate section on line 3", err)
+	}
+
+	_, err = NewINIProcessor().Process(context.Background(), "bad.ini", []byte("[open\n= value\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 1") || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Process() error = %v, want errors on lines 1 and 2", err)
+	}
+}
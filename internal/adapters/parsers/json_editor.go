@@ -0,0 +1,295 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// jsonIdentifierPattern matches keys JSON5 allows without quotes
+var jsonIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsonEditor edits JSON, JSONC and JSON5 content in place: only the bytes of the edited
+// values change, so comments, key order and formatting elsewhere are kept
+type jsonEditor struct {
+	content []byte
+	spans   map[string]*jsonSpan
+	unit    string
+}
+
+// newJSONEditor creates an editor for JSON, JSONC or JSON5 content
+func newJSONEditor(content []byte) (models.ConfigEditor, error) {
+	editor := &jsonEditor{content: content, unit: detectIndentUnit(content)}
+	if err := editor.reload(); err != nil {
+		return nil, err
+	}
+	return editor, nil
+}
+
+// Set replaces the value at path, or inserts it as a new member or array item
+func (e *jsonEditor) Set(path string, value interface{}) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	if span, ok := e.spans[parsed.String()]; ok {
+		text, err := e.marshal(value, e.lineIndent(span.Start))
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		return e.splice(span.Start, span.End, text)
+	}
+
+	// Find the deepest existing ancestor and build the missing part below it
+	depth := len(parsed) - 1
+	for depth > 0 && e.spans[parsed[:depth].String()] == nil {
+		depth--
+	}
+	parent := e.spans[parsed[:depth].String()]
+	segment := parsed[depth]
+
+	nested, err := nestValue(parsed[depth+1:], value)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	switch {
+	case parent.Kind == '{' && !segment.IsIndex:
+		return e.insert(parent, segment, nested)
+	case parent.Kind == '[' && segment.IsIndex && segment.Index == len(parent.Children):
+		return e.insert(parent, segment, nested)
+	case parent.Kind == '[' && segment.IsIndex:
+		return pathNotFound(path)
+	}
+	return fmt.Errorf("cannot set %s: %s is not an object or array", path, parsed[:depth].String())
+}
+
+// insert adds a member (or an item) as the last child of an object (or array)
+func (e *jsonEditor) insert(parent *jsonSpan, segment models.PathSegment, value interface{}) error {
+	parentIndent := e.lineIndent(parent.Start)
+	indent := parentIndent + e.unit
+	if len(parent.Children) > 0 {
+		indent = e.lineIndent(e.spans[parent.Children[0]].KeyStart)
+	}
+
+	text, err := e.marshal(value, indent)
+	if err != nil {
+		return err
+	}
+	if !segment.IsIndex {
+		key, err := e.marshal(segment.Key, "")
+		if err != nil {
+			return err
+		}
+		text = key + ": " + text
+	}
+
+	// Empty containers are opened onto their own lines
+	if len(parent.Children) == 0 {
+		return e.splice(parent.Start+1, parent.End-1, "\n"+indent+text+"\n"+parentIndent)
+	}
+
+	last := e.spans[parent.Children[len(parent.Children)-1]]
+
+	// Single-line containers stay on one line
+	if !bytes.Contains(e.content[parent.Start:parent.End], []byte("\n")) {
+		return e.splice(last.End, last.End, ", "+text)
+	}
+
+	lineEnd, trailingComma, ok := e.restOfLine(last.End)
+	if !ok {
+		return e.splice(last.End, last.End, ",\n"+indent+text)
+	}
+	if trailingComma {
+		return e.splice(lineEnd, lineEnd, "\n"+indent+text+",")
+	}
+
+	// The comma goes after the last value, the new child after any comment on its line
+	updated := make([]byte, 0, len(e.content)+len(text)+len(indent)+2)
+	updated = append(updated, e.content[:last.End]...)
+	updated = append(updated, ',')
+	updated = append(updated, e.content[last.End:lineEnd]...)
+	updated = append(updated, "\n"+indent+text...)
+	updated = append(updated, e.content[lineEnd:]...)
+	return e.update(updated)
+}
+
+// Delete removes the member or array item at path
+func (e *jsonEditor) Delete(path string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	key := parsed.String()
+	span, ok := e.spans[key]
+	if !ok {
+		return pathNotFound(path)
+	}
+	parent := e.spans[parsed[:len(parsed)-1].String()]
+
+	position := 0
+	for i, child := range parent.Children {
+		if child == key {
+			position = i
+		}
+	}
+
+	// Guard clause: removing the only child leaves an empty container
+	if len(parent.Children) == 1 {
+		return e.splice(parent.Start+1, parent.End-1, "")
+	}
+
+	// Children on lines of their own are removed with their lines
+	lineStart := bytes.LastIndexByte(e.content[:span.KeyStart], '\n') + 1
+	if lineEnd, _, ok := e.restOfLine(span.End); ok && len(bytes.TrimLeft(e.content[lineStart:span.KeyStart], " \t")) == 0 {
+		if position < len(parent.Children)-1 {
+			return e.splice(lineStart, min(lineEnd+1, len(e.content)), "")
+		}
+
+		// The last child takes the comma of the previous child with it, unless it has a
+		// trailing comma of its own
+		end := min(lineEnd+1, len(e.content))
+		if _, trailingComma, _ := e.restOfLine(span.End); trailingComma {
+			return e.splice(lineStart, end, "")
+		}
+		previous := e.spans[parent.Children[position-1]]
+		comma := previous.End + bytes.IndexByte(e.content[previous.End:lineStart], ',')
+		updated := append(append([]byte{}, e.content[:comma]...), e.content[comma+1:lineStart]...)
+		return e.update(append(updated, e.content[end:]...))
+	}
+
+	if position < len(parent.Children)-1 {
+		return e.splice(span.KeyStart, e.spans[parent.Children[position+1]].KeyStart, "")
+	}
+	return e.splice(e.spans[parent.Children[position-1]].End, span.End, "")
+}
+
+// Rename renames the object member at path
+func (e *jsonEditor) Rename(path, newKey string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+	if parsed[len(parsed)-1].IsIndex {
+		return fmt.Errorf("cannot rename %s: array items have no key", path)
+	}
+
+	span, ok := e.spans[parsed.String()]
+	if !ok {
+		return pathNotFound(path)
+	}
+	renamed := append(append(models.Path{}, parsed[:len(parsed)-1]...), models.KeySegment(newKey))
+	if _, exists := e.spans[renamed.String()]; exists {
+		return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+	}
+
+	// Unquoted JSON5 keys stay unquoted when possible
+	text := newKey
+	if quote := e.content[span.KeyStart]; quote == '"' || quote == '\'' || !jsonIdentifierPattern.MatchString(newKey) {
+		if text, err = e.marshal(newKey, ""); err != nil {
+			return err
+		}
+	}
+	return e.splice(span.KeyStart, span.KeyEnd, text)
+}
+
+// Bytes returns the edited content
+func (e *jsonEditor) Bytes() ([]byte, error) {
+	return e.content, nil
+}
+
+// splice replaces the bytes [start, end) with text
+func (e *jsonEditor) splice(start, end int, text string) error {
+	updated := make([]byte, 0, len(e.content)-(end-start)+len(text))
+	updated = append(updated, e.content[:start]...)
+	updated = append(updated, text...)
+	updated = append(updated, e.content[end:]...)
+	return e.update(updated)
+}
+
+// update replaces the content and locates its values again
+func (e *jsonEditor) update(content []byte) error {
+	previous := e.content
+	e.content = content
+	if err := e.reload(); err != nil {
+		e.content = previous
+		return fmt.Errorf("edit produced invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// reload locates every value of the content
+func (e *jsonEditor) reload() error {
+	spans, err := parseJSONSpans(e.content)
+	if err != nil {
+		return err
+	}
+	e.spans = spans
+	return nil
+}
+
+// lineIndent returns the indentation of the line containing offset
+func (e *jsonEditor) lineIndent(offset int) string {
+	lineStart := bytes.LastIndexByte(e.content[:offset], '\n') + 1
+	return leadingWhitespace(string(e.content[lineStart:offset]))
+}
+
+// restOfLine checks that only an optional comma, whitespace and a line comment follow
+// offset on its line, returning the end of the line and whether the comma is present
+func (e *jsonEditor) restOfLine(offset int) (int, bool, bool) {
+	lineEnd := len(e.content)
+	if newline := bytes.IndexByte(e.content[offset:], '\n'); newline != -1 {
+		lineEnd = offset + newline
+	}
+
+	rest := bytes.TrimLeft(e.content[offset:lineEnd], " \t")
+	comma := bytes.HasPrefix(rest, []byte(","))
+	rest = bytes.TrimSpace(bytes.TrimPrefix(rest, []byte(",")))
+	return lineEnd, comma, len(rest) == 0 || bytes.HasPrefix(rest, []byte("//"))
+}
+
+// marshal formats a value as JSON indented for a line starting with prefix
+func (e *jsonEditor) marshal(value interface{}, prefix string) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent(prefix, e.unit)
+	if err := encoder.Encode(models.NormalizeValue(value)); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// nestValue wraps a value in the maps and arrays named by the remaining path segments
+func nestValue(path models.Path, value interface{}) (interface{}, error) {
+	for i := len(path) - 1; i >= 0; i-- {
+		switch {
+		case !path[i].IsIndex:
+			value = map[string]interface{}{path[i].Key: value}
+		case path[i].Index == 0:
+			value = []interface{}{value}
+		default:
+			return nil, fmt.Errorf("index %d is out of range of a new array", path[i].Index)
+		}
+	}
+	return value, nil
+}
+
+// detectIndentUnit returns the indentation of the first indented line, or two spaces
+func detectIndentUnit(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		if indent := leadingWhitespace(line); indent != "" && strings.TrimSpace(line) != "" {
+			if indent[0] == '\t' {
+				return "\t"
+			}
+			return strings.Repeat(" ", len(indent))
+		}
+	}
+	return "  "
+}
@@ -0,0 +1,553 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// jsoncNumberPattern matches numbers allowed by strict JSON (and therefore JSONC)
+var jsoncNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// lenientJSONParser parses JSONC (comments and trailing commas) and, when json5 is set,
+// JSON5 (unquoted keys, single quotes, hex numbers, Infinity/NaN, extra escapes).
+// It records key positions and keeps comments attached to the keys they document.
+type lenientJSONParser struct {
+	content []byte
+	pos     int
+	json5   bool
+	index   *lineIndex
+
+	positions map[string]models.Position
+	comments  []models.Comment
+	pending   []int
+	lastPath  string
+	lastLine  int
+
+	// spans is only set when parsing for edits (see parseJSONSpans)
+	spans map[string]*jsonSpan
+}
+
+// jsonSpan locates a value in the source. For object members KeyStart/KeyEnd delimit the
+// key; for array items KeyStart is the start of the item. Children lists the paths of the
+// members or items of objects and arrays in source order.
+type jsonSpan struct {
+	Key      string
+	KeyStart int
+	KeyEnd   int
+	Start    int
+	End      int
+	Kind     byte
+	Children []string
+}
+
+// parseJSONSpans parses JSON, JSONC or JSON5 content and locates every value by key path
+func parseJSONSpans(content []byte) (map[string]*jsonSpan, error) {
+	parser := &lenientJSONParser{
+		content:   content,
+		json5:     true,
+		index:     newLineIndex(content),
+		positions: createEmptyPositions(),
+		spans:     make(map[string]*jsonSpan),
+	}
+
+	if err := parser.skip(); err != nil {
+		return nil, err
+	}
+	if parser.pos >= len(content) {
+		return nil, fmt.Errorf("document has no top-level object")
+	}
+	if _, err := parser.parseValue(""); err != nil {
+		return nil, err
+	}
+	if parser.spans[""].Kind != '{' {
+		return nil, fmt.Errorf("top-level value must be an object")
+	}
+
+	return parser.spans, nil
+}
+
+// parseLenientJSON parses JSONC or JSON5 content into data, positions and comments
+func parseLenientJSON(content []byte, json5 bool) (map[string]interface{}, map[string]models.Position, []models.Comment, error) {
+	content = bytes.TrimPrefix(content, []byte("\uFEFF"))
+	parser := &lenientJSONParser{
+		content:   content,
+		json5:     json5,
+		index:     newLineIndex(content),
+		positions: createEmptyPositions(),
+	}
+
+	if err := parser.skip(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Guard clause: empty document (possibly only comments)
+	if parser.pos >= len(content) {
+		return createEmptyResult(), parser.positions, parser.comments, nil
+	}
+
+	value, err := parser.parseValue("")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := parser.skip(); err != nil {
+		return nil, nil, nil, err
+	}
+	if parser.pos < len(content) {
+		return nil, nil, nil, parser.errorf("unexpected %q after top-level value", parser.content[parser.pos])
+	}
+
+	result, ok := value.(map[string]interface{})
+	if !ok {
+		if value != nil {
+			return nil, nil, nil, fmt.Errorf("top-level value must be an object")
+		}
+		result = createEmptyResult()
+	}
+
+	return result, parser.positions, parser.comments, nil
+}
+
+// errorf creates an error located at the current position
+func (p *lenientJSONParser) errorf(format string, args ...interface{}) error {
+	position := p.index.position(p.pos)
+	return fmt.Errorf("line %d, column %d: %s", position.Line, position.Column, fmt.Sprintf(format, args...))
+}
+
+// skip skips whitespace and comments, recording the comments
+func (p *lenientJSONParser) skip() error {
+	for p.pos < len(p.content) {
+		switch c := p.content[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.pos++
+		case p.json5 && (c == '\v' || c == '\f'):
+			p.pos++
+		case c == '/' && p.pos+1 < len(p.content) && p.content[p.pos+1] == '/':
+			end := bytes.IndexByte(p.content[p.pos:], '\n')
+			if end == -1 {
+				end = len(p.content) - p.pos
+			}
+			p.addComment(p.pos, string(p.content[p.pos+2:p.pos+end]))
+			p.pos += end
+		case c == '/' && p.pos+1 < len(p.content) && p.content[p.pos+1] == '*':
+			end := bytes.Index(p.content[p.pos+2:], []byte("*/"))
+			if end == -1 {
+				return p.errorf("unterminated block comment")
+			}
+			p.addComment(p.pos, string(p.content[p.pos+2:p.pos+2+end]))
+			p.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// addComment records a comment, attaching it to the key on the same line or the next key
+func (p *lenientJSONParser) addComment(offset int, text string) {
+	position := p.index.position(offset)
+	comment := models.Comment{Text: strings.TrimSpace(text), Position: position}
+
+	if p.lastPath != "" && position.Line == p.lastLine {
+		comment.Path = p.lastPath
+		p.comments = append(p.comments, comment)
+		return
+	}
+
+	p.pending = append(p.pending, len(p.comments))
+	p.comments = append(p.comments, comment)
+}
+
+// record records the position of a key or array item starting at offset
+func (p *lenientJSONParser) record(path string, offset int) {
+	position := p.index.position(offset)
+	recordPosition(p.positions, path, position)
+
+	for _, i := range p.pending {
+		p.comments[i].Path = path
+	}
+	p.pending = nil
+	p.lastPath = path
+	p.lastLine = position.Line
+}
+
+// parseValue parses any value, recording its span when spans are collected
+func (p *lenientJSONParser) parseValue(path string) (interface{}, error) {
+	start := p.pos
+	value, err := p.parseAnyValue(path)
+	if err == nil && p.spans != nil {
+		span := p.span(path)
+		span.Start, span.End, span.Kind = start, p.pos, p.content[start]
+	}
+	return value, err
+}
+
+// span returns the span recorded for a path, creating it
+func (p *lenientJSONParser) span(path string) *jsonSpan {
+	span, ok := p.spans[path]
+	if !ok {
+		span = &jsonSpan{}
+		p.spans[path] = span
+	}
+	return span
+}
+
+// recordMember records the key of an object member or the start of an array item
+func (p *lenientJSONParser) recordMember(parent, path, key string, start, end int) {
+	// Guard clause: spans are not collected
+	if p.spans == nil {
+		return
+	}
+
+	span := p.span(path)
+	span.Key, span.KeyStart, span.KeyEnd = key, start, end
+	p.span(parent).Children = append(p.span(parent).Children, path)
+}
+
+// parseAnyValue parses any value
+func (p *lenientJSONParser) parseAnyValue(path string) (interface{}, error) {
+	// Guard clause: unexpected end of input
+	if p.pos >= len(p.content) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.content[p.pos]; {
+	case c == '{':
+		return p.parseObject(path)
+	case c == '[':
+		return p.parseArray(path)
+	case c == '"' || (p.json5 && c == '\''):
+		return p.parseString()
+	case p.matchWord("true"):
+		return true, nil
+	case p.matchWord("false"):
+		return false, nil
+	case p.matchWord("null"):
+		return nil, nil
+	case c == '-' || (c >= '0' && c <= '9') || (p.json5 && (c == '+' || c == '.' || c == 'I' || c == 'N')):
+		return p.parseNumber()
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+// parseObject parses an object, allowing a trailing comma
+func (p *lenientJSONParser) parseObject(path string) (interface{}, error) {
+	p.pos++
+	result := createEmptyResult()
+
+	for {
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.content) && p.content[p.pos] == '}' {
+			p.pos++
+			return result, nil
+		}
+
+		start := p.pos
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		keyPath := models.JoinPath(path, key)
+		p.record(keyPath, start)
+		p.recordMember(path, keyPath, key, start, p.pos)
+
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.content) || p.content[p.pos] != ':' {
+			return nil, p.errorf("expected ':' after key %q", key)
+		}
+		p.pos++
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+
+		if err := p.endOfMember('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseArray parses an array, allowing a trailing comma
+func (p *lenientJSONParser) parseArray(path string) (interface{}, error) {
+	p.pos++
+	result := make([]interface{}, 0)
+
+	for {
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.content) && p.content[p.pos] == ']' {
+			p.pos++
+			return result, nil
+		}
+
+		itemPath := models.IndexPath(path, len(result))
+		p.record(itemPath, p.pos)
+		p.recordMember(path, itemPath, "", p.pos, p.pos)
+
+		value, err := p.parseValue(itemPath)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+
+		if err := p.endOfMember(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// endOfMember consumes the comma after an object member or array item
+func (p *lenientJSONParser) endOfMember(closing byte) error {
+	if err := p.skip(); err != nil {
+		return err
+	}
+	if p.pos >= len(p.content) {
+		return p.errorf("expected ',' or '%c'", closing)
+	}
+
+	switch p.content[p.pos] {
+	case ',':
+		p.pos++
+		return nil
+	case closing:
+		return nil
+	default:
+		return p.errorf("expected ',' or '%c', found %q", closing, p.content[p.pos])
+	}
+}
+
+// parseKey parses an object key: a string, or an identifier in JSON5
+func (p *lenientJSONParser) parseKey() (string, error) {
+	// Guard clause: unexpected end of input
+	if p.pos >= len(p.content) {
+		return "", p.errorf("unexpected end of input")
+	}
+
+	c := p.content[p.pos]
+	if c == '"' || (p.json5 && c == '\'') {
+		return p.parseString()
+	}
+
+	if p.json5 {
+		start := p.pos
+		for p.pos < len(p.content) {
+			r, size := utf8.DecodeRune(p.content[p.pos:])
+			if r != '_' && r != '$' && !unicode.IsLetter(r) && (p.pos == start || !unicode.IsDigit(r)) {
+				break
+			}
+			p.pos += size
+		}
+		if p.pos > start {
+			return string(p.content[start:p.pos]), nil
+		}
+	}
+
+	return "", p.errorf("expected string key, found %q", c)
+}
+
+// jsonEscapes maps the single character escapes of JSON strings
+var jsonEscapes = map[byte]string{'"': `"`, '\\': `\`, '/': "/", 'b': "\b", 'f': "\f", 'n': "\n", 'r': "\r", 't': "\t"}
+
+// json5Escapes maps the additional single character escapes of JSON5 strings
+var json5Escapes = map[byte]string{'\'': "'", 'v': "\v", '0': "\x00"}
+
+// parseString parses a quoted string
+func (p *lenientJSONParser) parseString() (string, error) {
+	quote := p.content[p.pos]
+	p.pos++
+
+	var result strings.Builder
+	for p.pos < len(p.content) {
+		c := p.content[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return result.String(), nil
+		case c == '\n':
+			return "", p.errorf("unterminated string")
+		case c == '\\':
+			if err := p.parseEscape(&result); err != nil {
+				return "", err
+			}
+		default:
+			r, size := utf8.DecodeRune(p.content[p.pos:])
+			result.WriteRune(r)
+			p.pos += size
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// parseEscape parses an escape sequence starting at a backslash
+func (p *lenientJSONParser) parseEscape(result *strings.Builder) error {
+	p.pos++
+	if p.pos >= len(p.content) {
+		return p.errorf("unterminated string")
+	}
+
+	c := p.content[p.pos]
+	if replacement, ok := jsonEscapes[c]; ok {
+		result.WriteString(replacement)
+		p.pos++
+		return nil
+	}
+
+	switch {
+	case c == 'u':
+		r, err := p.parseHexRune(4)
+		if err != nil {
+			return err
+		}
+		// Combine UTF-16 surrogate pairs
+		if utf16.IsSurrogate(r) && bytes.HasPrefix(p.content[p.pos:], []byte(`\u`)) {
+			p.pos++
+			low, err := p.parseHexRune(4)
+			if err != nil {
+				return err
+			}
+			r = utf16.DecodeRune(r, low)
+		}
+		result.WriteRune(r)
+		return nil
+	case !p.json5:
+		return p.errorf("invalid escape sequence \\%c", c)
+	case c == 'x':
+		r, err := p.parseHexRune(2)
+		if err != nil {
+			return err
+		}
+		result.WriteRune(r)
+		return nil
+	case c == '\n':
+		// Line continuation
+		p.pos++
+		return nil
+	case c == '\r':
+		p.pos++
+		if p.pos < len(p.content) && p.content[p.pos] == '\n' {
+			p.pos++
+		}
+		return nil
+	}
+
+	if replacement, ok := json5Escapes[c]; ok {
+		result.WriteString(replacement)
+		p.pos++
+		return nil
+	}
+
+	// Any other escaped character stands for itself
+	r, size := utf8.DecodeRune(p.content[p.pos:])
+	result.WriteRune(r)
+	p.pos += size
+	return nil
+}
+
+// parseHexRune parses the hex digits of a \u or \x escape; p.pos is on the escape letter
+func (p *lenientJSONParser) parseHexRune(digits int) (rune, error) {
+	start := p.pos + 1
+	if start+digits > len(p.content) {
+		return 0, p.errorf("truncated escape sequence")
+	}
+
+	code, err := strconv.ParseUint(string(p.content[start:start+digits]), 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid escape sequence %q", p.content[p.pos-1:start+digits])
+	}
+
+	p.pos = start + digits
+	return rune(code), nil
+}
+
+// parseNumber parses a number; JSON5 adds hex, leading/trailing dots, '+', Infinity and NaN
+func (p *lenientJSONParser) parseNumber() (interface{}, error) {
+	start := p.pos
+	sign := 1.0
+	if c := p.content[p.pos]; p.json5 && (c == '+' || c == '-') {
+		if c == '-' {
+			sign = -1
+		}
+		p.pos++
+	}
+
+	// Infinity and NaN are kept as their text: they have no JSON encoding and NaN never
+	// equals itself, which would make identical files differ
+	switch {
+	case p.json5 && p.matchWord("Infinity"):
+		if sign < 0 {
+			return "-Infinity", nil
+		}
+		return "Infinity", nil
+	case p.json5 && p.matchWord("NaN"):
+		return "NaN", nil
+	case p.json5 && (bytes.HasPrefix(p.content[p.pos:], []byte("0x")) || bytes.HasPrefix(p.content[p.pos:], []byte("0X"))):
+		p.pos += 2
+		digits := p.pos
+		for p.pos < len(p.content) && isHexDigit(p.content[p.pos]) {
+			p.pos++
+		}
+		value, err := strconv.ParseUint(string(p.content[digits:p.pos]), 16, 64)
+		if err != nil {
+			return nil, p.errorf("invalid hex number %q", p.content[start:p.pos])
+		}
+		return sign * float64(value), nil
+	}
+
+	for p.pos < len(p.content) && strings.IndexByte("0123456789.eE+-", p.content[p.pos]) != -1 {
+		p.pos++
+	}
+
+	text := string(p.content[start:p.pos])
+	if !p.json5 && !jsoncNumberPattern.MatchString(text) {
+		return nil, p.errorf("invalid number %q", text)
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return value, nil
+}
+
+// matchWord consumes a keyword if it is not followed by an identifier character
+func (p *lenientJSONParser) matchWord(word string) bool {
+	if !bytes.HasPrefix(p.content[p.pos:], []byte(word)) {
+		return false
+	}
+
+	end := p.pos + len(word)
+	if end < len(p.content) {
+		r, _ := utf8.DecodeRune(p.content[end:])
+		if r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	p.pos = end
+	return true
+}
+
+// isHexDigit checks if a byte is a hexadecimal digit
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
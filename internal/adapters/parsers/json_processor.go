@@ -1,6 +1,7 @@
 package parsers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,15 +12,46 @@ import (
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
+// JSONDialect selects the JSON syntax accepted by a JSONProcessor
+type JSONDialect string
+
+const (
+	// JSONDialectStrict accepts standard JSON only
+	JSONDialectStrict JSONDialect = "json"
+	// JSONDialectJSONC accepts comments and trailing commas (VS Code, tsconfig, appsettings)
+	JSONDialectJSONC JSONDialect = "jsonc"
+	// JSONDialectJSON5 accepts JSON5: JSONC plus unquoted keys, single quotes, hex numbers,
+	// Infinity/NaN and extra string escapes
+	JSONDialectJSON5 JSONDialect = "json5"
+)
+
+// JSONOptions configures the JSON dialect of a JSONProcessor
+type JSONOptions struct {
+	Dialect JSONDialect
+}
+
 // JSONProcessor implements FileProcessor for JSON files
 type JSONProcessor struct {
 	supportedExtensions []string
+	dialect             JSONDialect
 }
 
 // NewJSONProcessor creates a new JSON processor
 func NewJSONProcessor() *JSONProcessor {
+	return NewJSONProcessorWithOptions(JSONOptions{})
+}
+
+// NewJSONProcessorWithOptions creates a JSON processor for a dialect.
+// The dialect name is also the file extension and the reported format.
+func NewJSONProcessorWithOptions(options JSONOptions) *JSONProcessor {
+	dialect := options.Dialect
+	if dialect == "" {
+		dialect = JSONDialectStrict
+	}
+
 	return &JSONProcessor{
-		supportedExtensions: []string{"json"},
+		supportedExtensions: []string{string(dialect)},
+		dialect:             dialect,
 	}
 }
 
@@ -46,14 +78,27 @@ func (p *JSONProcessor) Process(ctx context.Context, filename string, content []
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
+	// Guard clause: commented JSON dialects use the lenient parser
+	if p.dialect != JSONDialectStrict {
+		return p.processLenient(filename, content)
+	}
+
 	// Parse JSON content
 	data, err := p.parseJSON(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
+	// Record key positions
+	positions, err := collectJSONPositions(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
 	// Create config data
-	return p.createConfigData(filename, data), nil
+	configData := p.createConfigData(filename, data)
+	configData.Positions = positions
+	return configData, nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -64,6 +109,19 @@ func (p *JSONProcessor) GetSupportedExtensions() []string {
 	return extensions
 }
 
+// processLenient processes JSONC or JSON5 content, keeping its comments
+func (p *JSONProcessor) processLenient(filename string, content []byte) (*models.ConfigData, error) {
+	data, positions, comments, err := parseLenientJSON(content, p.dialect == JSONDialectJSON5)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", strings.ToUpper(string(p.dialect)), err)
+	}
+
+	configData := p.createConfigData(filename, data)
+	configData.Positions = positions
+	configData.Comments = comments
+	return configData, nil
+}
+
 // parseJSON parses JSON content into a map
 func (p *JSONProcessor) parseJSON(content []byte) (map[string]interface{}, error) {
 	// Guard clause: empty content
@@ -88,7 +146,7 @@ func (p *JSONProcessor) parseJSON(content []byte) (map[string]interface{}, error
 func (p *JSONProcessor) createConfigData(filename string, data map[string]interface{}) *models.ConfigData {
 	return &models.ConfigData{
 		Filename:  filename,
-		Format:    "json",
+		Format:    string(p.dialect),
 		Data:      data,
 		Metadata:  p.createMetadata(filename, data),
 		Timestamp: time.Now(),
@@ -98,11 +156,11 @@ func (p *JSONProcessor) createConfigData(filename string, data map[string]interf
 // createMetadata creates metadata for the config data
 func (p *JSONProcessor) createMetadata(filename string, data map[string]interface{}) map[string]interface{} {
 	metadata := map[string]interface{}{
-		"processor":    "json",
-		"file_size":    len(data),
-		"key_count":    p.countKeys(data),
-		"has_nested":   p.hasNestedData(data),
-		"is_valid":     p.isValidJSON(data),
+		"processor":  string(p.dialect),
+		"file_size":  len(data),
+		"key_count":  p.countKeys(data),
+		"has_nested": p.hasNestedData(data),
+		"is_valid":   p.isValidJSON(data),
 	}
 
 	// Add file-specific metadata
@@ -175,3 +233,103 @@ func (p *JSONProcessor) isValidJSON(data map[string]interface{}) bool {
 	_, err := json.Marshal(data)
 	return err == nil
 }
+
+// jsonPositionWalker records key positions while streaming JSON tokens
+type jsonPositionWalker struct {
+	decoder   *json.Decoder
+	content   []byte
+	index     *lineIndex
+	positions map[string]models.Position
+}
+
+// collectJSONPositions records the position of every key and array item
+func collectJSONPositions(content []byte) (map[string]models.Position, error) {
+	positions := createEmptyPositions()
+
+	// Guard clause: empty content
+	if len(bytes.TrimSpace(content)) == 0 {
+		return positions, nil
+	}
+
+	walker := &jsonPositionWalker{
+		decoder:   json.NewDecoder(bytes.NewReader(content)),
+		content:   content,
+		index:     newLineIndex(content),
+		positions: positions,
+	}
+
+	if err := walker.walkValue(""); err != nil {
+		return nil, fmt.Errorf("JSON token scan failed: %w", err)
+	}
+
+	return positions, nil
+}
+
+// walkValue consumes one JSON value, descending into objects and arrays
+func (w *jsonPositionWalker) walkValue(path string) error {
+	token, err := w.decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		return w.walkObject(path)
+	case json.Delim('['):
+		return w.walkArray(path)
+	}
+
+	return nil
+}
+
+// walkObject consumes the members of an object up to its closing brace
+func (w *jsonPositionWalker) walkObject(path string) error {
+	for w.decoder.More() {
+		start := w.nextTokenOffset()
+
+		token, err := w.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		key, _ := token.(string)
+		keyPath := models.JoinPath(path, key)
+		recordPosition(w.positions, keyPath, w.index.position(start))
+
+		if err := w.walkValue(keyPath); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.decoder.Token()
+	return err
+}
+
+// walkArray consumes the items of an array up to its closing bracket
+func (w *jsonPositionWalker) walkArray(path string) error {
+	for i := 0; w.decoder.More(); i++ {
+		itemPath := models.IndexPath(path, i)
+		recordPosition(w.positions, itemPath, w.index.position(w.nextTokenOffset()))
+
+		if err := w.walkValue(itemPath); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.decoder.Token()
+	return err
+}
+
+// nextTokenOffset returns the byte offset where the next token starts
+func (w *jsonPositionWalker) nextTokenOffset() int {
+	offset := int(w.decoder.InputOffset())
+	for offset < len(w.content) {
+		switch w.content[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
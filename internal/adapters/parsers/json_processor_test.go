@@ -0,0 +1,126 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestJSONProcessorJSONC tests comments and trailing commas in JSONC files
+func TestJSONProcessorJSONC(t *testing.T) {
+	content := []byte(`{
+  // Logging settings
+  "Logging": {
+    "LogLevel": {
+      "Default": "Information", // praetorian-ignore
+    },
+  },
+  /* Allowed origins */
+  "AllowedHosts": ["*", "example.com",],
+  "Url": "http://example.com/*not-a-comment*/"
+}
+`)
+
+	configData, err := NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSONC}).Process(context.Background(), "appsettings.json", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"Logging":      map[string]interface{}{"LogLevel": map[string]interface{}{"Default": "Information"}},
+		"AllowedHosts": []interface{}{"*", "example.com"},
+		"Url":          "http://example.com/*not-a-comment*/",
+	}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+	if configData.Format != "jsonc" {
+		t.Errorf("Process() format = %s, want jsonc", configData.Format)
+	}
+
+	expectedComments := []models.Comment{
+		{Text: "Logging settings", Path: "Logging", Position: models.Position{Line: 2, Column: 3}},
+		{Text: "praetorian-ignore", Path: "Logging.LogLevel.Default", Position: models.Position{Line: 5, Column: 33}},
+		{Text: "Allowed origins", Path: "AllowedHosts", Position: models.Position{Line: 8, Column: 3}},
+	}
+	if !reflect.DeepEqual(configData.Comments, expectedComments) {
+		t.Errorf("Process() comments = %+v, want %+v", configData.Comments, expectedComments)
+	}
+
+	if position, ok := configData.PositionOf("AllowedHosts[1]"); !ok || position.Line != 9 {
+		t.Errorf("PositionOf(AllowedHosts[1]) = %v, %v, want line 9", position, ok)
+	}
+}
+
+// TestJSONProcessorJSON5 tests JSON5 syntax
+func TestJSONProcessorJSON5(t *testing.T) {
+	content := []byte(`// JSON5 sample
+{
+  unquoted: 'single "quoted"',
+  $special_key1: "line \
+continued",
+  hex: 0xFF,
+  negativeHex: -0x10,
+  leading: .5,
+  trailing: 5.,
+  positive: +1,
+  infinite: -Infinity,
+  nan: NaN,
+  escapes: '\x41B\'',
+}
+`)
+
+	configData, err := NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSON5}).Process(context.Background(), "config.json5", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"unquoted":      `single "quoted"`,
+		"$special_key1": "line continued",
+		"hex":           float64(255),
+		"negativeHex":   float64(-16),
+		"leading":       0.5,
+		"trailing":      float64(5),
+		"positive":      float64(1),
+		"infinite":      "-Infinity",
+		"nan":           "NaN",
+		"escapes":       "AB'",
+	}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+	if len(configData.Comments) != 1 || configData.Comments[0].Path != "unquoted" {
+		t.Errorf("Process() comments = %+v, want one comment documenting unquoted", configData.Comments)
+	}
+}
+
+// TestJSONProcessorDialectErrors tests that each dialect rejects syntax it does not allow
+func TestJSONProcessorDialectErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  JSONDialect
+		content  string
+		expected string
+	}{
+		{name: "strict rejects comments", dialect: JSONDialectStrict, content: "{\n// c\n\"a\": 1}", expected: "failed to parse JSON"},
+		{name: "jsonc rejects unquoted keys", dialect: JSONDialectJSONC, content: "{a: 1}", expected: "line 1, column 2"},
+		{name: "jsonc rejects hex", dialect: JSONDialectJSONC, content: "{\"a\": 0x1}", expected: "expected ','"},
+		{name: "jsonc rejects single quotes", dialect: JSONDialectJSONC, content: "{\"a\": 'b'}", expected: "unexpected"},
+		{name: "json5 rejects missing comma", dialect: JSONDialectJSON5, content: "{a: 1\n b: 2}", expected: "line 2, column 2"},
+		{name: "unterminated comment", dialect: JSONDialectJSON5, content: "{a: 1 /* open", expected: "unterminated block comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewJSONProcessorWithOptions(JSONOptions{Dialect: tt.dialect})
+			_, err := processor.Process(context.Background(), "config.json", []byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Process() error = %v, want it to contain %q", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package parsers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// kubernetesDataSections lists, per kind, the sections holding configuration entries.
+// Later sections win, like stringData over data when Kubernetes builds a Secret.
+var kubernetesDataSections = map[string][]string{
+	"ConfigMap": {"data"},
+	"Secret":    {"data", "stringData"},
+}
+
+// extractKubernetesData replaces the data of a ConfigMap or Secret document with its
+// configuration entries. Secret data values are base64-decoded. Entries named like files
+// (application.properties: |) are parsed with the processor registry selects for them
+// and exposed as embedded documents addressable as
+// file.yaml#ConfigMap/name/application.properties; the entry itself keeps its text.
+// Entries that do not parse keep their text and are listed in the embedded_errors
+// metadata.
+func extractKubernetesData(ctx context.Context, registry *ParserRegistry, filename string, content []byte, document *yamlDocument) error {
+	kind, _ := document.data["kind"].(string)
+	sections, ok := kubernetesDataSections[kind]
+
+	// Guard clause: only Kubernetes ConfigMaps and Secrets
+	if _, hasAPIVersion := document.data["apiVersion"]; !ok || !hasAPIVersion {
+		return nil
+	}
+
+	extractor := &kubernetesExtractor{
+		ctx:       ctx,
+		registry:  registry,
+		filename:  filename,
+		lines:     splitLines(content),
+		document:  document,
+		data:      createEmptyResult(),
+		positions: createEmptyPositions(),
+	}
+
+	for _, section := range sections {
+		encoded := kind == "Secret" && section == "data"
+		if err := extractor.extractSection(section, encoded); err != nil {
+			return err
+		}
+	}
+
+	document.kind = kind
+	document.data = extractor.data
+	document.positions = extractor.positions
+	document.embedded = extractor.embedded
+	document.embeddedErrors = extractor.errors
+	return nil
+}
+
+// kubernetesExtractor collects the configuration entries of one document
+type kubernetesExtractor struct {
+	ctx       context.Context
+	registry  *ParserRegistry
+	filename  string
+	lines     []string
+	document  *yamlDocument
+	data      map[string]interface{}
+	positions map[string]models.Position
+	embedded  []*models.ConfigData
+	errors    []string
+}
+
+// extractSection extracts the entries of a data section
+func (e *kubernetesExtractor) extractSection(section string, encoded bool) error {
+	entries, _ := e.document.data[section].(map[string]interface{})
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, err := kubernetesEntryValue(entries[key], encoded)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", section, key, err)
+		}
+
+		keyPath := models.JoinPath("", key)
+		keyPosition := e.document.positions[models.JoinPath(section, key)]
+		e.data[key] = value
+		e.positions[keyPath] = keyPosition
+
+		embedded, err := e.processEmbeddedFile(key, value)
+		if err != nil {
+			e.errors = append(e.errors, fmt.Sprintf("%s.%s: %v", section, key, err))
+			continue
+		}
+		if embedded == nil {
+			continue
+		}
+
+		// Map embedded positions back to the YAML source
+		embedded.Positions = e.sourcePositions(section, key, embedded.Positions, encoded, keyPosition)
+		e.embedded = append(e.embedded, embedded)
+	}
+
+	return nil
+}
+
+// processEmbeddedFile parses an entry whose key names a supported file, or returns nil
+func (e *kubernetesExtractor) processEmbeddedFile(key, value string) (*models.ConfigData, error) {
+	processor, err := e.registry.GetProcessor(key)
+	if err != nil {
+		return nil, nil
+	}
+
+	ref := key
+	if e.document.name != "" {
+		ref = e.document.name + "/" + key
+	}
+
+	embedded, err := processor.Process(e.ctx, models.DocumentFilename(e.filename, ref), []byte(value))
+	if err != nil {
+		return nil, err
+	}
+
+	embedded.Metadata["document_name"] = ref
+	embedded.Metadata["embedded_file"] = key
+	return embedded, nil
+}
+
+// sourcePositions maps positions inside an embedded file to the YAML file. Literal and folded
+// block scalars map line by line; other values (and base64 content) map to the entry key.
+func (e *kubernetesExtractor) sourcePositions(section, key string, positions map[string]models.Position, encoded bool, keyPosition models.Position) map[string]models.Position {
+	mapped := make(map[string]models.Position, len(positions))
+	node := e.entryNode(section, key)
+	block := node != nil && !encoded && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0
+
+	for path, position := range positions {
+		if !block || node.Line >= len(e.lines) {
+			mapped[path] = keyPosition
+			continue
+		}
+
+		// Block content starts on the line after the indicator, indented uniformly
+		firstLine := e.lines[node.Line]
+		indent := len(firstLine) - len(strings.TrimLeft(firstLine, " "))
+		mapped[path] = models.Position{Line: position.Line + node.Line, Column: position.Column + indent}
+	}
+
+	return mapped
+}
+
+// entryNode finds the value node of section.key in the document tree
+func (e *kubernetesExtractor) entryNode(section, key string) *yaml.Node {
+	node := e.document.root
+	for _, name := range []string{section, key} {
+		if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+			node = node.Content[0]
+		}
+		node = yamlMappingValue(node, name)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// yamlMappingValue returns the value node of a mapping key, or nil
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	// Guard clause: not a mapping
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// kubernetesEntryValue converts a data entry to its string value, decoding base64 when needed
+func kubernetesEntryValue(value interface{}, encoded bool) (string, error) {
+	text, ok := value.(string)
+	if !ok && value != nil {
+		text = fmt.Sprint(value)
+	}
+
+	// Guard clause: plain value
+	if !encoded {
+		return text, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 value: %w", err)
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// kubernetesManifests holds a ConfigMap with an embedded properties file and a Secret
+const kubernetesManifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: orders
+data:
+  LOG_LEVEL: debug
+  application.properties: |
+    server.port=8080
+    spring.datasource.url=jdbc:postgresql://db/orders
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: orders-credentials
+type: Opaque
+data:
+  DB_PASSWORD: czNjcjN0
+  DB_USER: b3JkZXJz
+stringData:
+  DB_USER: admin
+`
+
+// TestYAMLProcessorKubernetesData tests ConfigMap and Secret extraction
+func TestYAMLProcessorKubernetesData(t *testing.T) {
+	configData, err := NewYAMLProcessor().Process(context.Background(), "overlays/prod/orders.yaml", []byte(kubernetesManifests))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	configMap, ok := configData.Document("ConfigMap/orders")
+	if !ok {
+		t.Fatal("Document(ConfigMap/orders) not found")
+	}
+	expectedProperties := map[string]interface{}{
+		"server.port":           "8080",
+		"spring.datasource.url": "jdbc:postgresql://db/orders",
+	}
+	expected := map[string]interface{}{
+		"LOG_LEVEL":              "debug",
+		"application.properties": "server.port=8080\nspring.datasource.url=jdbc:postgresql://db/orders\n",
+	}
+	if !reflect.DeepEqual(configMap.Data, expected) {
+		t.Errorf("ConfigMap data = %#v, want %#v", configMap.Data, expected)
+	}
+	if configMap.Metadata["kubernetes_kind"] != "ConfigMap" {
+		t.Errorf("ConfigMap metadata = %v, want kubernetes_kind", configMap.Metadata)
+	}
+
+	embedded, ok := configData.Document("ConfigMap/orders/application.properties")
+	if !ok {
+		t.Fatal("Document(ConfigMap/orders/application.properties) not found")
+	}
+	if embedded.Format != "properties" || !reflect.DeepEqual(embedded.Data, expectedProperties) {
+		t.Errorf("embedded document = %s %#v, want properties %#v", embedded.Format, embedded.Data, expectedProperties)
+	}
+	if embedded.Filename != "overlays/prod/orders.yaml#ConfigMap/orders/application.properties" {
+		t.Errorf("embedded filename = %s", embedded.Filename)
+	}
+	if position, ok := embedded.PositionOf(`spring\.datasource\.url`); !ok || position.Line != 9 || position.Column != 5 {
+		t.Errorf("embedded PositionOf(spring.datasource.url) = %v, %v, want line 9 column 5", position, ok)
+	}
+	if position, ok := configMap.PositionOf(`application\.properties`); !ok || position.Line != 7 {
+		t.Errorf("ConfigMap PositionOf(application.properties) = %v, %v, want line 7", position, ok)
+	}
+
+	// The embedded file is one document of its own, not also nested in the ConfigMap
+	documents := models.ExpandDocuments([]*models.ConfigData{configData})
+	if len(documents) != 3 {
+		t.Errorf("ExpandDocuments() = %d documents, want ConfigMap, embedded file and Secret", len(documents))
+	}
+
+	secret, ok := configData.Document("Secret/orders-credentials")
+	if !ok {
+		t.Fatal("Document(Secret/orders-credentials) not found")
+	}
+	expectedSecret := map[string]interface{}{"DB_PASSWORD": "s3cr3t", "DB_USER": "admin"}
+	if !reflect.DeepEqual(secret.Data, expectedSecret) {
+		t.Errorf("Secret data = %#v, want %#v", secret.Data, expectedSecret)
+	}
+	if position, ok := secret.PositionOf("DB_USER"); !ok || position.Line != 20 {
+		t.Errorf("Secret PositionOf(DB_USER) = %v, %v, want the stringData line 20", position, ok)
+	}
+}
+
+// TestYAMLProcessorKubernetesErrors tests invalid Secret content
+func TestYAMLProcessorKubernetesErrors(t *testing.T) {
+	content := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: s\ndata:\n  KEY: not base64!\n"
+	_, err := NewYAMLProcessor().Process(context.Background(), "manifest.yaml", []byte(content))
+	if err == nil || !strings.Contains(err.Error(), "data.KEY: invalid base64 value") {
+		t.Errorf("Process() error = %v, want an invalid base64 error", err)
+	}
+}
+
+// TestYAMLProcessorKubernetesEmbeddedErrors tests that embedded files that do not parse
+// keep their text and are reported in the metadata
+func TestYAMLProcessorKubernetesEmbeddedErrors(t *testing.T) {
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: c\ndata:\n  settings.json: '{broken'\n  nginx.conf: |\n    server { listen 80; }\n"
+
+	configData, err := NewYAMLProcessor().Process(context.Background(), "manifest.yaml", []byte(content))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{"settings.json": "{broken", "nginx.conf": "server { listen 80; }\n"}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+	errors, _ := configData.Metadata["embedded_errors"].([]string)
+	if len(errors) != 2 || !strings.HasPrefix(errors[0], "data.nginx.conf: ") || !strings.HasPrefix(errors[1], "data.settings.json: ") {
+		t.Errorf("embedded_errors = %q, want nginx.conf and settings.json", errors)
+	}
+}
+
+// TestYAMLProcessorKubernetesRegistry tests that embedded files are parsed with the format
+// rules and type inference of the registry that selected the YAML processor
+func TestYAMLProcessorKubernetesRegistry(t *testing.T) {
+	registry := NewParserRegistry()
+	if err := registry.ApplyFormatRules([]models.FileFormatRule{{Pattern: "*.conf", Format: "properties"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ApplyTypeInference(models.TypeInferenceConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	processor, err := registry.GetProcessor("manifest.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: c\ndata:\n  app.conf: |\n    port=8080\n"
+	configData, err := processor.Process(context.Background(), "manifest.yaml", []byte(content))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	embedded, ok := configData.Document("ConfigMap/c/app.conf")
+	if !ok {
+		t.Fatal("Document(ConfigMap/c/app.conf) not found")
+	}
+	if embedded.Format != "properties" || !reflect.DeepEqual(embedded.Data, map[string]interface{}{"port": 8080}) {
+		t.Errorf("embedded document = %s %#v, want properties with an inferred port", embedded.Format, embedded.Data)
+	}
+}
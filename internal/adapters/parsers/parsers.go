@@ -17,12 +17,16 @@ func RegisterAllProcessors(registry *ParserRegistry) error {
 	processors := []models.FileProcessor{
 		NewYAMLProcessor(),
 		NewJSONProcessor(),
+		NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSONC}),
+		NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSON5}),
 		NewTOMLProcessor(),
 		NewPropertiesProcessor(),
 		NewINIProcessor(),
 		NewHCLProcessor(),
+		NewTFVarsProcessor(),
 		NewXMLProcessor(),
 		NewENVProcessor(),
+		NewHOCONProcessor(),
 	}
 
 	// Register each processor
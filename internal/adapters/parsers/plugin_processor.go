@@ -0,0 +1,245 @@
+package parsers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// PluginProtocolVersion is the version of the parser plugin protocol.
+//
+// The plugin command is run once per request, with PRAETORIAN_PARSER_PROTOCOL set to the
+// protocol version and PRAETORIAN_PARSER_REQUEST set to the request:
+//
+//   - "handshake" (once, with empty stdin): the plugin prints
+//     {"protocol_version": 1, "name": "myfmt"}
+//   - "parse" (per file, with the file content on stdin and PRAETORIAN_FILENAME set):
+//     the plugin prints {"protocol_version": 1, "data": {...}, "positions": {"a.b": {"line": 1, "column": 3}}}
+//     or {"protocol_version": 1, "error": {"message": "...", "line": 3}}
+//
+// Positions are optional and keyed by key path (servers[0].host).
+const PluginProtocolVersion = 1
+
+// defaultPluginTimeout bounds each plugin invocation
+const defaultPluginTimeout = 10 * time.Second
+
+// maxPluginStderr limits the plugin stderr quoted in errors
+const maxPluginStderr = 512
+
+// PluginOptions configures an external parser plugin
+type PluginOptions struct {
+	Name     string
+	Patterns []string
+	Command  []string
+	Timeout  time.Duration
+}
+
+// PluginProcessor implements FileProcessor by running an external parser command
+type PluginProcessor struct {
+	supportedExtensions []string
+	options             PluginOptions
+
+	mu         sync.Mutex
+	handshaken bool
+}
+
+// pluginResponse is the JSON printed by a plugin
+type pluginResponse struct {
+	ProtocolVersion *int                       `json:"protocol_version"`
+	Name            string                     `json:"name,omitempty"`
+	Data            map[string]interface{}     `json:"data,omitempty"`
+	Positions       map[string]models.Position `json:"positions,omitempty"`
+	Error           *pluginError               `json:"error,omitempty"`
+}
+
+// pluginError is a parse error reported by a plugin
+type pluginError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// NewPluginProcessor creates a processor for an external parser plugin
+func NewPluginProcessor(options PluginOptions) (*PluginProcessor, error) {
+	// Guard clause: validate options
+	if options.Name == "" {
+		return nil, fmt.Errorf("parser plugin name cannot be empty")
+	}
+	if len(options.Command) == 0 || options.Command[0] == "" {
+		return nil, fmt.Errorf("parser plugin %s: command cannot be empty", options.Name)
+	}
+	if len(options.Patterns) == 0 {
+		return nil, fmt.Errorf("parser plugin %s: at least one pattern is required", options.Name)
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = defaultPluginTimeout
+	}
+
+	// Extensions are derived from "*.ext" patterns
+	var extensions []string
+	for _, pattern := range options.Patterns {
+		if ext, ok := strings.CutPrefix(pattern, "*."); ok && !strings.ContainsAny(ext, "*?[/") {
+			extensions = append(extensions, strings.ToLower(ext))
+		}
+	}
+
+	return &PluginProcessor{supportedExtensions: extensions, options: options}, nil
+}
+
+// ResolvePluginCommand returns the program a plugin command runs. Relative paths
+// (./bin/parse) are resolved against baseDir, the directory of the configuration file, so
+// that they do not depend on the working directory; bare names (python3) are looked up in
+// PATH when run.
+func ResolvePluginCommand(program, baseDir string) string {
+	if filepath.IsAbs(program) || !strings.ContainsRune(filepath.ToSlash(program), '/') {
+		return program
+	}
+	return filepath.Join(baseDir, program)
+}
+
+// CanProcess checks if this processor can handle the given filename
+func (p *PluginProcessor) CanProcess(filename string) bool {
+	for _, pattern := range p.options.Patterns {
+		if models.MatchGlob(pattern, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// Process runs the plugin on the file content
+func (p *PluginProcessor) Process(ctx context.Context, filename string, content []byte) (*models.ConfigData, error) {
+	if err := ValidateContextAndInput(ctx, filename, content); err != nil {
+		return nil, err
+	}
+
+	if err := p.ensureHandshake(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := p.run(ctx, "parse", filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		parseErr := error(fmt.Errorf("%s", response.Error.Message))
+		if response.Error.Line > 0 {
+			parseErr = &LineError{Line: response.Error.Line, Message: response.Error.Message}
+		}
+		return nil, fmt.Errorf("failed to parse %s with plugin %s: %w", filename, p.options.Name, parseErr)
+	}
+
+	data := response.Data
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	positions := response.Positions
+	if positions == nil {
+		positions = make(map[string]models.Position)
+	}
+
+	configData := createConfigData(filename, p.options.Name, data, positions)
+	configData.Metadata["processor"] = "plugin"
+	configData.Metadata["plugin"] = p.options.Name
+	return configData, nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (p *PluginProcessor) GetSupportedExtensions() []string {
+	return copyExtensions(p.supportedExtensions)
+}
+
+// ensureHandshake performs the handshake before the first successful parse
+func (p *PluginProcessor) ensureHandshake(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Guard clause: already agreed on the protocol
+	if p.handshaken {
+		return nil
+	}
+
+	if err := p.handshake(ctx); err != nil {
+		return err
+	}
+	p.handshaken = true
+	return nil
+}
+
+// handshake checks that the plugin speaks a supported protocol version
+func (p *PluginProcessor) handshake(ctx context.Context) error {
+	response, err := p.run(ctx, "handshake", "", []byte{})
+	if err != nil {
+		return fmt.Errorf("handshake failed: %w", err)
+	}
+
+	if response.Name != "" && response.Name != p.options.Name {
+		return fmt.Errorf("parser plugin %s: handshake returned name %q", p.options.Name, response.Name)
+	}
+	return nil
+}
+
+// run invokes the plugin for a request and decodes its response
+func (p *PluginProcessor) run(ctx context.Context, request, filename string, content []byte) (*pluginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.options.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.options.Command[0], p.options.Command[1:]...)
+	cmd.Env = append(os.Environ(),
+		"PRAETORIAN_PARSER_PROTOCOL="+strconv.Itoa(PluginProtocolVersion),
+		"PRAETORIAN_PARSER_REQUEST="+request,
+		"PRAETORIAN_FILENAME="+filename,
+	)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, fmt.Errorf("parser plugin %s timed out after %s", p.options.Name, p.options.Timeout)
+	case err != nil:
+		if message := pluginStderr(stderr.Bytes()); message != "" {
+			return nil, fmt.Errorf("parser plugin %s failed: %w: %s", p.options.Name, err, message)
+		}
+		return nil, fmt.Errorf("parser plugin %s failed: %w", p.options.Name, err)
+	}
+
+	var response pluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("parser plugin %s returned an invalid response: %w", p.options.Name, err)
+	}
+
+	// Guard clause: every response carries the protocol version
+	if response.ProtocolVersion == nil {
+		return nil, fmt.Errorf("parser plugin %s returned no protocol_version", p.options.Name)
+	}
+	if *response.ProtocolVersion != PluginProtocolVersion {
+		return nil, fmt.Errorf("parser plugin %s speaks protocol version %d, expected %d", p.options.Name, *response.ProtocolVersion, PluginProtocolVersion)
+	}
+
+	return &response, nil
+}
+
+// pluginStderr returns the trimmed (and truncated) stderr of a plugin
+func pluginStderr(stderr []byte) string {
+	message := strings.TrimSpace(string(stderr))
+	if len(message) > maxPluginStderr {
+		message = message[:maxPluginStderr] + "..."
+	}
+	return message
+}
@@ -0,0 +1,131 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// writePlugin writes an executable shell script plugin and returns its path
+func writePlugin(t *testing.T, body string) string {
+	t.Helper()
+
+	script := filepath.Join(t.TempDir(), "plugin.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"+body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+// TestPluginProcessor tests the handshake, parsing and error mapping of parser plugins
+func TestPluginProcessor(t *testing.T) {
+	parser := writePlugin(t, `if [ "$PRAETORIAN_PARSER_REQUEST" = "handshake" ]; then
+  echo '{"protocol_version": 1, "name": "mycfg"}'
+  exit 0
+fi
+content=$(cat)
+case "$content" in
+  broken*) echo '{"protocol_version": 1, "error": {"message": "unexpected token", "line": 3}}' ;;
+  crash*) echo "segfault in parser" >&2; exit 2 ;;
+  *) echo "{\"protocol_version\": 1, \"data\": {\"file\": \"$PRAETORIAN_FILENAME\", \"content\": \"$content\"}, \"positions\": {\"content\": {\"line\": 1, \"column\": 1}}}" ;;
+esac
+`)
+
+	registry := NewParserRegistry()
+	err := registry.ApplyParserPlugins([]models.ParserPlugin{{Name: "mycfg", Patterns: []string{"*.mycfg"}, Command: []string{parser}}}, ".")
+	if err != nil {
+		t.Fatalf("ApplyParserPlugins() error = %v", err)
+	}
+
+	processor, err := registry.GetProcessor("app.mycfg")
+	if err != nil {
+		t.Fatalf("GetProcessor() error = %v", err)
+	}
+
+	configData, err := processor.Process(context.Background(), "app.mycfg", []byte("hello"))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if configData.Format != "mycfg" || configData.Data["content"] != "hello" || configData.Data["file"] != "app.mycfg" {
+		t.Errorf("Process() = %s %v, want mycfg data", configData.Format, configData.Data)
+	}
+	if configData.Positions["content"].Line != 1 {
+		t.Errorf("Process() positions = %v, want content at line 1", configData.Positions)
+	}
+
+	_, err = processor.Process(context.Background(), "app.mycfg", []byte("broken"))
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 {
+		t.Errorf("Process() error = %v, want a line 3 error", err)
+	}
+
+	_, err = processor.Process(context.Background(), "app.mycfg", []byte("crash"))
+	if err == nil || !strings.Contains(err.Error(), "exit status 2: segfault in parser") {
+		t.Errorf("Process() error = %v, want exit status and stderr", err)
+	}
+}
+
+// TestResolvePluginCommand tests that relative command paths are resolved against the
+// configuration directory
+func TestResolvePluginCommand(t *testing.T) {
+	tests := []struct {
+		program  string
+		expected string
+	}{
+		{program: "./bin/parse", expected: filepath.Join("config", "bin", "parse")},
+		{program: "bin/parse", expected: filepath.Join("config", "bin", "parse")},
+		{program: "python3", expected: "python3"},
+		{program: "/usr/bin/parse", expected: "/usr/bin/parse"},
+	}
+
+	for _, tt := range tests {
+		if resolved := ResolvePluginCommand(tt.program, "config"); resolved != tt.expected {
+			t.Errorf("ResolvePluginCommand(%q) = %q, want %q", tt.program, resolved, tt.expected)
+		}
+	}
+}
+
+// TestPluginProcessorFailures tests protocol mismatches, invalid responses and timeouts
+func TestPluginProcessorFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		timeout  time.Duration
+		expected string
+	}{
+		{name: "protocol mismatch", body: `echo '{"protocol_version": 2}'`, expected: "speaks protocol version 2, expected 1"},
+		{name: "missing version", body: `echo '{"data": {}}'`, expected: "returned no protocol_version"},
+		{name: "invalid json", body: `echo 'not json'`, expected: "invalid response"},
+		{name: "wrong name", body: `echo '{"protocol_version": 1, "name": "other"}'`, expected: `handshake returned name "other"`},
+		{name: "timeout", body: "sleep 5", timeout: 100 * time.Millisecond, expected: "timed out after 100ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewPluginProcessor(PluginOptions{
+				Name:     "mycfg",
+				Patterns: []string{"*.mycfg"},
+				Command:  []string{writePlugin(t, tt.body+"\n")},
+				Timeout:  tt.timeout,
+			})
+			if err != nil {
+				t.Fatalf("NewPluginProcessor() error = %v", err)
+			}
+
+			_, err = processor.Process(context.Background(), "app.mycfg", []byte("a"))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Process() error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := NewPluginProcessor(PluginOptions{Name: "mycfg", Patterns: []string{"*.mycfg"}}); err == nil {
+		t.Error("NewPluginProcessor() without command should fail")
+	}
+}
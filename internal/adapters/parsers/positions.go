@@ -0,0 +1,52 @@
+package parsers
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// lineIndex converts byte offsets into line/column positions
+type lineIndex struct {
+	content    []byte
+	lineStarts []int
+}
+
+// newLineIndex creates a line index for content
+func newLineIndex(content []byte) *lineIndex {
+	lineStarts := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	return &lineIndex{content: content, lineStarts: lineStarts}
+}
+
+// position returns the 1-based position of a byte offset (columns count characters)
+func (l *lineIndex) position(offset int) models.Position {
+	// Guard clause: clamp offset to content
+	if offset > len(l.content) {
+		offset = len(l.content)
+	}
+
+	line := sort.Search(len(l.lineStarts), func(i int) bool {
+		return l.lineStarts[i] > offset
+	}) - 1
+
+	column := utf8.RuneCount(l.content[l.lineStarts[line]:offset]) + 1
+	return models.Position{Line: line + 1, Column: column}
+}
+
+// linePosition returns the position of the first non-blank character of a line
+func linePosition(lineNumber int, line string) models.Position {
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	return models.Position{Line: lineNumber, Column: utf8.RuneCountInString(line[:indent]) + 1}
+}
+
+// createEmptyPositions creates an empty position map
+func createEmptyPositions() map[string]models.Position {
+	return make(map[string]models.Position)
+}
@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestProcessorPositions tests that every processor records key positions
+func TestProcessorPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		path     string
+		expected models.Position
+	}{
+		{"yaml nested key", "config.yaml", "app:\n  name: test\n  ports:\n    - 80\n", "app.name", models.Position{Line: 2, Column: 3}},
+		{"yaml sequence item", "config.yaml", "app:\n  name: test\n  ports:\n    - 80\n", "app.ports[0]", models.Position{Line: 4, Column: 7}},
+		{"json nested key", "config.json", "{\n  \"app\": {\n    \"name\": \"test\"\n  }\n}", "app.name", models.Position{Line: 3, Column: 5}},
+		{"json array item", "config.json", "{\"hosts\": [\"a\", \"b\"]}", "hosts[1]", models.Position{Line: 1, Column: 17}},
+		{"json dotted key", "config.json", "{\"logging.level\": \"info\"}", `logging\.level`, models.Position{Line: 1, Column: 2}},
+		{"toml table key", "config.toml", "title = \"x\"\n\n[database]\n  port = 5432\n", "database.port", models.Position{Line: 4, Column: 3}},
+		{"toml array table", "config.toml", "[[servers]]\nhost = \"a\"\n[[servers]]\nhost = \"b\"\n", "servers[1].host", models.Position{Line: 4, Column: 1}},
+		{"toml dotted key", "config.toml", "[app]\nlog.level = \"info\"\n", "app.log.level", models.Position{Line: 2, Column: 1}},
+		{"hcl object key", "config.hcl", "app = {\n  name = \"x\"\n}\n", "app.name", models.Position{Line: 2, Column: 3}},
+		{"hcl block", "config.hcl", "service \"api\" {\n  port = 80\n}\n", "service.api.port", models.Position{Line: 2, Column: 3}},
+		{"env key", "config.env", "# comment\nAPP_NAME=test\n", "APP_NAME", models.Position{Line: 2, Column: 1}},
+		{"properties key", "config.properties", "app.name=test\n  app.port=80\n", `app\.port`, models.Position{Line: 2, Column: 3}},
+		{"ini section key", "config.ini", "[database]\nhost=localhost\n", "database.host", models.Position{Line: 2, Column: 1}},
+	}
+
+	registry := NewParserRegistry()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := registry.GetProcessor(tt.filename)
+			if err != nil {
+				t.Fatalf("GetProcessor() error = %v", err)
+			}
+
+			configData, err := processor.Process(context.Background(), tt.filename, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			position, ok := configData.PositionOf(tt.path)
+			if !ok {
+				t.Fatalf("PositionOf(%q) not found in %v", tt.path, configData.Positions)
+			}
+			if position != tt.expected {
+				t.Errorf("PositionOf(%q) = %+v, want %+v", tt.path, position, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// propertiesEditor edits Java properties files line by line, keeping comments and the
+// separator style of every entry
+type propertiesEditor struct {
+	text *textLines
+}
+
+// propertiesEditEntry locates a logical line: lines [start, end), the raw key and the
+// text before the value on the first line
+type propertiesEditEntry struct {
+	key    string
+	rawKey string
+	start  int
+	end    int
+	prefix string
+}
+
+// newPropertiesEditor creates an editor for properties content
+func newPropertiesEditor(content []byte) (models.ConfigEditor, error) {
+	if _, err := readPropertiesEntries(splitLines(content)); err != nil {
+		return nil, err
+	}
+	return &propertiesEditor{text: newTextLines(content)}, nil
+}
+
+// Set replaces the value of a property (every occurrence of it), or appends it
+func (e *propertiesEditor) Set(path string, value interface{}) error {
+	key, err := flatEditKey(path)
+	if err != nil {
+		return err
+	}
+
+	text, err := scalarText(value)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	escaped := escapePropertiesValue(text)
+
+	entries := e.entries()
+	found := false
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].key != key {
+			continue
+		}
+		found = true
+		e.text.replace(entries[i].start, entries[i].end, entries[i].prefix+escaped)
+	}
+	if found {
+		return nil
+	}
+
+	// New properties use the separator of the last entry
+	separator := "="
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		separator = strings.TrimLeft(last.prefix, propertiesWhitespace)[len(last.rawKey):]
+	}
+	e.text.insert(e.text.lastContentLine(0, len(e.text.lines)), escapePropertiesKey(key)+separator+escaped)
+	return nil
+}
+
+// Delete removes every occurrence of a property
+func (e *propertiesEditor) Delete(path string) error {
+	key, err := flatEditKey(path)
+	if err != nil {
+		return err
+	}
+
+	entries := e.entries()
+	found := false
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].key == key {
+			found = true
+			e.text.replace(entries[i].start, entries[i].end)
+		}
+	}
+	if !found {
+		return pathNotFound(path)
+	}
+	return nil
+}
+
+// Rename renames every occurrence of a property
+func (e *propertiesEditor) Rename(path, newKey string) error {
+	key, err := flatEditKey(path)
+	if err != nil {
+		return err
+	}
+	newKey, err = renamedFlatKey(path, newKey)
+	if err != nil {
+		return err
+	}
+
+	entries := e.entries()
+	var matches []propertiesEditEntry
+	for _, entry := range entries {
+		if entry.key == newKey {
+			return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+		}
+		if entry.key == key {
+			matches = append(matches, entry)
+		}
+	}
+	if len(matches) == 0 {
+		return pathNotFound(path)
+	}
+
+	for _, entry := range matches {
+		line := e.text.lines[entry.start]
+		offset := len(leadingWhitespace(line))
+		e.text.lines[entry.start] = line[:offset] + escapePropertiesKey(newKey) + line[offset+len(entry.rawKey):]
+	}
+	return nil
+}
+
+// Bytes returns the edited content
+func (e *propertiesEditor) Bytes() ([]byte, error) {
+	return e.text.bytes(), nil
+}
+
+// entries returns the logical lines of the content in source order
+func (e *propertiesEditor) entries() []propertiesEditEntry {
+	var entries []propertiesEditEntry
+	lines := e.text.lines
+
+	for i := 0; i < len(lines); i++ {
+		first := lines[i]
+		logical := strings.TrimLeft(first, propertiesWhitespace)
+		if logical == "" || isPropertiesComment(logical) {
+			continue
+		}
+
+		start := i
+		for endsWithContinuation(logical) && i+1 < len(lines) {
+			i++
+			logical = logical[:len(logical)-1] + strings.TrimLeft(lines[i], propertiesWhitespace)
+		}
+
+		rawKey, firstValue := splitPropertiesLine(strings.TrimLeft(first, propertiesWhitespace))
+		key, err := unescapeProperties(rawKey)
+		if err != nil {
+			continue
+		}
+
+		// The prefix keeps the indentation, key and separator of the first line; keys without
+		// a value get an "=" separator
+		indent := leadingWhitespace(first)
+		prefix := first[:len(first)-len(firstValue)]
+		if firstValue == "" && !strings.ContainsAny(prefix[len(indent)+len(rawKey):], "=:") {
+			prefix = indent + rawKey + "="
+		}
+
+		entries = append(entries, propertiesEditEntry{key: key, rawKey: rawKey, start: start, end: i + 1, prefix: prefix})
+	}
+
+	return entries
+}
+
+// escapePropertiesKey escapes the characters that end or start a key
+func escapePropertiesKey(key string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, " ", `\ `, ":", `\:`, "=", `\=`, "#", `\#`, "!", `\!`, "\t", `\t`, "\n", `\n`)
+	return replacer.Replace(key)
+}
+
+// escapePropertiesValue escapes backslashes, line breaks and leading whitespace of a value
+func escapePropertiesValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	escaped := replacer.Replace(value)
+	if strings.HasPrefix(escaped, " ") {
+		escaped = `\` + escaped
+	}
+	return escaped
+}
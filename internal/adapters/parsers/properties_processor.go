@@ -2,21 +2,37 @@ package parsers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
+// PropertiesOptions configures how Properties files are mapped
+type PropertiesOptions struct {
+	// ExpandKeys expands dotted keys (server.port) into nested maps so they
+	// compare cleanly against YAML equivalents
+	ExpandKeys bool
+}
+
 // PropertiesProcessor implements FileProcessor for Properties files
 type PropertiesProcessor struct {
 	supportedExtensions []string
+	options             PropertiesOptions
 }
 
 // NewPropertiesProcessor creates a new Properties processor
 func NewPropertiesProcessor() *PropertiesProcessor {
+	return NewPropertiesProcessorWithOptions(PropertiesOptions{})
+}
+
+// NewPropertiesProcessorWithOptions creates a new Properties processor with the given options
+func NewPropertiesProcessorWithOptions(options PropertiesOptions) *PropertiesProcessor {
 	return &PropertiesProcessor{
 		supportedExtensions: []string{"properties"},
+		options:             options,
 	}
 }
 
@@ -31,12 +47,12 @@ func (p *PropertiesProcessor) Process(ctx context.Context, filename string, cont
 		return nil, err
 	}
 
-	data, err := parsePropertiesContent(content)
+	data, positions, err := parsePropertiesContent(content, p.options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse Properties: %w", err)
 	}
 
-	return createConfigData(filename, "properties", data), nil
+	return createConfigData(filename, "properties", data, positions), nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -44,14 +60,205 @@ func (p *PropertiesProcessor) GetSupportedExtensions() []string {
 	return copyExtensions(p.supportedExtensions)
 }
 
-// parsePropertiesContent parses Properties content
-func parsePropertiesContent(content []byte) (map[string]interface{}, error) {
+// propertiesEntry is a key/value pair read from a logical line
+type propertiesEntry struct {
+	key      string
+	value    string
+	line     int
+	position models.Position
+}
+
+// parsePropertiesContent parses Properties content following the java.util.Properties
+// format: "=", ":" or whitespace separators, backslash line continuations, escape
+// sequences (including \uXXXX) and "#" / "!" comments. Later keys override earlier ones.
+func parsePropertiesContent(content []byte, options PropertiesOptions) (map[string]interface{}, map[string]models.Position, error) {
 	// Guard clause: empty content
 	if isEmptyContent(content) {
-		return createEmptyResult(), nil
+		return createEmptyResult(), createEmptyPositions(), nil
+	}
+
+	entries, err := readPropertiesEntries(splitLines(content))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := createEmptyResult()
+	positions := createEmptyPositions()
+	var errs []error
+	for _, entry := range entries {
+		if !options.ExpandKeys {
+			result[entry.key] = entry.value
+			positions[models.JoinPath("", entry.key)] = entry.position
+			continue
+		}
+
+		if err := setExpandedProperty(result, positions, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return result, positions, errors.Join(errs...)
+}
+
+// readPropertiesEntries joins continuation lines and splits every logical line into a key and value
+func readPropertiesEntries(lines []string) ([]propertiesEntry, error) {
+	var entries []propertiesEntry
+	var errs []error
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		logical := strings.TrimLeft(line, propertiesWhitespace)
+
+		// Skip blank lines and comments (comment lines are never continued)
+		if logical == "" || isPropertiesComment(logical) {
+			continue
+		}
+
+		start := i
+		for endsWithContinuation(logical) && i+1 < len(lines) {
+			i++
+			logical = logical[:len(logical)-1] + strings.TrimLeft(strings.TrimRight(lines[i], "\r"), propertiesWhitespace)
+		}
+		if endsWithContinuation(logical) {
+			logical = logical[:len(logical)-1]
+		}
+
+		rawKey, rawValue := splitPropertiesLine(logical)
+		key, keyErr := unescapeProperties(rawKey)
+		value, valueErr := unescapeProperties(rawValue)
+		if err := errors.Join(keyErr, valueErr); err != nil {
+			errs = append(errs, &LineError{Line: start + 1, Message: err.Error()})
+			continue
+		}
+
+		entries = append(entries, propertiesEntry{
+			key:      key,
+			value:    value,
+			line:     start + 1,
+			position: linePosition(start+1, line),
+		})
+	}
+
+	return entries, errors.Join(errs...)
+}
+
+// propertiesWhitespace lists the characters the format treats as whitespace
+const propertiesWhitespace = " \t\f"
+
+// endsWithContinuation checks if a line ends with an odd number of backslashes
+func endsWithContinuation(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// splitPropertiesLine splits a logical line at the first unescaped separator.
+// The key ends at "=", ":" or whitespace; whitespace around a single "=" or ":" is skipped.
+func splitPropertiesLine(line string) (string, string) {
+	end := 0
+	for end < len(line) {
+		c := line[end]
+		if c == '\\' {
+			end += 2
+			continue
+		}
+		if c == '=' || c == ':' || strings.IndexByte(propertiesWhitespace, c) != -1 {
+			break
+		}
+		end++
+	}
+	if end > len(line) {
+		end = len(line)
+	}
+
+	rest := strings.TrimLeft(line[end:], propertiesWhitespace)
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], propertiesWhitespace)
+	}
+
+	return line[:end], rest
+}
+
+// propertiesEscapes maps the single character escape sequences of the format
+var propertiesEscapes = map[byte]byte{'t': '\t', 'n': '\n', 'r': '\r', 'f': '\f'}
+
+// unescapeProperties interprets escape sequences; unknown escapes yield the escaped character
+func unescapeProperties(value string) (string, error) {
+	// Guard clause: nothing to unescape
+	if strings.IndexByte(value, '\\') == -1 {
+		return value, nil
+	}
+
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 >= len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		if value[i] == 'u' {
+			if i+5 > len(value) {
+				return "", fmt.Errorf("malformed \\uXXXX escape %q", value[i-1:])
+			}
+			code, err := strconv.ParseUint(value[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uXXXX escape %q", value[i-1:i+5])
+			}
+			result.WriteRune(rune(code))
+			i += 4
+			continue
+		}
+
+		if replacement, ok := propertiesEscapes[value[i]]; ok {
+			result.WriteByte(replacement)
+			continue
+		}
+		result.WriteByte(value[i])
+	}
+
+	return result.String(), nil
+}
+
+// setExpandedProperty stores a property below nested maps built from its dotted key
+func setExpandedProperty(result map[string]interface{}, positions map[string]models.Position, entry propertiesEntry) error {
+	segments := strings.Split(entry.key, ".")
+
+	// Keys with empty segments (".a", "a..b") cannot be expanded and stay flat
+	for _, segment := range segments {
+		if segment == "" {
+			segments = []string{entry.key}
+			break
+		}
+	}
+
+	current := result
+	path := ""
+	for _, segment := range segments[:len(segments)-1] {
+		path = models.JoinPath(path, segment)
+		switch existing := current[segment].(type) {
+		case nil:
+			child := createEmptyResult()
+			current[segment] = child
+			recordPosition(positions, path, entry.position)
+			current = child
+		case map[string]interface{}:
+			current = existing
+		default:
+			return &LineError{Line: entry.line, Message: fmt.Sprintf("key %q conflicts with the value of %q", entry.key, path)}
+		}
+	}
+
+	last := segments[len(segments)-1]
+	if _, isMap := current[last].(map[string]interface{}); isMap {
+		return &LineError{Line: entry.line, Message: fmt.Sprintf("key %q conflicts with nested keys below it", entry.key)}
 	}
 
-	return parseKeyValueContent(content, isPropertiesComment)
+	current[last] = entry.value
+	positions[models.JoinPath(path, last)] = entry.position
+	return nil
 }
 
 // isPropertiesComment checks if a line is a comment in Properties format
@@ -0,0 +1,80 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// propertiesSample exercises separators, continuations, escapes and comments
+const propertiesSample = `# Spring settings
+! Kafka settings
+server.port=8080
+server.host : localhost
+spring.application.name   orders-service
+bootstrap.servers = broker-1:9092,\
+                    broker-2:9092
+greeting=Hello\u0020World\t!
+key\=with\:separators = value
+key\ with\ spaces=spaced
+path=C:\\temp
+empty
+`
+
+// TestPropertiesProcessor tests the java.util.Properties format
+func TestPropertiesProcessor(t *testing.T) {
+	configData, err := NewPropertiesProcessor().Process(context.Background(), "application.properties", []byte(propertiesSample))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"server.port":             "8080",
+		"server.host":             "localhost",
+		"spring.application.name": "orders-service",
+		"bootstrap.servers":       "broker-1:9092,broker-2:9092",
+		"greeting":                "Hello World\t!",
+		"key=with:separators":     "value",
+		"key with spaces":         "spaced",
+		"path":                    `C:\temp`,
+		"empty":                   "",
+	}
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Process() data = %#v, want %#v", configData.Data, expected)
+	}
+
+	if position, ok := configData.PositionOf(`bootstrap\.servers`); !ok || position.Line != 6 {
+		t.Errorf("PositionOf(bootstrap.servers) = %v, %v, want line 6", position, ok)
+	}
+}
+
+// TestPropertiesProcessorExpandKeys tests expanding dotted keys into nested maps
+func TestPropertiesProcessorExpandKeys(t *testing.T) {
+	processor := NewPropertiesProcessorWithOptions(PropertiesOptions{ExpandKeys: true})
+	configData, err := processor.Process(context.Background(), "application.properties", []byte(propertiesSample))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	server, ok := configData.Data["server"].(map[string]interface{})
+	if !ok || server["port"] != "8080" || server["host"] != "localhost" {
+		t.Errorf("Process() server = %#v, want nested port and host", configData.Data["server"])
+	}
+	if position, ok := configData.PositionOf("spring.application.name"); !ok || position.Line != 5 {
+		t.Errorf("PositionOf(spring.application.name) = %v, %v, want line 5", position, ok)
+	}
+
+	_, err = processor.Process(context.Background(), "conflict.properties", []byte("a.b=1\na=2\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Process() error = %v, want conflict on line 2", err)
+	}
+}
+
+// TestPropertiesProcessorMalformedEscape tests that malformed unicode escapes are reported
+func TestPropertiesProcessorMalformedEscape(t *testing.T) {
+	_, err := NewPropertiesProcessor().Process(context.Background(), "bad.properties", []byte("ok=1\nbad=\\u12G4\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Process() error = %v, want malformed escape on line 2", err)
+	}
+}
@@ -2,16 +2,43 @@ package parsers
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
-// ParserRegistry manages file processors
+// ParserRegistry manages file processors.
+// Processors are selected by user filename rules first, then by extension (ignoring template
+// suffixes such as .j2), then by built-in filename rules and finally by content sniffing.
 type ParserRegistry struct {
-	processors []models.FileProcessor
-	byExtension map[string]models.FileProcessor
+	processors   []models.FileProcessor
+	byExtension  map[string]models.FileProcessor
+	rules        []filenameRule
+	builtinRules []filenameRule
+	inference    *TypeInference
+}
+
+// filenameRule maps a filename glob to a processor
+type filenameRule struct {
+	pattern   string
+	processor models.FileProcessor
+}
+
+// defaultRegistry is shared by processors that delegate embedded content (see DefaultParserRegistry)
+var (
+	defaultRegistry     *ParserRegistry
+	defaultRegistryOnce sync.Once
+)
+
+// DefaultParserRegistry returns a shared registry with the built-in processors and rules
+func DefaultParserRegistry() *ParserRegistry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistry = NewParserRegistry()
+	})
+	return defaultRegistry
 }
 
 // NewParserRegistry creates a new parser registry with all processors registered
@@ -26,6 +53,15 @@ func NewParserRegistry() *ParserRegistry {
 		panic(fmt.Sprintf("Failed to register processors: %v", err))
 	}
 
+	// Register built-in filename rules (env.dev, .env.production, ...)
+	for _, rule := range builtinFilenamePatterns {
+		processor, err := NewProcessorForFormat(rule.format, nil)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to register filename rules: %v", err))
+		}
+		registry.builtinRules = append(registry.builtinRules, filenameRule{pattern: rule.pattern, processor: processor})
+	}
+
 	return registry
 }
 
@@ -38,7 +74,7 @@ func (r *ParserRegistry) RegisterProcessor(processor models.FileProcessor) error
 
 	// Guard clause: check for conflicts
 	if err := r.validateNoConflicts(processor); err != nil {
-		return fmt.Errorf("processor conflicts with existing: %w", err)
+		return fmt.Errorf("processor conflicts with existing (use OverrideProcessor to replace it): %w", err)
 	}
 
 	// Register processor
@@ -52,26 +88,196 @@ func (r *ParserRegistry) RegisterProcessor(processor models.FileProcessor) error
 	return nil
 }
 
+// OverrideProcessor registers a processor that takes over the extensions of existing ones
+func (r *ParserRegistry) OverrideProcessor(processor models.FileProcessor) error {
+	// Guard clause: validate processor
+	if processor == nil {
+		return fmt.Errorf("processor cannot be nil")
+	}
+
+	r.processors = append(r.processors, processor)
+	for _, ext := range processor.GetSupportedExtensions() {
+		r.byExtension[r.normalizeExtension(ext)] = processor
+	}
+
+	return nil
+}
+
+// RegisterPattern maps a filename glob (e.g. "env.*" or "deploy/**/*.yml.j2") to a processor.
+// Patterns take precedence over extensions; later patterns take precedence over earlier ones.
+func (r *ParserRegistry) RegisterPattern(pattern string, processor models.FileProcessor) error {
+	// Guard clause: validate input
+	if processor == nil {
+		return fmt.Errorf("processor cannot be nil")
+	}
+	if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+		return fmt.Errorf("invalid filename pattern %q", pattern)
+	}
+
+	r.rules = append(r.rules, filenameRule{pattern: pattern, processor: processor})
+	return nil
+}
+
+// ApplyFormatRules registers the format rules of a praetorian.yaml configuration
+func (r *ParserRegistry) ApplyFormatRules(rules []models.FileFormatRule) error {
+	for _, rule := range rules {
+		processor, err := NewProcessorForFormat(rule.Format, rule.Options)
+		if err != nil {
+			return fmt.Errorf("invalid format rule for %q: %w", rule.Pattern, err)
+		}
+		if err := r.RegisterPattern(rule.Pattern, processor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyParserPlugins registers the external parser plugins of a praetorian.yaml configuration.
+// Plugin patterns take precedence over built-in formats like any other filename rule.
+// Relative command paths are resolved against baseDir (see ResolvePluginCommand).
+func (r *ParserRegistry) ApplyParserPlugins(plugins []models.ParserPlugin, baseDir string) error {
+	for _, plugin := range plugins {
+		command := plugin.Command
+		if len(command) > 0 {
+			command = append([]string{ResolvePluginCommand(command[0], baseDir)}, command[1:]...)
+		}
+
+		processor, err := NewPluginProcessor(PluginOptions{
+			Name:     plugin.Name,
+			Patterns: plugin.Patterns,
+			Command:  command,
+			Timeout:  plugin.Timeout,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, pattern := range plugin.Patterns {
+			if err := r.RegisterPattern(pattern, processor); err != nil {
+				return fmt.Errorf("parser plugin %s: %w", plugin.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyTypeInference enables type inference for the values of string-only formats
+// (see TypeInference); processors returned by the registry apply it after parsing
+func (r *ParserRegistry) ApplyTypeInference(config models.TypeInferenceConfig) error {
+	inference, err := NewTypeInference(config)
+	if err != nil {
+		return err
+	}
+
+	r.inference = inference
+	return nil
+}
+
 // GetProcessor returns the appropriate processor for a filename
 func (r *ParserRegistry) GetProcessor(filename string) (models.FileProcessor, error) {
+	processor, err := r.lookupProcessor(filename)
+	if err != nil {
+		return nil, err
+	}
+	return r.prepare(processor), nil
+}
+
+// registryProcessor is implemented by processors that parse embedded files (such as the
+// files of a Kubernetes ConfigMap) with the registry that selected them
+type registryProcessor interface {
+	withRegistry(registry *ParserRegistry) models.FileProcessor
+}
+
+// prepare binds a selected processor to the registry and applies its type inference
+func (r *ParserRegistry) prepare(processor models.FileProcessor) models.FileProcessor {
+	if bound, ok := processor.(registryProcessor); ok {
+		processor = bound.withRegistry(r)
+	}
+	return r.withInference(processor)
+}
+
+// withInference wraps a processor so that it applies the registry's type inference
+func (r *ParserRegistry) withInference(processor models.FileProcessor) models.FileProcessor {
+	// Guard clause: inference is disabled
+	if r.inference == nil {
+		return processor
+	}
+	return &inferringProcessor{FileProcessor: processor, inference: r.inference}
+}
+
+// lookupProcessor selects the processor for a filename
+func (r *ParserRegistry) lookupProcessor(filename string) (models.FileProcessor, error) {
 	// Guard clause: validate filename
 	if filename == "" {
 		return nil, fmt.Errorf("filename cannot be empty")
 	}
 
+	// User rules override everything else
+	if processor := r.matchRules(r.rules, filename); processor != nil {
+		return processor, nil
+	}
+
+	// Look up processor by extension, ignoring template suffixes.
+	// Compound extensions (tfvars.json) take precedence over the last extension.
+	name := filename
+	for {
+		if processor, exists := r.byExtension[r.getCompoundExtension(name)]; exists {
+			return processor, nil
+		}
+		if processor, exists := r.byExtension[r.getFileExtension(name)]; exists {
+			return processor, nil
+		}
+
+		stripped, ok := stripTemplateSuffix(name)
+		if !ok {
+			break
+		}
+		name = stripped
+	}
+
+	if processor := r.matchRules(r.builtinRules, name); processor != nil {
+		return processor, nil
+	}
+
 	// Get file extension
-	ext := r.getFileExtension(filename)
+	ext := r.getFileExtension(name)
 	if ext == "" {
 		return nil, fmt.Errorf("no extension found in filename: %s", filename)
 	}
 
-	// Look up processor by extension
-	processor, exists := r.byExtension[ext]
-	if !exists {
-		return nil, fmt.Errorf("no processor found for extension: %s", ext)
+	return nil, fmt.Errorf("no processor found for extension: %s", ext)
+}
+
+// DetectProcessor returns the processor for a file, falling back to content sniffing
+// when the filename is not recognised
+func (r *ParserRegistry) DetectProcessor(filename string, content []byte) (models.FileProcessor, error) {
+	processor, err := r.GetProcessor(filename)
+	if err == nil {
+		return processor, nil
 	}
 
-	return processor, nil
+	format := SniffFormat(content)
+	if format == "" {
+		return nil, fmt.Errorf("%w (content format could not be detected)", err)
+	}
+
+	processor, err = NewProcessorForFormat(format, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.prepare(processor), nil
+}
+
+// matchRules returns the processor of the last rule matching filename
+func (r *ParserRegistry) matchRules(rules []filenameRule, filename string) models.FileProcessor {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if models.MatchGlob(rules[i].pattern, filename) {
+			return rules[i].processor
+		}
+	}
+	return nil
 }
 
 // GetProcessorByExtension returns processor by extension
@@ -124,14 +330,14 @@ func (r *ParserRegistry) GetProcessorCount() int {
 // validateNoConflicts checks if a processor conflicts with existing ones
 func (r *ParserRegistry) validateNoConflicts(newProcessor models.FileProcessor) error {
 	newExtensions := newProcessor.GetSupportedExtensions()
-	
+
 	for _, existing := range r.processors {
 		existingExtensions := existing.GetSupportedExtensions()
 		if r.hasExtensionOverlap(newExtensions, existingExtensions) {
 			return fmt.Errorf("extension conflict between processors")
 		}
 	}
-	
+
 	return nil
 }
 
@@ -153,6 +359,16 @@ func (r *ParserRegistry) getFileExtension(filename string) string {
 	return r.normalizeExtension(ext)
 }
 
+// getCompoundExtension extracts the last two extensions from filename (e.g. tfvars.json)
+func (r *ParserRegistry) getCompoundExtension(filename string) string {
+	ext := filepath.Ext(filename)
+	inner := filepath.Ext(strings.TrimSuffix(filename, ext))
+	if inner == "" || ext == "" {
+		return ""
+	}
+	return r.normalizeExtension(inner + ext)
+}
+
 // normalizeExtension normalizes an extension (removes leading dot, converts to lowercase)
 func (r *ParserRegistry) normalizeExtension(extension string) string {
 	ext := strings.TrimPrefix(extension, ".")
@@ -167,12 +383,16 @@ func CreateDefaultRegistry() (*ParserRegistry, error) {
 	processors := []models.FileProcessor{
 		NewYAMLProcessor(),
 		NewJSONProcessor(),
+		NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSONC}),
+		NewJSONProcessorWithOptions(JSONOptions{Dialect: JSONDialectJSON5}),
 		NewTOMLProcessor(),
 		NewPropertiesProcessor(),
 		NewINIProcessor(),
 		NewHCLProcessor(),
+		NewTFVarsProcessor(),
 		NewXMLProcessor(),
 		NewENVProcessor(),
+		NewHOCONProcessor(),
 	}
 
 	for _, processor := range processors {
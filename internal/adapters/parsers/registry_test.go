@@ -1,7 +1,10 @@
 package parsers
 
 import (
+	"context"
 	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
 // TestParserRegistry tests the parser registry functionality
@@ -52,8 +55,8 @@ func TestRegistryIntegration(t *testing.T) {
 	registry := NewParserRegistry()
 
 	t.Run("should support multiple file types", func(t *testing.T) {
-		fileTypes := []string{"test.yaml", "test.json", "test.toml", "test.properties", "test.ini", "test.hcl", "test.xml", "test.env"}
-		
+		fileTypes := []string{"test.yaml", "test.json", "test.toml", "test.properties", "test.ini", "test.hcl", "test.xml", "test.env", "test.conf", "test.jsonc", "test.json5", "test.tfvars", "test.tfvars.json"}
+
 		for _, filename := range fileTypes {
 			processor, err := registry.GetProcessor(filename)
 			if err != nil {
@@ -66,6 +69,104 @@ func TestRegistryIntegration(t *testing.T) {
 	})
 }
 
+// TestRegistryFormatDetection tests filename rules, template suffixes and content sniffing
+func TestRegistryFormatDetection(t *testing.T) {
+	registry := NewParserRegistry()
+
+	tests := []struct {
+		filename string
+		content  string
+		expected string
+	}{
+		{filename: "env.staging", expected: "env"},
+		{filename: ".env.production", expected: "env"},
+		{filename: "app.env.local", expected: "env"},
+		{filename: "env.json", content: `{"a": 1}`, expected: "json"},
+		{filename: "prod.tfvars.json", content: `{"region": "eu-west-1"}`, expected: "tfvars"},
+		{filename: "deploy/application-prod.yml.j2", content: "a: 1\n", expected: "yaml"},
+		{filename: "app.properties.example", content: "a = 1\n", expected: "properties"},
+		{filename: "settings", content: `{"a": 1}`, expected: "json"},
+		{filename: "settings", content: "<config><a>1</a></config>", expected: "xml"},
+		{filename: "settings", content: "server:\n  port: 80\n", expected: "yaml"},
+		{filename: "settings", content: "export API_KEY=abc\nDEBUG=true\n", expected: "env"},
+		{filename: "settings", content: "[server]\nport = 80\n", expected: "toml"},
+		{filename: "settings", content: "[server]\nport = eighty\n", expected: "ini"},
+		{filename: "settings", content: "server.port=80\n", expected: "properties"},
+		{filename: "settings", content: "resource \"aws_s3_bucket\" \"logs\" {\n  acl = \"private\"\n}\n", expected: "hcl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename+"/"+tt.expected, func(t *testing.T) {
+			processor, err := registry.DetectProcessor(tt.filename, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("DetectProcessor() error = %v", err)
+			}
+
+			configData, err := processor.Process(context.Background(), tt.filename, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if configData.Format != tt.expected {
+				t.Errorf("DetectProcessor() format = %s, want %s", configData.Format, tt.expected)
+			}
+		})
+	}
+
+	if _, err := registry.DetectProcessor("README", []byte("just some prose")); err == nil {
+		t.Error("DetectProcessor() expected error for undetectable content")
+	}
+}
+
+// TestRegistryFormatRules tests user rules and overriding the built-in mapping
+func TestRegistryFormatRules(t *testing.T) {
+	registry := NewParserRegistry()
+
+	err := registry.ApplyFormatRules([]models.FileFormatRule{
+		{Pattern: "Dockerfile", Format: "env"},
+		{Pattern: "config/**/*.conf", Format: "ini", Options: map[string]interface{}{"case_insensitive": true}},
+		{Pattern: "*.cfg", Format: "properties", Options: map[string]interface{}{"expand_keys": true}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyFormatRules() error = %v", err)
+	}
+
+	formats := map[string]string{
+		"build/Dockerfile":         "env",
+		"config/services/app.conf": "ini",
+		"config/app.conf":          "ini",
+		"application.conf":         "hocon",
+		"kafka.cfg":                "properties",
+	}
+	for filename, expected := range formats {
+		processor, err := registry.GetProcessor(filename)
+		if err != nil {
+			t.Fatalf("GetProcessor(%s) error = %v", filename, err)
+		}
+		configData, err := processor.Process(context.Background(), filename, []byte("A=1\n"))
+		if err != nil {
+			t.Fatalf("Process(%s) error = %v", filename, err)
+		}
+		if configData.Format != expected {
+			t.Errorf("GetProcessor(%s) format = %s, want %s", filename, configData.Format, expected)
+		}
+	}
+
+	if err := registry.ApplyFormatRules([]models.FileFormatRule{{Pattern: "*.x", Format: "ini", Options: map[string]interface{}{"bogus": true}}}); err == nil {
+		t.Error("ApplyFormatRules() expected error for unknown option")
+	}
+
+	if err := registry.RegisterProcessor(NewINIProcessor()); err == nil {
+		t.Error("RegisterProcessor() expected extension conflict")
+	}
+	override := NewINIProcessorWithOptions(INIOptions{CaseInsensitive: true})
+	if err := registry.OverrideProcessor(override); err != nil {
+		t.Fatalf("OverrideProcessor() error = %v", err)
+	}
+	if processor, _ := registry.GetProcessor("app.ini"); processor != override {
+		t.Error("GetProcessor() should return the overriding processor")
+	}
+}
+
 // BenchmarkRegistryGetProcessor benchmarks processor retrieval
 func BenchmarkRegistryGetProcessor(b *testing.B) {
 	registry := NewParserRegistry()
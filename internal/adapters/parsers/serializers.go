@@ -0,0 +1,317 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// serializers write configuration trees by format name
+var serializers = map[string]func(data map[string]interface{}) ([]byte, error){
+	"yaml":       serializeYAML,
+	"json":       serializeJSON,
+	"toml":       serializeTOML,
+	"ini":        serializeINI,
+	"env":        serializeENV,
+	"properties": serializeProperties,
+}
+
+// stringFormats are the writable formats that store every value as a string
+var stringFormats = map[string]bool{"env": true, "properties": true, "ini": true}
+
+// Serialize writes a configuration tree in a format. Keys are written in sorted order.
+// Flat formats (env, properties) write one line per leaf value. Values a format cannot
+// represent are left out or written as text; TargetLosses lists them.
+func Serialize(format string, data map[string]interface{}) ([]byte, error) {
+	name := normalizeFormat(format)
+
+	serializer, ok := serializers[name]
+	if !ok {
+		return nil, fmt.Errorf("writing %s files is not supported (supported: %s)", format, strings.Join(SerializableFormats(), ", "))
+	}
+	return serializer(data)
+}
+
+// SerializableFormats returns the sorted names of the formats that can be written
+func SerializableFormats() []string {
+	formats := make([]string, 0, len(serializers))
+	for format := range serializers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// StoresTypes reports whether a format keeps the types of values (8080 stays a number),
+// unlike env, properties and ini files that store every value as a string
+func StoresTypes(format string) bool {
+	return !stringFormats[normalizeFormat(format)]
+}
+
+// serializeYAML writes a tree as a YAML document indented by two spaces
+func serializeYAML(data map[string]interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// serializeJSON writes a tree as an indented JSON object. Durations are written as
+// strings ("30s"), as JSON has no duration type.
+func serializeJSON(data map[string]interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(jsonTree(data)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// jsonTree converts the values JSON cannot represent
+func jsonTree(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = jsonTree(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = jsonTree(item)
+		}
+		return converted
+	case time.Duration:
+		return v.String()
+	}
+	return value
+}
+
+// serializeTOML writes a tree as a TOML document. TOML has no null, so null values are
+// left out.
+func serializeTOML(data map[string]interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := toml.NewEncoder(&buffer)
+	encoder.Indent = ""
+	if err := encoder.Encode(withoutNulls(data)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// withoutNulls removes the null values of a tree
+func withoutNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if item != nil {
+				converted[key] = withoutNulls(item)
+			}
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				converted = append(converted, withoutNulls(item))
+			}
+		}
+		return converted
+	}
+	return value
+}
+
+// serializeINI writes a tree as an INI file: top-level values first, then a section per
+// map ([database], [database.pool]). Lists of values are written as repeated keys and
+// null values as bare keys, as the INI parser reads them.
+func serializeINI(data map[string]interface{}) ([]byte, error) {
+	var builder strings.Builder
+	if err := writeINISection(&builder, "", data); err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimPrefix(builder.String(), "\n")), nil
+}
+
+// writeINISection writes the values of a section and then its subsections
+func writeINISection(builder *strings.Builder, name string, section map[string]interface{}) error {
+	keys := make([]string, 0, len(section))
+	for key := range section {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var subsections []string
+	hasValues := false
+	for _, key := range keys {
+		if _, ok := section[key].(map[string]interface{}); ok {
+			subsections = append(subsections, key)
+			continue
+		}
+		hasValues = true
+	}
+
+	if name != "" && (hasValues || len(subsections) == 0) {
+		fmt.Fprintf(builder, "\n[%s]\n", name)
+	}
+	for _, key := range keys {
+		switch value := section[key].(type) {
+		case map[string]interface{}:
+			continue
+		case []interface{}:
+			for _, item := range value {
+				if err := writeINIValue(builder, key, item); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := writeINIValue(builder, key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range subsections {
+		child := key
+		if name != "" {
+			child = name + "." + key
+		}
+		if err := writeINISection(builder, child, section[key].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeINIValue writes one key/value line, quoting values that the parser would trim or
+// cut at an inline comment. Lists and maps inside lists cannot be written and are skipped.
+func writeINIValue(builder *strings.Builder, key string, value interface{}) error {
+	switch value.(type) {
+	case nil:
+		fmt.Fprintf(builder, "%s\n", key)
+		return nil
+	case map[string]interface{}, []interface{}:
+		return nil
+	}
+
+	text, err := scalarText(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if text != strings.TrimSpace(text) || strings.ContainsAny(text, ";#") || strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'") {
+		quote := `"`
+		if strings.Contains(text, `"`) {
+			quote = "'"
+		}
+		text = quote + text + quote
+	}
+	// Line breaks continue the value on indented lines
+	text = strings.ReplaceAll(text, "\n", "\n    ")
+	fmt.Fprintf(builder, "%s = %s\n", key, text)
+	return nil
+}
+
+// serializeENV writes a tree as environment variables. Key paths become upper snake case
+// names (database.host is DATABASE_HOST, servers[0] is SERVERS_0).
+func serializeENV(data map[string]interface{}) ([]byte, error) {
+	var builder strings.Builder
+	for _, leaf := range flatLeaves(data) {
+		name := EnvName(leaf.path)
+		// Guard clause: keys such as 1 or "" have no variable name
+		if !envKeyPattern.MatchString(name) {
+			continue
+		}
+		// A bare variable name declares the variable without a value
+		if leaf.value == nil {
+			fmt.Fprintf(&builder, "%s\n", name)
+			continue
+		}
+		text, err := formatENVValue(leaf.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", leaf.path, err)
+		}
+		fmt.Fprintf(&builder, "%s=%s\n", name, text)
+	}
+	return []byte(builder.String()), nil
+}
+
+// serializeProperties writes a tree as Java properties with dotted keys (database.host)
+// and indexed keys for lists (servers[0])
+func serializeProperties(data map[string]interface{}) ([]byte, error) {
+	var builder strings.Builder
+	for _, leaf := range flatLeaves(data) {
+		text, err := scalarText(leaf.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", leaf.path, err)
+		}
+		fmt.Fprintf(&builder, "%s=%s\n", escapePropertiesKey(leaf.path.Dotted()), escapePropertiesValue(text))
+	}
+	return []byte(builder.String()), nil
+}
+
+// flatLeaf is a leaf value of a tree written by a flat format
+type flatLeaf struct {
+	path  models.Path
+	value interface{}
+}
+
+// flatLeaves returns the leaves of a tree in sorted key order. Empty maps and lists are
+// returned as empty strings.
+func flatLeaves(data map[string]interface{}) []flatLeaf {
+	var leaves []flatLeaf
+	tree := &models.ConfigData{Data: data}
+	_ = tree.Walk(func(keyPath models.Path, value interface{}) error {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if len(v) > 0 || len(keyPath) == 0 {
+				return nil
+			}
+			value = ""
+		case []interface{}:
+			if len(v) > 0 {
+				return nil
+			}
+			value = ""
+		}
+		leaves = append(leaves, flatLeaf{path: append(models.Path{}, keyPath...), value: value})
+		return nil
+	})
+	return leaves
+}
+
+// EnvName returns the environment variable name of a key path: its keys and indexes in
+// upper case joined by underscores, with other characters replaced by underscores
+func EnvName(keyPath models.Path) string {
+	parts := make([]string, len(keyPath))
+	for i, segment := range keyPath {
+		if segment.IsIndex {
+			parts[i] = strconv.Itoa(segment.Index)
+			continue
+		}
+		parts[i] = strings.Map(func(r rune) rune {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				return unicode.ToUpper(r)
+			}
+			return '_'
+		}, segment.Key)
+	}
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,107 @@
+package parsers
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestSerialize tests writing a configuration tree in every serializable format
+func TestSerialize(t *testing.T) {
+	data := map[string]interface{}{
+		"database": map[string]interface{}{"host": "db <primary>", "port": 5432},
+		"servers":  []interface{}{"a", "b"},
+		"timeout":  30 * time.Second,
+		"empty":    map[string]interface{}{},
+		"note":     "two words",
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format:   "yaml",
+			expected: "database:\n  host: db <primary>\n  port: 5432\nempty: {}\nnote: two words\nservers:\n  - a\n  - b\ntimeout: 30s\n",
+		},
+		{
+			format:   "json",
+			expected: "{\n  \"database\": {\n    \"host\": \"db <primary>\",\n    \"port\": 5432\n  },\n  \"empty\": {},\n  \"note\": \"two words\",\n  \"servers\": [\n    \"a\",\n    \"b\"\n  ],\n  \"timeout\": \"30s\"\n}\n",
+		},
+		{
+			format:   "toml",
+			expected: "note = \"two words\"\nservers = [\"a\", \"b\"]\ntimeout = \"30s\"\n\n[database]\nhost = \"db <primary>\"\nport = 5432\n\n[empty]\n",
+		},
+		{
+			format:   "ini",
+			expected: "note = two words\nservers = a\nservers = b\ntimeout = 30s\n\n[database]\nhost = db <primary>\nport = 5432\n\n[empty]\n",
+		},
+		{
+			format:   "env",
+			expected: "DATABASE_HOST=\"db <primary>\"\nDATABASE_PORT=5432\nEMPTY=\nNOTE=\"two words\"\nSERVERS_0=a\nSERVERS_1=b\nTIMEOUT=30s\n",
+		},
+		{
+			format:   "properties",
+			expected: "database.host=db <primary>\ndatabase.port=5432\nempty=\nnote=two words\nservers[0]=a\nservers[1]=b\ntimeout=30s\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			content, err := Serialize(tt.format, data)
+			if err != nil {
+				t.Fatalf("Serialize() error = %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("Serialize() =\n%s\nwant\n%s", content, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSerializeUnsupported tests formats that cannot be written
+func TestSerializeUnsupported(t *testing.T) {
+	if _, err := Serialize("xml", map[string]interface{}{}); err == nil {
+		t.Error("Serialize() error = nil, want an error")
+	}
+}
+
+// TestSerializeINIQuoting tests INI values the parser would otherwise trim or cut
+func TestSerializeINIQuoting(t *testing.T) {
+	data := map[string]interface{}{
+		"padded":  " value ",
+		"comment": "a;b",
+		"quoted":  `say "hi"`,
+		"unset":   nil,
+	}
+
+	content, err := Serialize("ini", data)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	expected := "comment = \"a;b\"\npadded = \" value \"\nquoted = say \"hi\"\nunset\n"
+	if string(content) != expected {
+		t.Errorf("Serialize() =\n%s\nwant\n%s", content, expected)
+	}
+}
+
+// TestSerializeENVInvalidNames tests that keys without a variable name are dropped, so
+// that the result can be read back
+func TestSerializeENVInvalidNames(t *testing.T) {
+	data := map[string]interface{}{
+		"1":    "one",
+		"port": 80,
+	}
+
+	content, err := Serialize("env", data)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	if _, err := NewENVProcessor().Process(context.Background(), ".env", content); err != nil {
+		t.Errorf("Process() of %q error = %v", content, err)
+	}
+	expected := "PORT=80\n"
+	if string(content) != expected {
+		t.Errorf("Serialize() =\n%s\nwant\n%s", content, expected)
+	}
+}
@@ -0,0 +1,182 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TFVarsOptions configures the Terraform variable file processor
+type TFVarsOptions struct {
+	// Variables is a variables.tf file, or a directory of .tf files, whose variable
+	// declarations are attached to every processed file as "terraform_variables" metadata.
+	// Relative paths are resolved against the directory of the tfvars file.
+	Variables string
+}
+
+// TFVarsProcessor implements FileProcessor for Terraform variable files (.tfvars and .tfvars.json)
+type TFVarsProcessor struct {
+	supportedExtensions []string
+	options             TFVarsOptions
+}
+
+// NewTFVarsProcessor creates a new Terraform variable file processor
+func NewTFVarsProcessor() *TFVarsProcessor {
+	return NewTFVarsProcessorWithOptions(TFVarsOptions{})
+}
+
+// NewTFVarsProcessorWithOptions creates a Terraform variable file processor with the given options
+func NewTFVarsProcessorWithOptions(options TFVarsOptions) *TFVarsProcessor {
+	return &TFVarsProcessor{
+		supportedExtensions: []string{"tfvars", "tfvars.json"},
+		options:             options,
+	}
+}
+
+// CanProcess checks if this processor can handle the given filename
+func (p *TFVarsProcessor) CanProcess(filename string) bool {
+	name := strings.ToLower(filename)
+	for _, ext := range p.supportedExtensions {
+		if strings.HasSuffix(name, "."+ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Process processes a Terraform variable file
+func (p *TFVarsProcessor) Process(ctx context.Context, filename string, content []byte) (*models.ConfigData, error) {
+	if err := ValidateContextAndInput(ctx, filename, content); err != nil {
+		return nil, err
+	}
+
+	configData, err := p.parse(ctx, filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Guard clause: no declarations to attach
+	if p.options.Variables == "" {
+		return configData, nil
+	}
+
+	variablesPath := p.options.Variables
+	if !filepath.IsAbs(variablesPath) {
+		variablesPath = filepath.Join(filepath.Dir(filename), variablesPath)
+	}
+
+	variables, err := loadTerraformVariables(variablesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variable declarations: %w", err)
+	}
+	configData.Metadata["terraform_variables"] = variables
+
+	return configData, nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (p *TFVarsProcessor) GetSupportedExtensions() []string {
+	return copyExtensions(p.supportedExtensions)
+}
+
+// parse parses either tfvars flavour into config data with the "tfvars" format
+func (p *TFVarsProcessor) parse(ctx context.Context, filename string, content []byte) (*models.ConfigData, error) {
+	// JSON flavour
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		configData, err := NewJSONProcessor().Process(ctx, filename, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tfvars: %w", err)
+		}
+		configData.Format = "tfvars"
+		configData.Metadata["processor"] = "tfvars"
+		configData.Metadata["syntax"] = "json"
+		return configData, nil
+	}
+
+	data, positions, err := parseHCLContent(filename, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tfvars: %w", err)
+	}
+
+	configData := createConfigData(filename, "tfvars", data, positions)
+	configData.Metadata["syntax"] = "hcl"
+	return configData, nil
+}
+
+// loadTerraformVariables reads the variable blocks of a .tf file or of every .tf file in a directory
+func loadTerraformVariables(path string) ([]models.TerraformVariable, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.tf"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+	}
+
+	var variables []models.TerraformVariable
+	for _, file := range files {
+		declared, err := parseTerraformVariables(file)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, declared...)
+	}
+
+	return variables, nil
+}
+
+// parseTerraformVariables extracts variable declarations from a .tf file
+func parseTerraformVariables(filename string) ([]models.TerraformVariable, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	file, diags := hclsyntax.ParseConfig(content, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unsupported HCL body type %T", file.Body)
+	}
+
+	var variables []models.TerraformVariable
+	for _, block := range body.Blocks {
+		if block.Type != "variable" || len(block.Labels) != 1 {
+			continue
+		}
+
+		variable := models.TerraformVariable{
+			Name:     block.Labels[0],
+			Required: true,
+			File:     filename,
+			Position: hclPosition(block.DefRange()),
+		}
+		if attr, ok := block.Body.Attributes["type"]; ok {
+			variable.Type = strings.TrimSpace(string(attr.Expr.Range().SliceBytes(content)))
+		}
+		if _, ok := block.Body.Attributes["default"]; ok {
+			variable.Required = false
+		}
+
+		variables = append(variables, variable)
+	}
+
+	return variables, nil
+}
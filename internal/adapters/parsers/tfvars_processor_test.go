@@ -0,0 +1,84 @@
+package parsers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestTFVarsProcessor tests both tfvars flavours and variable declarations
+func TestTFVarsProcessor(t *testing.T) {
+	dir := t.TempDir()
+	declarations := `variable "region" {
+  type = string
+}
+
+variable "instance_count" {
+  type    = number
+  default = 1
+}
+
+variable "tags" {
+  type = map(string)
+  default = {}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(declarations), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedVariables := []models.TerraformVariable{
+		{Name: "region", Type: "string", Required: true, File: filepath.Join(dir, "variables.tf"), Position: models.Position{Line: 1, Column: 1}},
+		{Name: "instance_count", Type: "number", File: filepath.Join(dir, "variables.tf"), Position: models.Position{Line: 5, Column: 1}},
+		{Name: "tags", Type: "map(string)", File: filepath.Join(dir, "variables.tf"), Position: models.Position{Line: 10, Column: 1}},
+	}
+
+	tests := []struct {
+		filename string
+		content  string
+		syntax   string
+	}{
+		{filename: "dev.tfvars", content: "region = \"eu-west-1\"\ninstance_count = 2\n", syntax: "hcl"},
+		{filename: "dev.tfvars.json", content: `{"region": "eu-west-1", "instance_count": 2}`, syntax: "json"},
+	}
+
+	processor := NewTFVarsProcessorWithOptions(TFVarsOptions{Variables: "variables.tf"})
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			if !processor.CanProcess(tt.filename) {
+				t.Fatalf("CanProcess(%s) = false, want true", tt.filename)
+			}
+
+			configData, err := processor.Process(context.Background(), filepath.Join(dir, tt.filename), []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			if configData.Format != "tfvars" || configData.Metadata["syntax"] != tt.syntax {
+				t.Errorf("Process() format = %s (%v), want tfvars (%s)", configData.Format, configData.Metadata["syntax"], tt.syntax)
+			}
+			if configData.Data["region"] != "eu-west-1" {
+				t.Errorf("Process() region = %v, want eu-west-1", configData.Data["region"])
+			}
+			if configData.Positions["instance_count"].Line != 2 && tt.syntax == "hcl" {
+				t.Errorf("Process() instance_count line = %d, want 2", configData.Positions["instance_count"].Line)
+			}
+
+			variables := configData.Metadata["terraform_variables"]
+			if !reflect.DeepEqual(variables, expectedVariables) {
+				t.Errorf("Process() variables = %+v, want %+v", variables, expectedVariables)
+			}
+		})
+	}
+
+	t.Run("missing declarations", func(t *testing.T) {
+		processor := NewTFVarsProcessorWithOptions(TFVarsOptions{Variables: "missing.tf"})
+		if _, err := processor.Process(context.Background(), filepath.Join(dir, "dev.tfvars"), []byte("a = 1\n")); err == nil {
+			t.Error("Process() should fail when the declarations cannot be read")
+		}
+	})
+}
@@ -0,0 +1,449 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// tomlBareKeyPattern matches keys TOML allows without quotes
+var tomlBareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tomlEditor edits TOML files line by line, keeping comments, key order and the
+// spacing and trailing comments of edited assignments
+type tomlEditor struct {
+	text *textLines
+}
+
+// tomlTable is a table header and its body: lines [start, end). The root table has no
+// header and starts at line 0.
+type tomlTable struct {
+	keys  []string
+	path  models.Path
+	start int
+	end   int
+	array bool
+	root  bool
+}
+
+// tomlEntry locates an assignment: lines [start, end), the text before its value and the
+// trailing comment of a single-line value
+type tomlEntry struct {
+	table   *tomlTable
+	keys    []string
+	path    models.Path
+	start   int
+	end     int
+	prefix  string
+	comment string
+}
+
+// newTOMLEditor creates an editor for TOML content
+func newTOMLEditor(content []byte) (models.ConfigEditor, error) {
+	if _, err := parseTOMLContent(content); err != nil {
+		return nil, err
+	}
+	return &tomlEditor{text: newTextLines(content)}, nil
+}
+
+// Set replaces the value of an assignment, or adds it to the closest existing table.
+// New tables are appended at the end of the file.
+func (e *tomlEditor) Set(path string, value interface{}) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+	value = models.NormalizeValue(value)
+
+	tables, entries := e.scan()
+	for _, entry := range entries {
+		switch {
+		case equalPaths(entry.path, parsed):
+			text, err := formatTOMLValue(value)
+			if err != nil {
+				return fmt.Errorf("cannot set %s: %w", path, err)
+			}
+			e.text.replace(entry.start, entry.end, entry.prefix+text+entry.comment)
+			return nil
+		case hasPathPrefix(parsed, entry.path):
+			return fmt.Errorf("cannot set %s: it is inside the inline value of %s", path, entry.path)
+		}
+	}
+
+	// Tables are replaced as a whole
+	for _, table := range tables {
+		if !table.root && equalPaths(table.path, parsed) {
+			if err := e.Delete(path); err != nil {
+				return err
+			}
+			tables, _ = e.scan()
+			break
+		}
+	}
+
+	return e.add(path, parsed, tables, value)
+}
+
+// add inserts a new assignment below the longest existing table prefix of a path, or
+// appends a new table for map values
+func (e *tomlEditor) add(path string, parsed models.Path, tables []*tomlTable, value interface{}) error {
+	var target *tomlTable
+	for _, table := range tables {
+		if hasPathPrefix(parsed, table.path) && (target == nil || len(table.path) >= len(target.path)) {
+			target = table
+		}
+	}
+
+	keys, ok := pathKeys(parsed[len(target.path):])
+	if !ok {
+		return fmt.Errorf("cannot set %s: new array items cannot be added to TOML files", path)
+	}
+
+	// Guard clause: maps outside array tables become new tables
+	if values, isMap := value.(map[string]interface{}); isMap && len(values) > 0 {
+		if allKeys, ok := pathKeys(parsed); ok {
+			return e.appendTable(path, allKeys, values)
+		}
+	}
+
+	text, err := formatTOMLValue(value)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	bodyStart := target.start + 1
+	if target.root {
+		bodyStart = 0
+	}
+	at := e.text.lastContentLine(bodyStart, target.end)
+
+	indent := ""
+	if at > bodyStart {
+		indent = leadingWhitespace(e.text.lines[at-1])
+	}
+	lines := []string{indent + formatTOMLKey(keys) + " = " + text}
+
+	// Keep a blank line between root keys and the first table
+	if target.root && at == 0 && len(tables) > 1 {
+		lines = append(lines, "")
+	}
+	e.text.insert(at, lines...)
+	return nil
+}
+
+// appendTable appends a new table with the keys of a map at the end of the file
+func (e *tomlEditor) appendTable(path string, keys []string, values map[string]interface{}) error {
+	lines := []string{"[" + formatTOMLKey(keys) + "]"}
+	for _, key := range sortedMapKeys(values) {
+		text, err := formatTOMLValue(values[key])
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		lines = append(lines, formatTOMLKey([]string{key})+" = "+text)
+	}
+
+	at := e.text.lastContentLine(0, len(e.text.lines))
+	if at > 0 {
+		lines = append([]string{""}, lines...)
+	}
+	e.text.replace(at, len(e.text.lines), lines...)
+	return nil
+}
+
+// Delete removes an assignment, the assignments below a dotted key or a table with its
+// subtables
+func (e *tomlEditor) Delete(path string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	tables, entries := e.scan()
+	var ranges [][2]int
+
+	for _, entry := range entries {
+		switch {
+		case hasPathPrefix(entry.path, parsed):
+			ranges = append(ranges, [2]int{entry.start, entry.end})
+		case hasPathPrefix(parsed, entry.path):
+			return fmt.Errorf("cannot delete %s: it is inside the inline value of %s", path, entry.path)
+		}
+	}
+	for _, table := range tables {
+		if !table.root && hasPathPrefix(table.path, parsed) {
+			ranges = append(ranges, [2]int{table.start, table.end})
+		}
+	}
+
+	if len(ranges) == 0 {
+		return pathNotFound(path)
+	}
+
+	e.text.remove(ranges)
+	return nil
+}
+
+// Rename renames the last key of path in assignments and table headers
+func (e *tomlEditor) Rename(path, newKey string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+	if parsed[len(parsed)-1].IsIndex {
+		return fmt.Errorf("cannot rename %s: array items have no key", path)
+	}
+	if newKey == "" {
+		return fmt.Errorf("new key cannot be empty")
+	}
+
+	renamed := append(append(models.Path{}, parsed[:len(parsed)-1]...), models.KeySegment(newKey))
+	tables, entries := e.scan()
+	for _, entry := range entries {
+		if hasPathPrefix(entry.path, renamed) {
+			return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+		}
+	}
+	for _, table := range tables {
+		if !table.root && hasPathPrefix(table.path, renamed) {
+			return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+		}
+	}
+
+	found := false
+	for _, entry := range entries {
+		switch {
+		case hasPathPrefix(entry.path, parsed) && len(entry.table.path) < len(parsed):
+			found = true
+			keys := append([]string{}, entry.keys...)
+			keys[len(parsed)-len(entry.table.path)-1] = newKey
+			line := e.text.lines[entry.start]
+			indent := leadingWhitespace(line)
+			separator := indexOutsideQuotes(line, '=')
+			keyText := strings.TrimRight(line[len(indent):separator], " \t")
+			e.text.lines[entry.start] = indent + formatTOMLKey(keys) + line[len(indent)+len(keyText):]
+		case hasPathPrefix(parsed, entry.path) && !equalPaths(parsed, entry.path):
+			return fmt.Errorf("cannot rename %s: it is inside the inline value of %s", path, entry.path)
+		}
+	}
+
+	// Headers name keys only, so the renamed header key is counted without indexes
+	position := 0
+	for _, segment := range parsed {
+		if !segment.IsIndex {
+			position++
+		}
+	}
+	for _, table := range tables {
+		if table.root || !hasPathPrefix(table.path, parsed) {
+			continue
+		}
+		found = true
+		keys := append([]string{}, table.keys...)
+		keys[position-1] = newKey
+		line := e.text.lines[table.start]
+		comment := line[len(strings.TrimRight(stripTOMLComment(line), " \t")):]
+		opening, closing := "[", "]"
+		if table.array {
+			opening, closing = "[[", "]]"
+		}
+		e.text.lines[table.start] = leadingWhitespace(line) + opening + formatTOMLKey(keys) + closing + comment
+	}
+
+	if !found {
+		return pathNotFound(path)
+	}
+	return nil
+}
+
+// Bytes returns the edited content, checking that it is still valid TOML
+func (e *tomlEditor) Bytes() ([]byte, error) {
+	content := e.text.bytes()
+	if _, err := parseTOMLContent(content); err != nil {
+		return nil, fmt.Errorf("edit produced invalid TOML: %w", err)
+	}
+	return content, nil
+}
+
+// scan locates the tables and assignments of the content
+func (e *tomlEditor) scan() ([]*tomlTable, []tomlEntry) {
+	lines := e.text.lines
+	current := &tomlTable{root: true, end: len(lines)}
+	tables := []*tomlTable{current}
+	var entries []tomlEntry
+	arrayIndexes := make(map[string]int)
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := trimLine(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") {
+			path, err := models.ParsePath(resolveTOMLTable(trimmed, arrayIndexes))
+			if err != nil {
+				continue
+			}
+			current.end = i
+			current = &tomlTable{
+				keys:  splitTOMLKey(strings.Trim(strings.TrimSpace(stripTOMLComment(trimmed)), "[]")),
+				path:  path,
+				start: i,
+				end:   len(lines),
+				array: strings.HasPrefix(trimmed, "[["),
+			}
+			tables = append(tables, current)
+			continue
+		}
+
+		separator := indexOutsideQuotes(line, '=')
+		if separator == -1 {
+			continue
+		}
+
+		keys := splitTOMLKey(line[:separator])
+		path := append(models.Path{}, current.path...)
+		for _, key := range keys {
+			path = path.Key(key)
+		}
+
+		rawValue := line[separator+1:]
+		value := strings.TrimLeft(rawValue, " \t")
+		entry := tomlEntry{
+			table:  current,
+			keys:   keys,
+			path:   path,
+			start:  i,
+			end:    i + 1,
+			prefix: line[:len(line)-len(value)],
+		}
+
+		// Multi-line strings and arrays continue until they are closed
+		if delimiter := openTOMLMultilineString(strings.TrimSpace(value)); delimiter != "" {
+			for entry.end < len(lines) && !strings.Contains(lines[entry.end], delimiter) {
+				entry.end++
+			}
+			entry.end = min(entry.end+1, len(lines))
+		} else if depth := tomlBracketDelta(value); strings.HasPrefix(value, "[") && depth > 0 {
+			for entry.end < len(lines) && depth > 0 {
+				depth += tomlBracketDelta(trimLine(lines[entry.end]))
+				entry.end++
+			}
+		} else {
+			entry.comment = value[len(strings.TrimRight(stripTOMLComment(value), " \t")):]
+		}
+
+		entries = append(entries, entry)
+		i = entry.end - 1
+	}
+
+	return tables, entries
+}
+
+// formatTOMLKey formats a dotted key, quoting segments that are not bare keys
+func formatTOMLKey(keys []string) string {
+	formatted := make([]string, len(keys))
+	for i, key := range keys {
+		formatted[i] = key
+		if !tomlBareKeyPattern.MatchString(key) {
+			formatted[i] = quoteTOMLString(key)
+		}
+	}
+	return strings.Join(formatted, ".")
+}
+
+// formatTOMLValue formats a value as a TOML literal; maps become inline tables
+func formatTOMLValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("TOML has no null value")
+	case string:
+		return quoteTOMLString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return formatTOMLFloat(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return quoteTOMLString(v.String()), nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			text, err := formatTOMLValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = text
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}", nil
+		}
+		members := make([]string, 0, len(v))
+		for _, key := range sortedMapKeys(v) {
+			text, err := formatTOMLValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			members = append(members, formatTOMLKey([]string{key})+" = "+text)
+		}
+		return "{ " + strings.Join(members, ", ") + " }", nil
+	}
+	return "", fmt.Errorf("cannot write %T value to TOML", value)
+}
+
+// formatTOMLFloat formats a float so that it is read back as a float
+func formatTOMLFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "nan"
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	}
+
+	text := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".eE") {
+		text += ".0"
+	}
+	return text
+}
+
+// quoteTOMLString quotes a string as a TOML basic string (JSON escapes are valid TOML)
+func quoteTOMLString(text string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(text)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// equalPaths checks if two key paths are equal
+func equalPaths(a, b models.Path) bool {
+	return len(a) == len(b) && hasPathPrefix(a, b)
+}
+
+// hasPathPrefix checks if a key path starts with prefix
+func hasPathPrefix(path, prefix models.Path) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package parsers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 
@@ -37,7 +38,7 @@ func (p *TOMLProcessor) Process(ctx context.Context, filename string, content []
 		return nil, fmt.Errorf("failed to parse TOML: %w", err)
 	}
 
-	return createConfigData(filename, "toml", data), nil
+	return createConfigData(filename, "toml", data, collectTOMLPositions(content)), nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -63,3 +64,176 @@ func parseTOMLContent(content []byte) (map[string]interface{}, error) {
 	return result, nil
 }
 
+// collectTOMLPositions records the position of every table and key by scanning the
+// source line by line. Array tables are resolved to indexed paths (e.g. servers[1].host).
+func collectTOMLPositions(content []byte) map[string]models.Position {
+	positions := createEmptyPositions()
+	arrayIndexes := make(map[string]int)
+	currentTable := ""
+	multilineDelimiter := ""
+	bracketDepth := 0
+
+	for i, line := range splitLines(content) {
+		trimmedLine := trimLine(line)
+
+		// Skip the body of multi-line strings and arrays
+		if multilineDelimiter != "" {
+			if strings.Contains(trimmedLine, multilineDelimiter) {
+				multilineDelimiter = ""
+			}
+			continue
+		}
+		if bracketDepth > 0 {
+			bracketDepth += tomlBracketDelta(trimmedLine)
+			continue
+		}
+
+		// Skip empty lines and comments
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
+
+		// Table headers
+		if strings.HasPrefix(trimmedLine, "[") {
+			currentTable = resolveTOMLTable(trimmedLine, arrayIndexes)
+			recordPosition(positions, currentTable, linePosition(i+1, line))
+			continue
+		}
+
+		// Key-value pairs
+		separator := indexOutsideQuotes(trimmedLine, '=')
+		if separator == -1 {
+			continue
+		}
+
+		path := currentTable
+		for _, segment := range splitTOMLKey(trimmedLine[:separator]) {
+			path = models.JoinPath(path, segment)
+			recordPosition(positions, path, linePosition(i+1, line))
+		}
+
+		value := strings.TrimSpace(trimmedLine[separator+1:])
+		multilineDelimiter = openTOMLMultilineString(value)
+		if strings.HasPrefix(value, "[") {
+			bracketDepth = tomlBracketDelta(value)
+		}
+	}
+
+	return positions
+}
+
+// resolveTOMLTable resolves a [table] or [[array]] header to a key path
+func resolveTOMLTable(header string, arrayIndexes map[string]int) string {
+	isArray := strings.HasPrefix(header, "[[")
+	name := strings.Trim(strings.TrimSpace(stripTOMLComment(header)), "[]")
+	segments := splitTOMLKey(name)
+
+	path := ""
+	for i, segment := range segments {
+		path = models.JoinPath(path, segment)
+
+		// The last segment of an array header opens a new element
+		if isArray && i == len(segments)-1 {
+			index, exists := arrayIndexes[path]
+			if exists {
+				index++
+			}
+			arrayIndexes[path] = index
+			return models.IndexPath(path, index)
+		}
+
+		// Intermediate array tables refer to their current element
+		if index, exists := arrayIndexes[path]; exists {
+			path = models.IndexPath(path, index)
+		}
+	}
+
+	return path
+}
+
+// splitTOMLKey splits a dotted TOML key into unquoted segments
+func splitTOMLKey(key string) []string {
+	segments := make([]string, 0)
+	for {
+		separator := indexOutsideQuotes(key, '.')
+		if separator == -1 {
+			break
+		}
+		segments = append(segments, unquoteTOMLKey(key[:separator]))
+		key = key[separator+1:]
+	}
+	return append(segments, unquoteTOMLKey(key))
+}
+
+// unquoteTOMLKey trims and unquotes a single TOML key segment
+func unquoteTOMLKey(segment string) string {
+	return removeQuotes(strings.TrimSpace(segment))
+}
+
+// stripTOMLComment removes a trailing comment from a line
+func stripTOMLComment(line string) string {
+	if index := indexOutsideQuotes(line, '#'); index != -1 {
+		return line[:index]
+	}
+	return line
+}
+
+// openTOMLMultilineString returns the delimiter of a multi-line string left open by value
+func openTOMLMultilineString(value string) string {
+	for _, delimiter := range []string{`"""`, "'''"} {
+		if strings.HasPrefix(value, delimiter) && !strings.Contains(value[len(delimiter):], delimiter) {
+			return delimiter
+		}
+	}
+	return ""
+}
+
+// tomlBracketDelta returns the bracket balance of a line, ignoring quoted text and comments
+func tomlBracketDelta(line string) int {
+	delta := 0
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return delta
+		case r == '[':
+			delta++
+		case r == ']':
+			delta--
+		}
+	}
+	return delta
+}
+
+// indexOutsideQuotes returns the index of the first separator that is not quoted
+func indexOutsideQuotes(line string, separator byte) int {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch {
+		case quote != 0:
+			if line[i] == '\\' && quote == '"' {
+				i++
+			} else if line[i] == quote {
+				quote = 0
+			}
+		case line[i] == '"' || line[i] == '\'':
+			quote = line[i]
+		case line[i] == separator:
+			return i
+		}
+	}
+	return -1
+}
+
+// recordPosition records a position unless the path was already seen
+func recordPosition(positions map[string]models.Position, path string, position models.Position) {
+	if _, exists := positions[path]; !exists {
+		positions[path] = position
+	}
+}
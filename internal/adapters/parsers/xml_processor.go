@@ -1,14 +1,24 @@
 package parsers
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/syntropysoft/praetorian-go/internal/domain/models"
 )
 
+// XML mapping conventions: attributes are exposed as "@name" keys, the text of an element
+// that also has attributes or children as "#text", and repeated sibling elements as arrays.
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+	xmlNamespaceURL    = "http://www.w3.org/XML/1998/namespace"
+)
+
 // XMLProcessor implements FileProcessor for XML files
 type XMLProcessor struct {
 	supportedExtensions []string
@@ -32,12 +42,20 @@ func (p *XMLProcessor) Process(ctx context.Context, filename string, content []b
 		return nil, err
 	}
 
-	data, err := parseXMLContent(content)
+	document, err := parseXMLContent(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
 
-	return createConfigData(filename, "xml", data), nil
+	configData := createConfigData(filename, "xml", document.data, document.positions)
+	if document.root != "" {
+		configData.Metadata["root_element"] = document.root
+	}
+	if len(document.namespaces) > 0 {
+		configData.Metadata["namespaces"] = document.namespaces
+	}
+
+	return configData, nil
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -45,19 +63,296 @@ func (p *XMLProcessor) GetSupportedExtensions() []string {
 	return copyExtensions(p.supportedExtensions)
 }
 
-// parseXMLContent parses XML content
-func parseXMLContent(content []byte) (map[string]interface{}, error) {
+// xmlDocument holds the mapped tree of an XML document
+type xmlDocument struct {
+	root       string
+	data       map[string]interface{}
+	positions  map[string]models.Position
+	namespaces map[string]string
+}
+
+// xmlAttribute represents a mapped element attribute
+type xmlAttribute struct {
+	name  string
+	value string
+}
+
+// xmlElement represents an element of the intermediate XML tree
+type xmlElement struct {
+	name       string
+	attributes []xmlAttribute
+	children   []*xmlElement
+	text       strings.Builder
+	position   models.Position
+}
+
+// parseXMLContent parses XML content into a tree rooted at the document element.
+// The root element itself is not part of the key paths (its name is kept as metadata).
+func parseXMLContent(content []byte) (*xmlDocument, error) {
+	document := &xmlDocument{
+		data:       createEmptyResult(),
+		positions:  createEmptyPositions(),
+		namespaces: make(map[string]string),
+	}
+
 	// Guard clause: empty content
-	if isEmptyContent(content) {
-		return createEmptyResult(), nil
+	if len(bytes.TrimSpace(content)) == 0 {
+		return document, nil
 	}
 
-	var data map[string]interface{}
-	decoder := xml.NewDecoder(strings.NewReader(string(content)))
-	err := decoder.Decode(&data) // This will decode into a generic map
+	root, err := buildXMLTree(content, document.namespaces)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse XML: %w", err)
+		return nil, err
+	}
+
+	document.root = root.name
+	value := mapXMLElement(root, "", document.positions)
+	if data, ok := value.(map[string]interface{}); ok {
+		document.data = data
+	} else {
+		document.data[root.name] = value
+		document.positions[models.JoinPath("", root.name)] = root.position
+	}
+
+	return document, nil
+}
+
+// buildXMLTree decodes content into an element tree, resolving namespace prefixes
+func buildXMLTree(content []byte, namespaces map[string]string) (*xmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	scopes := []map[string]string{{xmlNamespaceURL: "xml"}}
+	var stack []*xmlElement
+	var root *xmlElement
+
+	for {
+		line, column := decoder.InputPos()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			scope := declareXMLNamespaces(t.Attr, scopes[len(scopes)-1], namespaces)
+			scopes = append(scopes, scope)
+
+			element := &xmlElement{
+				name:       qualifyXMLName(t.Name, scope),
+				attributes: mapXMLAttributes(t.Attr, scope),
+				position:   models.Position{Line: line, Column: column},
+			}
+
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("multiple root elements at line %d", line)
+				}
+				root = element
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			scopes = scopes[:len(scopes)-1]
+		case xml.CharData:
+			// CDATA sections are delivered as character data as well
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("no root element found")
+	}
+
+	return root, nil
+}
+
+// declareXMLNamespaces returns the namespace scope of an element (URL -> prefix),
+// recording every declaration in namespaces (prefix -> URL)
+func declareXMLNamespaces(attributes []xml.Attr, parent map[string]string, namespaces map[string]string) map[string]string {
+	scope := parent
+	copied := false
+	for _, attr := range attributes {
+		prefix, ok := xmlNamespaceDeclaration(attr)
+		if !ok {
+			continue
+		}
+
+		// Copy on first declaration so the parent scope stays untouched
+		if !copied {
+			scope = make(map[string]string, len(parent)+1)
+			for url, p := range parent {
+				scope[url] = p
+			}
+			copied = true
+		}
+
+		scope[attr.Value] = prefix
+		namespaces[prefix] = attr.Value
+	}
+	return scope
+}
+
+// xmlNamespaceDeclaration returns the declared prefix if attr is an xmlns declaration
+func xmlNamespaceDeclaration(attr xml.Attr) (string, bool) {
+	if attr.Name.Space == "xmlns" {
+		return attr.Name.Local, true
+	}
+	if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+		return "", true
+	}
+	return "", false
+}
+
+// qualifyXMLName maps a resolved name back to its source prefix (e.g. soap:Envelope)
+func qualifyXMLName(name xml.Name, scope map[string]string) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	prefix, ok := scope[name.Space]
+	if !ok {
+		// Undeclared prefixes are left untranslated by the decoder
+		return name.Space + ":" + name.Local
+	}
+	if prefix == "" {
+		return name.Local
+	}
+
+	return prefix + ":" + name.Local
+}
+
+// mapXMLAttributes maps element attributes, dropping namespace declarations
+func mapXMLAttributes(attributes []xml.Attr, scope map[string]string) []xmlAttribute {
+	mapped := make([]xmlAttribute, 0, len(attributes))
+	for _, attr := range attributes {
+		if _, ok := xmlNamespaceDeclaration(attr); ok {
+			continue
+		}
+		mapped = append(mapped, xmlAttribute{name: qualifyXMLName(attr.Name, scope), value: attr.Value})
+	}
+	return mapped
+}
+
+// mapXMLElement converts an element into a value, recording positions below path
+func mapXMLElement(element *xmlElement, path string, positions map[string]models.Position) interface{} {
+	text := strings.TrimSpace(element.text.String())
+
+	// Leaf elements become plain strings
+	if len(element.attributes) == 0 && len(element.children) == 0 {
+		return text
+	}
+
+	// .NET <appSettings><add key="" value=""/></appSettings> style sections
+	if settings, ok := mapXMLKeyValueSection(element, path, positions); ok {
+		return settings
+	}
+
+	result := createEmptyResult()
+	for _, attr := range element.attributes {
+		key := xmlAttributePrefix + attr.name
+		result[key] = attr.value
+		recordPosition(positions, models.JoinPath(path, key), element.position)
+	}
+
+	for name, children := range groupXMLChildren(element.children) {
+		childPath := models.JoinPath(path, name)
+		recordPosition(positions, childPath, children[0].position)
+
+		// Repeated siblings become arrays
+		if len(children) > 1 {
+			items := make([]interface{}, 0, len(children))
+			for i, child := range children {
+				itemPath := models.IndexPath(childPath, i)
+				recordPosition(positions, itemPath, child.position)
+				items = append(items, mapXMLElement(child, itemPath, positions))
+			}
+			result[name] = items
+			continue
+		}
+
+		result[name] = mapXMLElement(children[0], childPath, positions)
+	}
+
+	if text != "" {
+		result[xmlTextKey] = text
+		recordPosition(positions, models.JoinPath(path, xmlTextKey), element.position)
+	}
+
+	return result
+}
+
+// groupXMLChildren groups child elements by name
+func groupXMLChildren(children []*xmlElement) map[string][]*xmlElement {
+	groups := make(map[string][]*xmlElement)
+	for _, child := range children {
+		groups[child.name] = append(groups[child.name], child)
+	}
+	return groups
+}
+
+// mapXMLKeyValueSection maps sections made of <add key="..." value="..."/> entries
+// (appSettings) or <add name="..." connectionString="..."/> entries (connectionStrings)
+// to plain key/value pairs. <clear/> and <remove/> entries are ignored.
+func mapXMLKeyValueSection(element *xmlElement, path string, positions map[string]models.Position) (map[string]interface{}, bool) {
+	// Guard clause: only attribute-free sections of add/clear/remove entries
+	if len(element.attributes) > 0 || len(element.children) == 0 {
+		return nil, false
+	}
+
+	result := createEmptyResult()
+	entryPositions := createEmptyPositions()
+	for _, child := range element.children {
+		if child.name == "clear" || child.name == "remove" {
+			continue
+		}
+		if child.name != "add" {
+			return nil, false
+		}
+
+		key, value, ok := xmlKeyValueEntry(child)
+		if !ok {
+			return nil, false
+		}
+
+		result[key] = value
+		recordPosition(entryPositions, models.JoinPath(path, key), child.position)
+	}
+
+	// Guard clause: nothing but clear/remove entries
+	if len(result) == 0 {
+		return nil, false
+	}
+
+	for entryPath, position := range entryPositions {
+		positions[entryPath] = position
+	}
+
+	return result, true
+}
+
+// xmlKeyValueEntry extracts the key and value of an <add/> entry
+func xmlKeyValueEntry(element *xmlElement) (string, string, bool) {
+	attributes := make(map[string]string, len(element.attributes))
+	for _, attr := range element.attributes {
+		attributes[attr.name] = attr.value
+	}
+
+	if key, ok := attributes["key"]; ok {
+		value, hasValue := attributes["value"]
+		return key, value, hasValue && len(attributes) == 2
+	}
+
+	if name, ok := attributes["name"]; ok {
+		value, hasValue := attributes["connectionString"]
+		return name, value, hasValue
 	}
 
-	return data, nil
+	return "", "", false
 }
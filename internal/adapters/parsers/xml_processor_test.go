@@ -0,0 +1,69 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestXMLProcessorMapping tests the XML to tree mapping conventions
+func TestXMLProcessorMapping(t *testing.T) {
+	content := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<configuration xmlns:log="http://logging.example.com">
+  <appSettings>
+    <clear/>
+    <add key="FeatureX" value="true"/>
+    <add key="Timeout" value="30"/>
+  </appSettings>
+  <connectionStrings>
+    <add name="Default" connectionString="Server=db" providerName="System.Data.SqlClient"/>
+  </connectionStrings>
+  <servers>
+    <server name="a">10.0.0.1</server>
+    <server name="b">10.0.0.2</server>
+  </servers>
+  <log:level>debug</log:level>
+  <script><![CDATA[if (a < b) run();]]></script>
+  <empty/>
+</configuration>`)
+
+	configData, err := NewXMLProcessor().Process(context.Background(), "web.config.xml", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"appSettings":       map[string]interface{}{"FeatureX": "true", "Timeout": "30"},
+		"connectionStrings": map[string]interface{}{"Default": "Server=db"},
+		"servers": map[string]interface{}{
+			"server": []interface{}{
+				map[string]interface{}{"@name": "a", "#text": "10.0.0.1"},
+				map[string]interface{}{"@name": "b", "#text": "10.0.0.2"},
+			},
+		},
+		"log:level": "debug",
+		"script":    "if (a < b) run();",
+		"empty":     "",
+	}
+
+	if !reflect.DeepEqual(configData.Data, expected) {
+		t.Errorf("Data = %#v, want %#v", configData.Data, expected)
+	}
+
+	if configData.Metadata["root_element"] != "configuration" {
+		t.Errorf("root_element = %v, want configuration", configData.Metadata["root_element"])
+	}
+
+	position, ok := configData.PositionOf("servers.server[1]")
+	if !ok || position.Line != 13 {
+		t.Errorf("PositionOf(servers.server[1]) = %+v, %v, want line 13", position, ok)
+	}
+}
+
+// TestXMLProcessorErrors tests that malformed XML is reported
+func TestXMLProcessorErrors(t *testing.T) {
+	_, err := NewXMLProcessor().Process(context.Background(), "bad.xml", []byte("<a><b></a>"))
+	if err == nil {
+		t.Error("Expected error for mismatched tags")
+	}
+}
@@ -0,0 +1,614 @@
+package parsers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// yamlEditor edits YAML in place: the source is parsed into a node tree to locate keys,
+// and only the lines of the edited entries change, so blank lines, comments, key order,
+// anchors and quoting elsewhere are kept byte for byte. Flow collections ({a: 1}) are
+// rewritten as a whole when keys are added to or removed from them. Content with several
+// documents is rejected, as edits address one document; empty documents are kept.
+type yamlEditor struct {
+	lines  *textLines
+	indent int
+}
+
+// yamlStep is a node reached by a path segment, with the mapping or sequence holding it
+// and the index of its key (mappings) or item (sequences) in the container's content
+type yamlStep struct {
+	container *yaml.Node
+	index     int
+	node      *yaml.Node
+}
+
+// newYAMLEditor creates an editor for YAML content
+func newYAMLEditor(content []byte) (models.ConfigEditor, error) {
+	editor := &yamlEditor{lines: newTextLines(content), indent: detectYAMLIndent(content)}
+	if _, err := editor.root(); err != nil {
+		return nil, err
+	}
+	return editor, nil
+}
+
+// root parses the current content and returns the top-level mapping of its document, or
+// nil when the content has no document with a value
+func (e *yamlEditor) root() (*yaml.Node, error) {
+	var filled []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(e.lines.bytes()))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !isEmptyYAMLDocument(&document) {
+			filled = append(filled, &document)
+		}
+	}
+
+	// Guard clause: an edit cannot tell which document it is meant for
+	if len(filled) > 1 {
+		return nil, fmt.Errorf("content has %d documents; only single-document YAML can be edited", len(filled))
+	}
+	if len(filled) == 0 {
+		return nil, nil
+	}
+
+	root := filled[0].Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top-level value must be a mapping")
+	}
+	return root, nil
+}
+
+// isEmptyYAMLDocument reports whether a document has no value
+func isEmptyYAMLDocument(document *yaml.Node) bool {
+	if len(document.Content) == 0 {
+		return true
+	}
+	node := document.Content[0]
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null" && node.Value == ""
+}
+
+// Set replaces the value at path, keeping its comments, anchor and quoting, or adds it
+func (e *yamlEditor) Set(path string, value interface{}) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	root, err := e.root()
+	if err != nil {
+		return err
+	}
+
+	// Guard clause: empty documents start with the new key
+	if root == nil {
+		nested, err := nestValue(parsed, value)
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		return e.appendRoot(nested)
+	}
+
+	steps, err := walkYAMLPath(root, parsed, path)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	// Missing keys are added below the deepest existing one
+	if len(steps) < len(parsed) {
+		nested, err := nestValue(parsed[len(steps)+1:], value)
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		child, err := yamlValueNode(nested)
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		return e.add(root, steps, parsed[len(steps)], child, path)
+	}
+
+	replacement, err := yamlValueNode(value)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	last := steps[len(steps)-1]
+	if e.replaceScalar(last.node, replacement) {
+		return nil
+	}
+	return e.rewrite(root, steps, len(steps)-1, func() {
+		replaceYAMLNode(last.node, replacement)
+	})
+}
+
+// Delete removes the key or sequence item at path, with the comment lines above it
+func (e *yamlEditor) Delete(path string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	root, err := e.root()
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return pathNotFound(path)
+	}
+
+	steps, err := walkYAMLPath(root, parsed, path)
+	if err != nil || len(steps) < len(parsed) {
+		return pathNotFound(path)
+	}
+
+	last := steps[len(steps)-1]
+	container := last.container
+	remove := func() {
+		container.Content = append(container.Content[:last.index], container.Content[last.index+yamlEntryWidth(container):]...)
+	}
+
+	// Flow collections, entries sharing their line with a parent ("- key: value") and
+	// the last entry of a nested collection are rewritten through their parent
+	start, end := e.entryRange(container, last.index)
+	prefix := e.lines.lines[start][:e.entryColumn(container, last.index)]
+	lastEntry := container != root && len(container.Content) <= yamlEntryWidth(container)
+	if root.Style&yaml.FlowStyle != 0 || flowOwner(steps, len(steps)-1) != -1 || strings.TrimSpace(prefix) != "" || lastEntry {
+		return e.rewrite(root, steps, len(steps)-2, func() {
+			remove()
+			if len(container.Content) == 0 {
+				container.Style |= yaml.FlowStyle
+			}
+		})
+	}
+
+	e.lines.replace(e.commentStart(start, len(prefix)), end)
+	return nil
+}
+
+// Rename renames the key at path, keeping its quoting
+func (e *yamlEditor) Rename(path, newKey string) error {
+	parsed, err := parseEditPath(path)
+	if err != nil {
+		return err
+	}
+
+	root, err := e.root()
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return pathNotFound(path)
+	}
+
+	steps, err := walkYAMLPath(root, parsed, path)
+	if err != nil || len(steps) < len(parsed) {
+		return pathNotFound(path)
+	}
+
+	last := steps[len(steps)-1]
+	if last.container.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot rename %s: sequence items have no key", path)
+	}
+	if yamlKeyIndex(last.container, newKey) != -1 {
+		return fmt.Errorf("cannot rename %s: %s already exists", path, newKey)
+	}
+
+	key := last.container.Content[last.index]
+	replacement := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: newKey}
+	if !e.replaceScalar(key, replacement) {
+		return fmt.Errorf("cannot rename %s: the key spans several lines", path)
+	}
+	return nil
+}
+
+// Bytes returns the edited content, checking that it is still valid YAML
+func (e *yamlEditor) Bytes() ([]byte, error) {
+	if _, err := e.root(); err != nil {
+		return nil, fmt.Errorf("edited content is not valid YAML: %w", err)
+	}
+	return e.lines.bytes(), nil
+}
+
+// add adds a key to the mapping, or an item to the sequence, reached by steps
+func (e *yamlEditor) add(root *yaml.Node, steps []yamlStep, segment models.PathSegment, child *yaml.Node, path string) error {
+	parent := root
+	if len(steps) > 0 {
+		parent = steps[len(steps)-1].node
+	}
+
+	switch {
+	case parent.Kind == yaml.AliasNode:
+		return fmt.Errorf("cannot set %s: its parent is an alias", path)
+	case parent.Kind == yaml.SequenceNode && segment.IsIndex && segment.Index != len(parent.Content):
+		return pathNotFound(path)
+	}
+
+	entry := []*yaml.Node{child}
+	if !segment.IsIndex {
+		entry = []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: segment.Key}, child}
+	}
+
+	// Flow collections are rewritten with the new entry
+	if root.Style&yaml.FlowStyle != 0 || flowOwner(steps, len(steps)) != -1 {
+		return e.rewrite(root, steps, len(steps)-1, func() {
+			parent.Content = append(parent.Content, entry...)
+		})
+	}
+
+	// Block collections get the entry below their last entry, at the same indentation
+	_, end := e.entryRange(parent, len(parent.Content)-len(entry))
+	lines, err := e.render(&yaml.Node{Kind: parent.Kind, Tag: parent.Tag, Content: entry}, strings.Repeat(" ", parent.Column-1))
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	e.lines.insert(end, lines...)
+	return nil
+}
+
+// appendRoot adds the keys of a value to a document without keys
+func (e *yamlEditor) appendRoot(value interface{}) error {
+	node, err := yamlValueNode(value)
+	if err != nil {
+		return err
+	}
+	lines, err := e.render(node, "")
+	if err != nil {
+		return err
+	}
+	e.lines.insert(e.lines.lastContentLine(0, len(e.lines.lines)), lines...)
+	return nil
+}
+
+// rewrite applies modify to the node tree, then rewrites the lines of the entry holding
+// steps[depth] (or its outermost flow collection), leaving every other line untouched
+func (e *yamlEditor) rewrite(root *yaml.Node, steps []yamlStep, depth int, modify func()) error {
+	if owner := flowOwner(steps, depth+1); owner != -1 {
+		depth = owner
+	}
+
+	// Guard clause: top-level flow mappings are rewritten as a whole
+	if root.Style&yaml.FlowStyle != 0 || depth < 0 {
+		start, end := root.Line-1, e.lines.lastContentLine(0, len(e.lines.lines))
+		modify()
+		lines, err := e.render(root, "")
+		if err != nil {
+			return err
+		}
+		e.lines.replace(start, end, lines...)
+		return nil
+	}
+
+	step := steps[depth]
+	start, end := e.entryRange(step.container, step.index)
+	column := e.entryColumn(step.container, step.index)
+	modify()
+
+	entry := []*yaml.Node{step.container.Content[step.index]}
+	if step.container.Kind == yaml.MappingNode {
+		key := *step.container.Content[step.index]
+		key.HeadComment, key.FootComment = "", ""
+		entry = []*yaml.Node{&key, step.container.Content[step.index+1]}
+	}
+	lines, err := e.render(&yaml.Node{Kind: step.container.Kind, Tag: step.container.Tag, Content: entry}, strings.Repeat(" ", column))
+	if err != nil {
+		return err
+	}
+
+	// The first line keeps what precedes the entry, such as the "- " of a sequence item
+	lines[0] = e.lines.lines[start][:column] + strings.TrimLeft(lines[0], " ")
+	e.lines.replace(start, end, lines...)
+	return nil
+}
+
+// flowOwner returns the depth of the outermost flow collection among the first count
+// steps, or -1 when they are all block collections
+func flowOwner(steps []yamlStep, count int) int {
+	for depth := 0; depth < count && depth < len(steps); depth++ {
+		if steps[depth].node.Style&yaml.FlowStyle != 0 {
+			return depth
+		}
+	}
+	return -1
+}
+
+// replaceScalar replaces a single-line scalar in its line, keeping its anchor, the
+// quoting of strings and the text around it. It reports false when the node has to be
+// rewritten instead.
+func (e *yamlEditor) replaceScalar(node, replacement *yaml.Node) bool {
+	if node.Kind != yaml.ScalarNode || replacement.Kind != yaml.ScalarNode || node.Line == 0 {
+		return false
+	}
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 || (node.Tag == "!!null" && node.Value == "") {
+		return false
+	}
+
+	line := e.lines.lines[node.Line-1]
+	start := skipYAMLProperties(line, node.Column-1)
+	end := yamlScalarEnd(line, start, node)
+	if end == -1 {
+		return false
+	}
+
+	updated := *replacement
+	if node.Tag == "!!str" && updated.Tag == "!!str" && node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		updated.Style = node.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+	}
+	text, err := yaml.Marshal(&updated)
+	if err != nil || strings.Count(string(text), "\n") != 1 {
+		return false
+	}
+
+	e.lines.lines[node.Line-1] = line[:start] + strings.TrimSuffix(string(text), "\n") + line[end:]
+	return true
+}
+
+// entryRange returns the lines [start, end) of the key (mappings) or item (sequences) at
+// index in a block collection. The entry continues on the lines indented deeper than it,
+// such as nested keys, block scalars and comments; trailing blank lines are left out.
+func (e *yamlEditor) entryRange(container *yaml.Node, index int) (int, int) {
+	first := container.Content[index]
+	last := yamlLastLine(first)
+	if container.Kind == yaml.MappingNode {
+		last = max(last, yamlLastLine(container.Content[index+1]))
+	}
+
+	start := first.Line - 1
+	if container.Kind == yaml.SequenceNode {
+		start = e.dashLine(container, first)
+	}
+
+	indent := e.entryColumn(container, index)
+	end := last
+	for i := last; i < len(e.lines.lines); i++ {
+		text := e.lines.lines[i]
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if len(leadingWhitespace(text)) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return start, end
+}
+
+// entryColumn returns the 0-based column where an entry starts: its key, or the dash of
+// a sequence item
+func (e *yamlEditor) entryColumn(container *yaml.Node, index int) int {
+	if container.Kind == yaml.SequenceNode {
+		return container.Column - 1
+	}
+	return container.Content[index].Column - 1
+}
+
+// dashLine returns the line index of the "-" introducing a sequence item, which may
+// precede the item (a "-" alone on its line followed by a nested mapping)
+func (e *yamlEditor) dashLine(sequence, item *yaml.Node) int {
+	column := sequence.Column - 1
+	for i := item.Line - 1; i >= 0 && i >= sequence.Line-1; i-- {
+		line := e.lines.lines[i]
+		if len(line) > column && line[column] == '-' && (len(line) == column+1 || line[column+1] == ' ') {
+			return i
+		}
+	}
+	return item.Line - 1
+}
+
+// commentStart returns the first line of the comment lines directly above line that are
+// indented like the entry, which are removed with it
+func (e *yamlEditor) commentStart(line, indent int) int {
+	start := line
+	for start > 0 {
+		text := e.lines.lines[start-1]
+		if !strings.HasPrefix(strings.TrimSpace(text), "#") || len(leadingWhitespace(text)) != indent {
+			break
+		}
+		start--
+	}
+	return start
+}
+
+// render encodes a node as lines prefixed with indent
+func (e *yamlEditor) render(node *yaml.Node, indent string) ([]string, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(e.indent)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return lines, nil
+}
+
+// walkYAMLPath follows a path from the top-level mapping and returns a step for every
+// existing segment; it stops at the first missing one
+func walkYAMLPath(root *yaml.Node, parsed models.Path, path string) ([]yamlStep, error) {
+	steps := make([]yamlStep, 0, len(parsed))
+	node := root
+	for i, segment := range parsed {
+		if node.Kind == yaml.AliasNode {
+			return nil, fmt.Errorf("%s is an alias", parsed[:i])
+		}
+
+		index, err := yamlChildIndex(node, segment)
+		if err != nil {
+			return nil, err
+		}
+		if index == -1 {
+			return steps, nil
+		}
+
+		child := node.Content[index]
+		if node.Kind == yaml.MappingNode {
+			child = node.Content[index+1]
+		}
+		steps = append(steps, yamlStep{container: node, index: index, node: child})
+		node = child
+	}
+	return steps, nil
+}
+
+// yamlChildIndex returns the content index of a key of a mapping or an item of a
+// sequence, or -1 when it is missing
+func yamlChildIndex(node *yaml.Node, segment models.PathSegment) (int, error) {
+	switch {
+	case node.Kind == yaml.MappingNode && !segment.IsIndex:
+		return yamlKeyIndex(node, segment.Key), nil
+	case node.Kind == yaml.SequenceNode && segment.IsIndex:
+		if segment.Index < len(node.Content) {
+			return segment.Index, nil
+		}
+		return -1, nil
+	case node.Kind == yaml.MappingNode:
+		return -1, fmt.Errorf("index [%d] used on a mapping", segment.Index)
+	}
+	return -1, fmt.Errorf("key %q used on a non-mapping value", segment.Key)
+}
+
+// yamlKeyIndex returns the index of a key node in a mapping, or -1
+func yamlKeyIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// yamlEntryWidth returns the number of content nodes per entry: a key and a value for
+// mappings, an item for sequences
+func yamlEntryWidth(container *yaml.Node) int {
+	if container.Kind == yaml.MappingNode {
+		return 2
+	}
+	return 1
+}
+
+// yamlLastLine returns the last line on which a node or one of its children starts
+func yamlLastLine(node *yaml.Node) int {
+	line := node.Line
+	for _, child := range node.Content {
+		line = max(line, yamlLastLine(child))
+	}
+	return line
+}
+
+// skipYAMLProperties skips the anchor (&name) and tag (!tag) preceding a value
+func skipYAMLProperties(line string, start int) int {
+	for start < len(line) && (line[start] == '&' || line[start] == '!') {
+		end := strings.IndexAny(line[start:], " \t")
+		if end == -1 {
+			return len(line)
+		}
+		start += end
+		for start < len(line) && (line[start] == ' ' || line[start] == '\t') {
+			start++
+		}
+	}
+	return start
+}
+
+// yamlScalarEnd returns the end of a scalar starting at start in line, or -1 when the
+// scalar continues on the next lines
+func yamlScalarEnd(line string, start int, node *yaml.Node) int {
+	switch {
+	case start >= len(line):
+		return -1
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+				continue
+			}
+			if line[i] == '"' {
+				return i + 1
+			}
+		}
+		return -1
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+		return -1
+	}
+
+	// Plain scalars are single-line when their source is their value
+	if strings.HasPrefix(line[start:], node.Value) {
+		return start + len(node.Value)
+	}
+	return -1
+}
+
+// yamlValueNode encodes a value as a node
+func yamlValueNode(value interface{}) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(models.NormalizeValue(value)); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// replaceYAMLNode replaces a node in place, keeping its comments, anchor and the quoting
+// of string scalars
+func replaceYAMLNode(node, replacement *yaml.Node) {
+	updated := *replacement
+	updated.HeadComment = node.HeadComment
+	updated.LineComment = node.LineComment
+	updated.FootComment = node.FootComment
+	updated.Anchor = node.Anchor
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && updated.Kind == yaml.ScalarNode && updated.Tag == "!!str" {
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 && !strings.Contains(updated.Value, "\n") {
+			updated.Style = node.Style
+		}
+	}
+	*node = updated
+}
+
+// detectYAMLIndent returns the smallest indentation of the content, or two spaces
+func detectYAMLIndent(content []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if width := len(line) - len(trimmed); width > 0 && (indent == 0 || width < indent) {
+			indent = width
+		}
+	}
+	if indent < 2 {
+		return 2
+	}
+	return indent
+}
@@ -0,0 +1,340 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// Custom (application-specific) tags such as CloudFormation's !Ref, !Sub or !GetAtt are
+// represented as {"tag": "!Ref", "value": <argument>} instead of being dropped.
+const (
+	yamlTagKey      = "tag"
+	yamlTagValueKey = "value"
+	yamlMergeTag    = "!!merge"
+)
+
+// Alias expansion limits, as in yaml.v3: once a document has more than
+// yamlAliasMinExpanded nodes expanded from aliases and more than yamlAliasMinNodes nodes
+// in total, the share of expanded nodes may not exceed yamlAliasRatio (lowered for
+// large documents). This rejects "billion laughs" documents.
+const (
+	yamlAliasMinExpanded = 100
+	yamlAliasMinNodes    = 1000
+	yamlAliasRatio       = 0.99
+	yamlAliasRatioLow    = 400000
+	yamlAliasRatioHigh   = 4000000
+)
+
+// yamlConverter converts a yaml.Node tree into plain maps, slices and scalars, resolving
+// aliases and merge keys, and records key positions and merge overrides on the way
+type yamlConverter struct {
+	positions map[string]models.Position
+	overrides []models.MergeOverride
+	visiting  map[*yaml.Node]bool
+	aliases   *yamlAliases
+}
+
+// yamlAliases caches converted anchors and counts converted nodes for the alias limits.
+// It is shared by the converters of one document.
+type yamlAliases struct {
+	anchors  map[*yaml.Node]yamlAnchor
+	nodes    int
+	expanded int
+	depth    int
+}
+
+// yamlAnchor is a converted anchored node with the positions below it (relative to the
+// anchor) and the number of nodes it was converted from
+type yamlAnchor struct {
+	value     interface{}
+	positions map[string]models.Position
+	nodes     int
+}
+
+// newYAMLConverter creates a converter for one document
+func newYAMLConverter() *yamlConverter {
+	return &yamlConverter{
+		positions: createEmptyPositions(),
+		visiting:  make(map[*yaml.Node]bool),
+		aliases:   &yamlAliases{anchors: make(map[*yaml.Node]yamlAnchor)},
+	}
+}
+
+// count adds converted nodes and fails on excessive aliasing
+func (a *yamlAliases) count(nodes int) error {
+	a.nodes += nodes
+	if a.depth > 0 {
+		a.expanded += nodes
+	}
+
+	ratio := yamlAliasRatio
+	switch {
+	case a.nodes >= yamlAliasRatioHigh:
+		ratio = 0.10
+	case a.nodes > yamlAliasRatioLow:
+		ratio = yamlAliasRatio - 0.89*float64(a.nodes-yamlAliasRatioLow)/float64(yamlAliasRatioHigh-yamlAliasRatioLow)
+	}
+	if a.expanded > yamlAliasMinExpanded && a.nodes > yamlAliasMinNodes && float64(a.expanded)/float64(a.nodes) > ratio {
+		return fmt.Errorf("document contains excessive aliasing")
+	}
+	return nil
+}
+
+// convert converts a node below path. Anchored nodes are cached, so every alias of them
+// reuses the converted value instead of converting the anchor again.
+func (c *yamlConverter) convert(node *yaml.Node, path string) (interface{}, error) {
+	// Guard clause: alias cycles
+	if c.visiting[node] {
+		return nil, fmt.Errorf("line %d: alias cycle detected", node.Line)
+	}
+
+	if node.Kind == yaml.AliasNode {
+		c.aliases.depth++
+		defer func() { c.aliases.depth-- }()
+		if anchor, ok := c.aliases.anchors[node.Alias]; ok {
+			return c.expandAnchor(anchor, path)
+		}
+	}
+
+	nodes := c.aliases.nodes
+	if err := c.aliases.count(1); err != nil {
+		return nil, err
+	}
+
+	c.visiting[node] = true
+	value, err := c.convertNode(node, path)
+	delete(c.visiting, node)
+	if err != nil || node.Anchor == "" {
+		return value, err
+	}
+
+	c.aliases.anchors[node] = yamlAnchor{
+		value:     value,
+		positions: relativePositions(c.positions, path),
+		nodes:     c.aliases.nodes - nodes,
+	}
+	return value, nil
+}
+
+// expandAnchor returns a copy of a converted anchor below path, counting its nodes as
+// expanded from an alias
+func (c *yamlConverter) expandAnchor(anchor yamlAnchor, path string) (interface{}, error) {
+	if err := c.aliases.count(anchor.nodes); err != nil {
+		return nil, err
+	}
+	for suffix, position := range anchor.positions {
+		if path == "" {
+			suffix = strings.TrimPrefix(suffix, ".")
+		}
+		recordPosition(c.positions, path+suffix, position)
+	}
+	return copyYAMLValue(anchor.value), nil
+}
+
+// convertNode converts a node by kind
+func (c *yamlConverter) convertNode(node *yaml.Node, path string) (interface{}, error) {
+	if isCustomYAMLTag(node.Tag) {
+		return c.convertTagged(node, path)
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return c.convert(node.Content[0], path)
+	case yaml.AliasNode:
+		return c.convert(node.Alias, path)
+	case yaml.MappingNode:
+		return c.convertMapping(node, path)
+	case yaml.SequenceNode:
+		items := make([]interface{}, 0, len(node.Content))
+		for i, item := range node.Content {
+			itemPath := models.IndexPath(path, i)
+			recordPosition(c.positions, itemPath, yamlNodePosition(item))
+
+			value, err := c.convert(item, itemPath)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	default:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// convertTagged converts a node carrying a custom tag into a structured value
+func (c *yamlConverter) convertTagged(node *yaml.Node, path string) (interface{}, error) {
+	untagged := *node
+	untagged.Tag = ""
+	if node.Style&yaml.TaggedStyle != 0 {
+		untagged.Style &^= yaml.TaggedStyle
+	}
+
+	valuePath := models.JoinPath(path, yamlTagValueKey)
+	recordPosition(c.positions, valuePath, yamlNodePosition(node))
+
+	value, err := c.convert(&untagged, valuePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{yamlTagKey: node.Tag, yamlTagValueKey: value}, nil
+}
+
+// convertMapping converts a mapping. Explicit keys always win over keys inherited through
+// merge keys; within a merge sequence, earlier mappings win over later ones.
+func (c *yamlConverter) convertMapping(node *yaml.Node, path string) (interface{}, error) {
+	result := createEmptyResult()
+	var merges []*yaml.Node
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		// Merge keys (<<) contribute inherited keys, not a key of their own
+		if key.Tag == yamlMergeTag {
+			merges = append(merges, value)
+			continue
+		}
+
+		keyPath := models.JoinPath(path, key.Value)
+		recordPosition(c.positions, keyPath, yamlNodePosition(key))
+
+		converted, err := c.convert(value, keyPath)
+		if err != nil {
+			return nil, err
+		}
+		result[key.Value] = converted
+	}
+
+	for _, merge := range merges {
+		if err := c.applyMerge(result, merge, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// applyMerge adds the keys of a merged mapping (or sequence of mappings) that are not set yet,
+// recording explicit keys that shadow inherited ones
+func (c *yamlConverter) applyMerge(result map[string]interface{}, merge *yaml.Node, path string) error {
+	sources := []*yaml.Node{merge}
+	if merge.Kind == yaml.SequenceNode {
+		sources = merge.Content
+	}
+
+	inherited := make(map[string]bool)
+	overridden := make(map[string]bool)
+	for _, source := range sources {
+		anchor := source.Value
+		target := source
+		if source.Kind == yaml.AliasNode {
+			target = source.Alias
+		}
+
+		if target.Kind != yaml.MappingNode {
+			return fmt.Errorf("line %d: merge key value must be a mapping or a list of mappings", source.Line)
+		}
+
+		// Inherited positions point at the anchored mapping and are only kept for added keys
+		converter := &yamlConverter{positions: createEmptyPositions(), visiting: c.visiting, aliases: c.aliases}
+		converted, err := converter.convert(source, path)
+		if err != nil {
+			return err
+		}
+
+		for key, value := range converted.(map[string]interface{}) {
+			existing, exists := result[key]
+			if !exists {
+				result[key] = value
+				inherited[key] = true
+				c.copyPositions(converter.positions, models.JoinPath(path, key))
+				continue
+			}
+
+			// Earlier sources take precedence; an explicit key is compared with the
+			// value it would have inherited, i.e. the first source providing it
+			if inherited[key] || overridden[key] {
+				continue
+			}
+			overridden[key] = true
+
+			c.overrides = append(c.overrides, models.MergeOverride{
+				Path:      models.JoinPath(path, key),
+				Anchor:    anchor,
+				Position:  c.positions[models.JoinPath(path, key)],
+				Value:     existing,
+				Inherited: value,
+			})
+		}
+	}
+
+	return nil
+}
+
+// copyPositions copies the positions of keyPath and everything below it
+func (c *yamlConverter) copyPositions(positions map[string]models.Position, keyPath string) {
+	for path, position := range positions {
+		if path == keyPath || strings.HasPrefix(path, keyPath+".") || strings.HasPrefix(path, keyPath+"[") {
+			recordPosition(c.positions, path, position)
+		}
+	}
+}
+
+// relativePositions returns the positions below keyPath, keyed by their path relative to it
+// (".host" or "[0]")
+func relativePositions(positions map[string]models.Position, keyPath string) map[string]models.Position {
+	relative := make(map[string]models.Position)
+	for path, position := range positions {
+		switch {
+		case keyPath == "" && strings.HasPrefix(path, "["):
+			relative[path] = position
+		case keyPath == "":
+			relative["."+path] = position
+		case strings.HasPrefix(path, keyPath+"."), strings.HasPrefix(path, keyPath+"["):
+			relative[strings.TrimPrefix(path, keyPath)] = position
+		}
+	}
+	return relative
+}
+
+// copyYAMLValue copies the maps and lists of a converted value, so that every alias of an
+// anchor can be changed on its own
+func copyYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyYAMLValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyYAMLValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+// isCustomYAMLTag checks if a tag is an application-specific tag (e.g. !Ref)
+func isCustomYAMLTag(tag string) bool {
+	return tag != "" && tag != "!" && !strings.HasPrefix(tag, "!!") && !strings.HasPrefix(tag, "tag:yaml.org,2002:")
+}
+
+// yamlNodePosition returns the position of a node
+func yamlNodePosition(node *yaml.Node) models.Position {
+	return models.Position{Line: node.Line, Column: node.Column}
+}
@@ -1,9 +1,12 @@
 package parsers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 // YAMLProcessor implements FileProcessor for YAML files
 type YAMLProcessor struct {
 	supportedExtensions []string
+	registry            *ParserRegistry
 }
 
 // NewYAMLProcessor creates a new YAML processor
@@ -47,14 +51,38 @@ func (p *YAMLProcessor) Process(ctx context.Context, filename string, content []
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
-	// Parse YAML content
-	data, err := p.parseYAML(content)
+	// Parse every YAML document in the stream
+	documents, err := p.parseYAMLDocuments(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	// Create config data
-	return p.createConfigData(filename, data), nil
+	// Kubernetes ConfigMaps and Secrets carry their configuration in data/stringData
+	for i := range documents {
+		if err := extractKubernetesData(ctx, p.embeddedRegistry(), filename, content, &documents[i]); err != nil {
+			return nil, fmt.Errorf("failed to extract Kubernetes data: %w", err)
+		}
+	}
+
+	// Single document files keep the flat representation
+	if len(documents) <= 1 && len(p.firstDocument(documents).embedded) == 0 {
+		return p.createDocumentConfigData(filename, p.firstDocument(documents)), nil
+	}
+
+	return p.createMultiDocumentConfigData(filename, documents), nil
+}
+
+// withRegistry returns a processor that parses embedded files with registry
+func (p *YAMLProcessor) withRegistry(registry *ParserRegistry) models.FileProcessor {
+	return &YAMLProcessor{supportedExtensions: p.supportedExtensions, registry: registry}
+}
+
+// embeddedRegistry returns the registry that parses embedded files
+func (p *YAMLProcessor) embeddedRegistry() *ParserRegistry {
+	if p.registry == nil {
+		return DefaultParserRegistry()
+	}
+	return p.registry
 }
 
 // GetSupportedExtensions returns supported file extensions
@@ -65,24 +93,140 @@ func (p *YAMLProcessor) GetSupportedExtensions() []string {
 	return extensions
 }
 
-// parseYAML parses YAML content into a map
-func (p *YAMLProcessor) parseYAML(content []byte) (map[string]interface{}, error) {
+// yamlDocument holds a parsed YAML document, the positions of its keys and the keys
+// that override values inherited through merge keys. Kubernetes ConfigMaps and Secrets
+// also carry their kind and the files embedded in their data.
+type yamlDocument struct {
+	root      *yaml.Node
+	index     int
+	name      string
+	kind      string
+	data      map[string]interface{}
+	positions map[string]models.Position
+	overrides []models.MergeOverride
+	embedded  []*models.ConfigData
+	// embeddedErrors lists the embedded files that could not be parsed
+	embeddedErrors []string
+}
+
+// parseYAMLDocuments parses every document of a YAML stream into a map
+func (p *YAMLProcessor) parseYAMLDocuments(content []byte) ([]yamlDocument, error) {
 	// Guard clause: empty content
 	if len(content) == 0 {
-		return make(map[string]interface{}), nil
+		return nil, nil
+	}
+
+	documents := make([]yamlDocument, 0, 1)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	// Documents are numbered by their position in the stream, counting empty ones
+	for index := 1; ; index++ {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("YAML unmarshal failed in document %d: %w", index, err)
+		}
+
+		converter := newYAMLConverter()
+		value, err := converter.convert(&node, "")
+		if err != nil {
+			return nil, fmt.Errorf("YAML unmarshal failed in document %d: %w", index, err)
+		}
+
+		// Skip empty documents (e.g. a trailing ---)
+		if value == nil {
+			continue
+		}
+
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("YAML unmarshal failed in document %d: top-level value must be a mapping", index)
+		}
+
+		documents = append(documents, yamlDocument{
+			root:      &node,
+			index:     index,
+			name:      p.documentName(data),
+			data:      data,
+			positions: converter.positions,
+			overrides: converter.overrides,
+		})
+	}
+
+	return documents, nil
+}
+
+// firstDocument returns the first parsed document or an empty document
+func (p *YAMLProcessor) firstDocument(documents []yamlDocument) yamlDocument {
+	if len(documents) == 0 {
+		return yamlDocument{data: make(map[string]interface{}), positions: createEmptyPositions()}
+	}
+	return documents[0]
+}
+
+// createDocumentConfigData creates ConfigData from a parsed document
+func (p *YAMLProcessor) createDocumentConfigData(filename string, document yamlDocument) *models.ConfigData {
+	configData := p.createConfigData(filename, document.data)
+	configData.Positions = document.positions
+	if len(document.overrides) > 0 {
+		configData.Metadata["merge_overrides"] = document.overrides
+	}
+	if document.kind != "" {
+		configData.Metadata["kubernetes_kind"] = document.kind
+	}
+	if len(document.embeddedErrors) > 0 {
+		configData.Metadata["embedded_errors"] = document.embeddedErrors
 	}
+	return configData
+}
+
+// createMultiDocumentConfigData creates ConfigData holding one addressable entry per document.
+// The top-level Data mirrors the first document so single-document consumers keep working.
+func (p *YAMLProcessor) createMultiDocumentConfigData(filename string, documents []yamlDocument) *models.ConfigData {
+	configData := p.createDocumentConfigData(filename, documents[0])
+	configData.Metadata["document_count"] = len(documents)
+	configData.Documents = make([]*models.ConfigData, 0, len(documents))
+
+	for _, document := range documents {
+		index := document.index
+		name := document.name
 
-	var result map[string]interface{}
-	if err := yaml.Unmarshal(content, &result); err != nil {
-		return nil, fmt.Errorf("YAML unmarshal failed: %w", err)
+		ref := name
+		if ref == "" {
+			ref = strconv.Itoa(index)
+		}
+
+		doc := p.createDocumentConfigData(models.DocumentFilename(filename, ref), document)
+		doc.Metadata["document_index"] = index
+		if name != "" {
+			doc.Metadata["document_name"] = name
+		}
+		configData.Documents = append(configData.Documents, doc)
+
+		// Embedded files share the index of their ConfigMap or Secret
+		for _, embedded := range document.embedded {
+			embedded.Metadata["document_index"] = index
+			configData.Documents = append(configData.Documents, embedded)
+		}
 	}
 
-	// Handle null result
-	if result == nil {
-		return make(map[string]interface{}), nil
+	return configData
+}
+
+// documentName returns kind/name for Kubernetes-style objects, or "" otherwise
+func (p *YAMLProcessor) documentName(document map[string]interface{}) string {
+	kind, _ := document["kind"].(string)
+	metadata, _ := document["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+
+	if kind == "" || name == "" {
+		return ""
 	}
 
-	return result, nil
+	return kind + "/" + name
 }
 
 // createConfigData creates ConfigData from parsed content
@@ -99,10 +243,10 @@ func (p *YAMLProcessor) createConfigData(filename string, data map[string]interf
 // createMetadata creates metadata for the config data
 func (p *YAMLProcessor) createMetadata(filename string, data map[string]interface{}) map[string]interface{} {
 	metadata := map[string]interface{}{
-		"processor":    "yaml",
-		"file_size":    len(data),
-		"key_count":    p.countKeys(data),
-		"has_nested":   p.hasNestedData(data),
+		"processor":  "yaml",
+		"file_size":  len(data),
+		"key_count":  p.countKeys(data),
+		"has_nested": p.hasNestedData(data),
 	}
 
 	// Add file-specific metadata
@@ -0,0 +1,256 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestYAMLProcessorMultiDocument tests that every document of a YAML stream is parsed
+func TestYAMLProcessorMultiDocument(t *testing.T) {
+	processor := NewYAMLProcessor()
+	content := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+---
+spring:
+  profiles: prod
+---
+`)
+
+	configData, err := processor.Process(context.Background(), "deploy.yaml", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	t.Run("should expose every document", func(t *testing.T) {
+		if len(configData.Documents) != 3 {
+			t.Fatalf("Documents = %d, want 3", len(configData.Documents))
+		}
+		if configData.Data["kind"] != "Deployment" {
+			t.Errorf("Data should mirror the first document, got %v", configData.Data)
+		}
+	})
+
+	t.Run("should address documents by kind/name and index", func(t *testing.T) {
+		tests := []struct {
+			ref      string
+			filename string
+		}{
+			{"Service/api", "deploy.yaml#Service/api"},
+			{"2", "deploy.yaml#Service/api"},
+			{"3", "deploy.yaml#3"},
+		}
+
+		for _, tt := range tests {
+			doc, ok := configData.Document(tt.ref)
+			if !ok {
+				t.Errorf("Document(%q) not found", tt.ref)
+				continue
+			}
+			if doc.Filename != tt.filename {
+				t.Errorf("Document(%q).Filename = %q, want %q", tt.ref, doc.Filename, tt.filename)
+			}
+		}
+
+		if _, ok := configData.Document("4"); ok {
+			t.Error("Expected empty trailing document to be skipped")
+		}
+	})
+}
+
+// TestYAMLProcessorEmptyDocuments tests that documents keep their position in the stream
+// when empty documents are skipped
+func TestYAMLProcessorEmptyDocuments(t *testing.T) {
+	content := []byte("a: 1\n---\n---\n# empty\n---\nc: 3\n")
+
+	configData, err := NewYAMLProcessor().Process(context.Background(), "stream.yaml", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if len(configData.Documents) != 2 {
+		t.Fatalf("Documents = %d, want 2", len(configData.Documents))
+	}
+	doc, ok := configData.Document("4")
+	if !ok || doc.Filename != "stream.yaml#4" || doc.Data["c"] != 3 {
+		t.Errorf("Document(4) = %v, %v, want the fourth document of the stream", doc, ok)
+	}
+	if _, ok := configData.Document("2"); ok {
+		t.Error("Expected the empty second document not to be addressable")
+	}
+}
+
+// TestYAMLProcessorSingleDocument tests that single documents keep the flat representation
+func TestYAMLProcessorSingleDocument(t *testing.T) {
+	processor := NewYAMLProcessor()
+
+	configData, err := processor.Process(context.Background(), "config.yaml", []byte("---\napp:\n  name: test\n"))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if configData.Documents != nil {
+		t.Errorf("Expected no documents for single-document file, got %d", len(configData.Documents))
+	}
+	if _, ok := configData.Data["app"]; !ok {
+		t.Errorf("Expected app key, got %v", configData.Data)
+	}
+}
+
+// TestYAMLProcessorAnchorsAndMergeKeys tests alias resolution, merge keys and override tracking
+func TestYAMLProcessorAnchorsAndMergeKeys(t *testing.T) {
+	content := []byte(`defaults: &defaults
+  adapter: postgres
+  pool: 5
+  options: &options
+    ssl: true
+    timeout: 30
+extras: &extras
+  pool: 50
+  region: eu
+development:
+  <<: [*defaults, *extras]
+  database: dev
+  pool: 5
+production:
+  <<: *defaults
+  pool: 20
+  options:
+    ssl: false
+backup_options: *options
+`)
+
+	configData, err := NewYAMLProcessor().Process(context.Background(), "database.yml", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	development := configData.Data["development"].(map[string]interface{})
+	expectedDevelopment := map[string]interface{}{
+		"adapter":  "postgres",
+		"pool":     5,
+		"region":   "eu",
+		"database": "dev",
+		"options":  map[string]interface{}{"ssl": true, "timeout": 30},
+	}
+	if !reflect.DeepEqual(development, expectedDevelopment) {
+		t.Errorf("development = %#v, want %#v", development, expectedDevelopment)
+	}
+	if !reflect.DeepEqual(configData.Data["backup_options"], map[string]interface{}{"ssl": true, "timeout": 30}) {
+		t.Errorf("backup_options = %#v, want the aliased options", configData.Data["backup_options"])
+	}
+
+	if position, ok := configData.PositionOf("production.adapter"); !ok || position.Line != 2 {
+		t.Errorf("PositionOf(production.adapter) = %v, %v, want the anchor line 2", position, ok)
+	}
+	if position, ok := configData.PositionOf("production.pool"); !ok || position.Line != 16 {
+		t.Errorf("PositionOf(production.pool) = %v, %v, want line 16", position, ok)
+	}
+	if _, ok := configData.PositionOf("production.options.timeout"); ok {
+		t.Error("PositionOf(production.options.timeout) should not exist after the override")
+	}
+
+	overrides, _ := configData.Metadata["merge_overrides"].([]models.MergeOverride)
+	paths := make(map[string]string)
+	for _, override := range overrides {
+		paths[override.Path] = override.Anchor
+	}
+	expectedPaths := map[string]string{"development.pool": "defaults", "production.pool": "defaults", "production.options": "defaults"}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("merge overrides = %v, want %v", paths, expectedPaths)
+	}
+}
+
+// TestYAMLProcessorAliasPositions tests that aliases of cached anchors record positions
+// below the alias path, including merges at the top level
+func TestYAMLProcessorAliasPositions(t *testing.T) {
+	content := []byte(`base: &base
+  host: localhost
+  pool:
+    max: 5
+copy: *base
+<<: *base
+`)
+
+	configData, err := NewYAMLProcessor().Process(context.Background(), "app.yaml", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	for _, path := range []string{"copy.host", "copy.pool.max", "host", "pool.max"} {
+		if _, ok := configData.PositionOf(path); !ok {
+			t.Errorf("PositionOf(%s) is missing", path)
+		}
+	}
+
+	// Aliases are copies: changing one leaves the anchor unchanged
+	if err := configData.Set("copy.pool.max", 10); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := configData.Get("base.pool.max"); value != 5 {
+		t.Errorf("base.pool.max = %v after changing the alias, want 5", value)
+	}
+}
+
+// TestYAMLProcessorExcessiveAliasing tests that "billion laughs" documents are rejected
+func TestYAMLProcessorExcessiveAliasing(t *testing.T) {
+	lines := []string{`a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]`}
+	previous := "a"
+	for _, name := range []string{"b", "c", "d", "e", "f", "g", "h", "i"} {
+		aliases := strings.TrimSuffix(strings.Repeat("*"+previous+",", 9), ",")
+		lines = append(lines, fmt.Sprintf("%s: &%s [%s]", name, name, aliases))
+		previous = name
+	}
+	content := []byte(strings.Join(lines, "\n") + "\n")
+
+	_, err := NewYAMLProcessor().Process(context.Background(), "bomb.yaml", content)
+	if err == nil || !strings.Contains(err.Error(), "excessive aliasing") {
+		t.Errorf("Process() error = %v, want excessive aliasing", err)
+	}
+}
+
+// TestYAMLProcessorCustomTags tests that CloudFormation style tags become structured values
+func TestYAMLProcessorCustomTags(t *testing.T) {
+	content := []byte(`Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-logs"
+      Arn: !GetAtt [Role, Arn]
+      Owner: !Ref Owner
+      Literal: !!str 123
+`)
+
+	configData, err := NewYAMLProcessor().Process(context.Background(), "template.yaml", content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	properties := configData.Data["Resources"].(map[string]interface{})["Bucket"].(map[string]interface{})["Properties"]
+	expected := map[string]interface{}{
+		"BucketName": map[string]interface{}{"tag": "!Sub", "value": "${AWS::StackName}-logs"},
+		"Arn":        map[string]interface{}{"tag": "!GetAtt", "value": []interface{}{"Role", "Arn"}},
+		"Owner":      map[string]interface{}{"tag": "!Ref", "value": "Owner"},
+		"Literal":    "123",
+	}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("Properties = %#v, want %#v", properties, expected)
+	}
+
+	if position, ok := configData.PositionOf("Resources.Bucket.Properties.Arn.value[1]"); !ok || position.Line != 6 {
+		t.Errorf("PositionOf(Arn.value[1]) = %v, %v, want line 6", position, ok)
+	}
+}
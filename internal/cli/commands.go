@@ -13,5 +13,11 @@ func RegisterCommands(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(NewValidateCommand())
 	rootCmd.AddCommand(NewAuditCommand())
 	rootCmd.AddCommand(NewInitCommand())
+	rootCmd.AddCommand(NewFixCommand())
+	rootCmd.AddCommand(NewDiffCommand())
+	rootCmd.AddCommand(NewConfigCommand())
+	rootCmd.AddCommand(NewTemplateCommand())
+	rootCmd.AddCommand(NewConvertCommand())
+	rootCmd.AddCommand(NewGetCommand())
 	rootCmd.AddCommand(NewVersionCommand())
-}
\ No newline at end of file
+}
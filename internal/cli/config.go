@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/adapters/loaders"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// NewConfigCommand creates the config command
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check and inspect the praetorian.yaml configuration",
+		Long: `Check and inspect the praetorian.yaml configuration itself.
+
+Examples:
+  praetorian config validate                 # Report unknown keys, bad globs, invalid regexes and missing files
+  praetorian config show --resolved          # Print the configuration with defaults, presets and extends applied`,
+	}
+
+	cmd.AddCommand(NewConfigValidateCommand())
+	cmd.AddCommand(NewConfigShowCommand())
+	return cmd
+}
+
+// NewConfigValidateCommand creates the config validate command
+func NewConfigValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the praetorian.yaml configuration",
+		Long: `Validate the praetorian.yaml configuration and the files it extends.
+
+Reports syntax errors, unknown keys (with suggestions for typos such as ignore_key),
+values of the wrong type, unknown presets, missing extended files, invalid glob patterns,
+invalid regexes, invalid key paths and environments pointing at files that do not exist.
+
+Exits with code 1 when the configuration has errors.`,
+		RunE:          runConfigValidate,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path")
+
+	return cmd
+}
+
+// NewConfigShowCommand creates the config show command
+func NewConfigShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the praetorian.yaml configuration",
+		Long: `Print the praetorian.yaml configuration.
+
+With --resolved the final configuration is printed: the defaults, then the presets and
+extended files, then the file itself. Mappings are merged key by key, while lists and
+values replace the ones they override.`,
+		RunE: runConfigShow,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path")
+	cmd.Flags().Bool("resolved", false, "Apply defaults, presets and extends")
+	cmd.Flags().StringP("output", "o", "yaml", "Output format (yaml, json)")
+
+	return cmd
+}
+
+// runConfigValidate executes the config validate command
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return fmt.Errorf("command cannot be nil")
+	}
+
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	result := loaders.ValidateConfigFile(configPath)
+	displayConfigValidation(cmd.OutOrStdout(), configPath, result)
+	if !result.Success {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+// displayConfigValidation displays the issues of a configuration file
+func displayConfigValidation(out io.Writer, configPath string, result models.ValidationResult) {
+	for _, issue := range result.Errors {
+		fmt.Fprintf(out, "%s error %s %s\n", issueLocation(issue.File, issue.Line, issue.Column), issue.Code, issue.Message)
+	}
+	for _, issue := range result.Warnings {
+		fmt.Fprintf(out, "%s warning %s %s\n", issueLocation(issue.File, issue.Line, issue.Column), issue.Code, issue.Message)
+	}
+
+	if result.Success {
+		fmt.Fprintf(out, "✅ %s is valid (%d warnings)\n", configPath, len(result.Warnings))
+		return
+	}
+	fmt.Fprintf(out, "❌ %s has %d errors and %d warnings\n", configPath, len(result.Errors), len(result.Warnings))
+}
+
+// issueLocation formats the position of an issue as file:line:column
+func issueLocation(file string, line, column int) string {
+	if line == 0 {
+		return file + ":"
+	}
+	if column == 0 {
+		return fmt.Sprintf("%s:%d:", file, line)
+	}
+	return fmt.Sprintf("%s:%d:%d:", file, line, column)
+}
+
+// ConfigShowFlags represents config show command flags
+type ConfigShowFlags struct {
+	ConfigPath   string
+	Resolved     bool
+	OutputFormat string
+}
+
+// runConfigShow executes the config show command
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return fmt.Errorf("command cannot be nil")
+	}
+
+	// Extract and validate flags
+	flags, err := extractConfigShowFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to extract flags: %w", err)
+	}
+
+	return executeConfigShow(flags, cmd.OutOrStdout())
+}
+
+// extractConfigShowFlags extracts and validates flags from command
+func extractConfigShowFlags(cmd *cobra.Command) (*ConfigShowFlags, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	resolved, err := cmd.Flags().GetBool("resolved")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resolved flag: %w", err)
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output flag: %w", err)
+	}
+
+	// Guard clause: validate output format
+	if outputFormat != "yaml" && outputFormat != "json" {
+		return nil, fmt.Errorf("invalid output format: %s, must be one of: [yaml json]", outputFormat)
+	}
+
+	return &ConfigShowFlags{
+		ConfigPath:   configPath,
+		Resolved:     resolved,
+		OutputFormat: outputFormat,
+	}, nil
+}
+
+// executeConfigShow prints the configuration file, or the resolved configuration
+func executeConfigShow(flags *ConfigShowFlags, out io.Writer) error {
+	var content []byte
+	if flags.Resolved {
+		config, err := loaders.LoadConfig(flags.ConfigPath)
+		if err != nil {
+			return err
+		}
+		if content, err = yaml.Marshal(config); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+	} else {
+		raw, err := os.ReadFile(flags.ConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to read config %s: %w", flags.ConfigPath, err)
+		}
+		content = raw
+	}
+
+	// Guard clause: YAML is printed as is
+	if flags.OutputFormat == "yaml" {
+		_, err := out.Write(content)
+		return err
+	}
+
+	// JSON goes through the YAML tree, so durations read "30s" as in the YAML file
+	var tree interface{}
+	if err := yaml.Unmarshal(content, &tree); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", flags.ConfigPath, err)
+	}
+	encoded, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	fmt.Fprintln(out, string(encoded))
+	return nil
+}
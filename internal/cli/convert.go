@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/adapters/parsers"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+	"github.com/syntropysoft/praetorian-go/internal/services/conversion"
+)
+
+// NewConvertCommand creates the convert command
+func NewConvertCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert <file> [output]",
+		Short: "Convert a configuration file to another format",
+		Long: fmt.Sprintf(`Convert a configuration file to another format (%s).
+
+Dotted keys (database.host) are nested, and string values of env, properties and ini
+files are converted to numbers, booleans and nulls when the target format has types and
+the value reads back as the same text. The converted file is checked against its source,
+so that praetorian diff finds no differences.
+
+Lossy conversions are reported as warnings: dropped comments, duplicate keys, non-string
+keys and values the target format cannot represent.
+
+The result is printed, or written to output.
+
+Examples:
+  praetorian convert application.properties --to yaml
+  praetorian convert application.properties --to yaml application.yaml
+  praetorian convert settings.ini --to toml settings.toml
+  praetorian convert .env --to json --nest=false`, strings.Join(parsers.SerializableFormats(), ", ")),
+		Args:          cobra.RangeArgs(1, 2),
+		RunE:          runConvert,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path (used for format rules when present)")
+	cmd.Flags().StringP("to", "t", "", "Target format")
+	cmd.Flags().Bool("nest", true, "Nest dotted keys")
+	cmd.Flags().Bool("infer", true, "Convert string values to typed values")
+
+	return cmd
+}
+
+// runConvert executes the convert command
+func runConvert(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return fmt.Errorf("command cannot be nil")
+	}
+
+	// Extract and validate flags
+	flags, err := extractConvertFlags(cmd, args)
+	if err != nil {
+		return fmt.Errorf("failed to extract flags: %w", err)
+	}
+
+	// Execute conversion
+	return executeConvert(flags, cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// ConvertFlags represents convert command flags
+type ConvertFlags struct {
+	ConfigPath     string
+	ConfigRequired bool
+	Source         string
+	Output         string
+	To             string
+	Nest           bool
+	Infer          bool
+}
+
+// extractConvertFlags extracts and validates flags from command
+func extractConvertFlags(cmd *cobra.Command, args []string) (*ConvertFlags, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get to flag: %w", err)
+	}
+
+	nest, err := cmd.Flags().GetBool("nest")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nest flag: %w", err)
+	}
+
+	infer, err := cmd.Flags().GetBool("infer")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infer flag: %w", err)
+	}
+
+	// Guard clause: the target format is required
+	if to == "" {
+		return nil, fmt.Errorf("--to is required (%s)", strings.Join(parsers.SerializableFormats(), ", "))
+	}
+
+	flags := &ConvertFlags{
+		ConfigPath:     configPath,
+		ConfigRequired: cmd.Flags().Changed("config"),
+		Source:         args[0],
+		To:             to,
+		Nest:           nest,
+		Infer:          infer,
+	}
+	if len(args) > 1 {
+		flags.Output = args[1]
+	}
+
+	// Guard clause: never overwrite the source
+	if flags.Output != "" && filepath.Clean(flags.Output) == filepath.Clean(flags.Source) {
+		return nil, fmt.Errorf("output %s is the file being converted", flags.Output)
+	}
+
+	return flags, nil
+}
+
+// executeConvert converts the file and prints or writes the result. Warnings go to errOut
+// so that printed output can be redirected to a file.
+func executeConvert(flags *ConvertFlags, out, errOut io.Writer) error {
+	// Guard clause: validate flags
+	if flags == nil {
+		return fmt.Errorf("flags cannot be nil")
+	}
+
+	ws, err := loadOptionalWorkspace(flags.ConfigPath, flags.ConfigRequired)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(flags.Source)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", flags.Source, err)
+	}
+	source, err := ws.parse(flags.Source)
+	if err != nil {
+		return err
+	}
+
+	// Guard clause: one document per output file
+	if source.IsMultiDocument() {
+		return fmt.Errorf("%s has %d documents; only single-document files can be converted", flags.Source, len(source.Documents))
+	}
+
+	options := conversion.Options{Nest: flags.Nest}
+	if flags.Infer && parsers.StoresTypes(flags.To) {
+		infer, err := convertInference(ws.config, source.Format)
+		if err != nil {
+			return err
+		}
+		options.Infer = infer
+	}
+
+	result := conversion.Convert(source, options)
+	converted, err := parsers.Serialize(flags.To, result.Data)
+	if err != nil {
+		return err
+	}
+
+	warnings := parsers.SourceLosses(source, content)
+	warnings = append(warnings, result.Warnings...)
+	warnings = append(warnings, parsers.TargetLosses(flags.To, result.Data)...)
+	mismatches, err := roundTripMismatches(source, flags.To, converted)
+	if err != nil {
+		return err
+	}
+	warnings = append(warnings, mismatches...)
+	for _, warning := range warnings {
+		fmt.Fprintf(errOut, "⚠️  %s\n", warning)
+	}
+
+	// Guard clause: print the result
+	if flags.Output == "" {
+		_, err := out.Write(converted)
+		return err
+	}
+
+	if err := os.WriteFile(flags.Output, converted, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", flags.Output, err)
+	}
+	fmt.Fprintf(out, "✅ Converted %s (%s) to %s (%s) with %d warnings\n", flags.Source, source.Format, flags.Output, flags.To, len(warnings))
+	return nil
+}
+
+// convertInference returns the type inference for the values of a source format, or nil
+// when the format already has typed values
+func convertInference(config *models.PraetorianConfig, format string) (func(string) interface{}, error) {
+	inferenceConfig := models.TypeInferenceConfig{}
+	if config.Inference != nil {
+		inferenceConfig = *config.Inference
+	}
+
+	inference, err := parsers.NewTypeInference(inferenceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !inference.Applies(format) {
+		return nil, nil
+	}
+	return inference.Infer, nil
+}
+
+// roundTripMismatches parses the converted content and lists the keys whose value differs
+// from the source, as praetorian diff would report them
+func roundTripMismatches(source *models.ConfigData, format string, converted []byte) ([]string, error) {
+	processor, err := parsers.NewProcessorForFormat(format, nil)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := models.ProcessNormalized(context.Background(), processor, "converted", converted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read back the converted %s: %w", format, err)
+	}
+
+	// Variable names are compared as diff --ignore-case --separators _ would
+	options := comparison.Options{}
+	if parsed.Format == "env" {
+		options.Keys = comparison.KeyOptions{IgnoreCase: true, Separators: "_"}
+	}
+
+	var mismatches []string
+	for _, entry := range comparison.Compare([]*models.ConfigData{source, parsed}, options).Differences() {
+		switch entry.Status {
+		case comparison.StatusRemoved:
+			mismatches = append(mismatches, fmt.Sprintf("%s is missing after conversion", entry.Key))
+		case comparison.StatusAdded:
+			mismatches = append(mismatches, fmt.Sprintf("%s appears after conversion", entry.Key))
+		default:
+			mismatches = append(mismatches, fmt.Sprintf("%s reads back as %q instead of %q", entry.Key, entry.Values[1].Text(), entry.Values[0].Text()))
+		}
+	}
+	return mismatches, nil
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+)
+
+// diffValueWidth is the maximum width of a value in the diff table
+const diffValueWidth = 40
+
+// diffSymbols marks the status of a key in the diff table
+var diffSymbols = map[comparison.Status]string{
+	comparison.StatusAdded:   "+",
+	comparison.StatusRemoved: "-",
+	comparison.StatusChanged: "~",
+}
+
+// NewDiffCommand creates the diff command
+func NewDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <file> <file> [files...]",
+		Short: "Compare the keys and values of configuration files",
+		Long: `Compare the keys and values of configuration files, in any supported format.
+
+Keys are compared against the first file: added keys are missing from it, removed keys
+are missing from another file and changed keys have different values. Dotted keys match
+nested keys, and the key flags match naming styles such as DATABASE_HOST and database.host.
+
+Exit codes: 0 when the files match, 1 when they differ, 2 on errors.
+
+Examples:
+  praetorian diff config/dev.yaml config/prod.json
+  praetorian diff app.yaml .env --ignore-case --separators _      # DATABASE_HOST = database.host
+  praetorian diff app.yaml .env -i --separators _ --strip-prefix APP_
+  praetorian diff dev.yaml staging.yaml prod.yaml --structure-only
+  praetorian diff a.yaml b.yaml --output json`,
+		RunE:          runDiff,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path (used for format rules when present)")
+	cmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	cmd.Flags().BoolP("structure-only", "s", false, "Compare which keys exist, ignoring values")
+	cmd.Flags().BoolP("ignore-case", "i", false, "Compare keys case-insensitively")
+	cmd.Flags().String("separators", "", "Characters that also separate keys (e.g. _ for environment variables)")
+	cmd.Flags().StringSlice("strip-prefix", nil, "Prefixes removed from keys before comparing (e.g. APP_)")
+
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &ExitError{Code: 2, Err: err}
+	})
+
+	return cmd
+}
+
+// runDiff executes the diff command
+func runDiff(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("command cannot be nil")}
+	}
+
+	// Extract and validate flags
+	flags, err := extractDiffFlags(cmd, args)
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to extract flags: %w", err)}
+	}
+
+	// Execute comparison
+	differences, err := executeDiff(flags, cmd.OutOrStdout())
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+	if differences > 0 {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+// DiffFlags represents diff command flags
+type DiffFlags struct {
+	ConfigPath     string
+	ConfigRequired bool
+	OutputFormat   string
+	Files          []string
+	Options        comparison.Options
+}
+
+// extractDiffFlags extracts and validates flags from command
+func extractDiffFlags(cmd *cobra.Command, args []string) (*DiffFlags, error) {
+	// Guard clause: at least two files are compared
+	if len(args) < 2 {
+		return nil, fmt.Errorf("diff needs at least two files, got %d", len(args))
+	}
+
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output flag: %w", err)
+	}
+
+	structureOnly, err := cmd.Flags().GetBool("structure-only")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get structure-only flag: %w", err)
+	}
+
+	ignoreCase, err := cmd.Flags().GetBool("ignore-case")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ignore-case flag: %w", err)
+	}
+
+	separators, err := cmd.Flags().GetString("separators")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get separators flag: %w", err)
+	}
+
+	stripPrefixes, err := cmd.Flags().GetStringSlice("strip-prefix")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strip-prefix flag: %w", err)
+	}
+
+	// Guard clause: validate output format
+	if outputFormat != "text" && outputFormat != "json" {
+		return nil, fmt.Errorf("invalid output format: %s, must be one of: [text json]", outputFormat)
+	}
+
+	return &DiffFlags{
+		ConfigPath:     configPath,
+		ConfigRequired: cmd.Flags().Changed("config"),
+		OutputFormat:   outputFormat,
+		Files:          args,
+		Options: comparison.Options{
+			StructureOnly: structureOnly,
+			Keys: comparison.KeyOptions{
+				IgnoreCase:    ignoreCase,
+				Separators:    separators,
+				StripPrefixes: stripPrefixes,
+			},
+		},
+	}, nil
+}
+
+// executeDiff compares the files and prints their differences, returning how many keys differ
+func executeDiff(flags *DiffFlags, out io.Writer) (int, error) {
+	// Guard clause: validate flags
+	if flags == nil {
+		return 0, fmt.Errorf("flags cannot be nil")
+	}
+
+	ws, err := loadOptionalWorkspace(flags.ConfigPath, flags.ConfigRequired)
+	if err != nil {
+		return 0, err
+	}
+
+	files := make([]*models.ConfigData, 0, len(flags.Files))
+	for _, filename := range flags.Files {
+		data, err := ws.parse(filename)
+		if err != nil {
+			return 0, err
+		}
+		files = append(files, data)
+	}
+
+	result := comparison.Compare(files, flags.Options)
+	differences := result.Differences()
+
+	if flags.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return len(differences), encoder.Encode(comparison.Result{Files: result.Files, Entries: differences})
+	}
+
+	displayDiffTable(out, result.Files, differences, flags.Options.StructureOnly)
+	displayDiffSummary(out, result)
+	return len(differences), nil
+}
+
+// displayDiffTable prints the differing keys side by side
+func displayDiffTable(out io.Writer, files []string, entries []comparison.Entry, structureOnly bool) {
+	// Guard clause: nothing to show
+	if len(entries) == 0 {
+		return
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, " \tKEY\t%s\n", strings.Join(files, "\t"))
+	for _, entry := range entries {
+		cells := make([]string, len(entry.Values))
+		for i, value := range entry.Values {
+			cells[i] = diffCell(value, structureOnly)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", diffSymbols[entry.Status], entry.Key, strings.Join(cells, "\t"))
+	}
+	_ = writer.Flush()
+	fmt.Fprintln(out)
+}
+
+// diffCell formats the value of a key in one file
+func diffCell(value comparison.Value, structureOnly bool) string {
+	switch {
+	case !value.Present && structureOnly:
+		return "✗"
+	case !value.Present:
+		return "(missing)"
+	case structureOnly:
+		return "✓"
+	}
+
+	text := strings.NewReplacer("\n", `\n`, "\t", `\t`).Replace(value.Text())
+	if runes := []rune(text); len(runes) > diffValueWidth {
+		text = string(runes[:diffValueWidth-1]) + "…"
+	}
+	if text == "" {
+		return `""`
+	}
+	return text
+}
+
+// displayDiffSummary prints the number of differences by status
+func displayDiffSummary(out io.Writer, result comparison.Result) {
+	added := result.Count(comparison.StatusAdded)
+	removed := result.Count(comparison.StatusRemoved)
+	changed := result.Count(comparison.StatusChanged)
+
+	if added+removed+changed == 0 {
+		fmt.Fprintf(out, "✅ No differences in %d keys\n", len(result.Entries))
+		return
+	}
+	fmt.Fprintf(out, "❌ %d keys differ: %d added, %d removed, %d changed\n", added+removed+changed, added, removed, changed)
+}
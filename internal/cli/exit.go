@@ -0,0 +1,23 @@
+package cli
+
+import "fmt"
+
+// ExitError makes the CLI exit with a specific code. Without Err the CLI exits silently,
+// e.g. when a command reports findings through its exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error returns the error message
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/services/remediation"
+)
+
+// NewFixCommand creates the fix command
+func NewFixCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fix [files...]",
+		Short: "Apply the fixes declared by validation rules",
+		Long: `Apply the fixes declared by validation rules.
+
+This command validates the configured files (or the given files) and applies the fixes
+the rules declare: adding missing required keys with a placeholder, removing forbidden
+keys, renaming deprecated keys and normalising booleans ("yes" becomes true). Files are
+edited in place, so only the fixed lines change. Fixes that cannot be applied safely are reported as manual.
+
+Examples:
+  praetorian fix --dry-run              # Show the changes as unified diffs
+  praetorian fix                        # Show the changes and ask for confirmation
+  praetorian fix --yes                  # Apply without asking
+  praetorian fix config/prod.yaml       # Fix specific files`,
+		RunE: runFix,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path")
+	cmd.Flags().Bool("dry-run", false, "Print the changes as unified diffs without writing files")
+	cmd.Flags().BoolP("yes", "y", false, "Apply fixes without asking for confirmation")
+
+	return cmd
+}
+
+// runFix executes the fix command
+func runFix(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return fmt.Errorf("command cannot be nil")
+	}
+
+	// Extract and validate flags
+	flags, err := extractFixFlags(cmd, args)
+	if err != nil {
+		return fmt.Errorf("failed to extract flags: %w", err)
+	}
+
+	// Execute fixes
+	return executeFix(flags, cmd.InOrStdin(), cmd.OutOrStdout())
+}
+
+// FixFlags represents fix command flags
+type FixFlags struct {
+	ConfigPath string
+	DryRun     bool
+	Yes        bool
+	Files      []string
+}
+
+// extractFixFlags extracts and validates flags from command
+func extractFixFlags(cmd *cobra.Command, args []string) (*FixFlags, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	// Guard clause: validate config path
+	if err := ValidateConfigPath(configPath); err != nil {
+		return nil, fmt.Errorf("invalid config path: %w", err)
+	}
+
+	return &FixFlags{
+		ConfigPath: configPath,
+		DryRun:     dryRun,
+		Yes:        yes,
+		Files:      args,
+	}, nil
+}
+
+// executeFix validates the files, then shows and applies the fixes of their issues
+func executeFix(flags *FixFlags, in io.Reader, out io.Writer) error {
+	// Guard clause: validate flags
+	if flags == nil {
+		return fmt.Errorf("flags cannot be nil")
+	}
+
+	ws, err := loadWorkspace(flags.ConfigPath)
+	if err != nil {
+		return err
+	}
+	files, err := ws.files(flags.Files)
+	if err != nil {
+		return err
+	}
+	configs, err := ws.parseFiles(files)
+	if err != nil {
+		return err
+	}
+
+	fixes := remediation.CollectFixes(ws.validate(configs))
+	plan := remediation.NewRemediator(ws.registry).Plan(fixes)
+
+	applied, changed := 0, 0
+	for _, result := range plan {
+		if !result.Changed() {
+			continue
+		}
+		applied += len(result.Applied)
+		changed++
+		fmt.Fprint(out, remediation.UnifiedDiff("a/"+result.File, "b/"+result.File, result.Original, result.Fixed))
+	}
+
+	// Guard clause: nothing to apply
+	if changed == 0 {
+		fmt.Fprintf(out, "✅ No automatic fixes to apply\n")
+		displayManualFixes(out, plan)
+		return nil
+	}
+
+	// Guard clause: dry runs only show the changes
+	if flags.DryRun {
+		fmt.Fprintf(out, "🔍 Dry run: %d fixes in %d files not applied\n", applied, changed)
+		displayManualFixes(out, plan)
+		return nil
+	}
+
+	if !flags.Yes && !confirm(in, out, fmt.Sprintf("Apply %d fixes to %d files?", applied, changed)) {
+		fmt.Fprintf(out, "Aborted, no files changed\n")
+		return nil
+	}
+
+	for _, result := range plan {
+		if !result.Changed() {
+			continue
+		}
+		if err := writeFixedFile(result.File, result.Fixed); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "✅ Applied %d fixes to %d files\n", applied, changed)
+	displayManualFixes(out, plan)
+	return nil
+}
+
+// confirm asks a yes/no question, defaulting to no
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// writeFixedFile replaces the content of a file, keeping its permissions
+func writeFixedFile(filename string, content []byte) error {
+	stat, err := os.Stat(filename)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", filename, err)
+	}
+	if err := os.WriteFile(filename, content, stat.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return nil
+}
+
+// displayManualFixes lists the fixes that have to be made by hand
+func displayManualFixes(out io.Writer, plan []remediation.FileResult) {
+	var lines []string
+	for _, result := range plan {
+		for _, manual := range result.Manual {
+			line := fmt.Sprintf("  %s: %s", result.File, manual.Fix.Description)
+			if manual.Reason != "" {
+				line += fmt.Sprintf(" (%s)", manual.Reason)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	// Guard clause: nothing to do by hand
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "⚠️  %d fixes must be made manually:\n", len(lines))
+	for _, line := range lines {
+		fmt.Fprintln(out, line)
+	}
+}
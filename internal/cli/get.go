@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+	"github.com/syntropysoft/praetorian-go/internal/services/query"
+	"github.com/syntropysoft/praetorian-go/internal/services/secrets"
+)
+
+// NewGetCommand creates the get command
+func NewGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <path>",
+		Short: "Show the value of keys in every environment",
+		Long: `Show the value of keys in every environment configured in praetorian.yaml.
+
+The path selects keys and everything below them (database selects database.host). Keys
+may use * and ? wildcards, [*] matches any list index and ** matches any number of keys.
+Dotted keys match nested keys, and the key flags match naming styles such as
+DATABASE_HOST and database.host, as in praetorian diff.
+
+Secrets (passwords, tokens, keys, credentials in URLs and the security custom_patterns)
+are masked unless --show-secrets is given.
+
+Exit codes: 0 when keys match, 1 when no key matches, 2 on errors.
+
+Examples:
+  praetorian get database.pool.max
+  praetorian get 'feature.*'
+  praetorian get 'servers[*].host' --env dev --env prod
+  praetorian get '**.timeout' --output json
+  praetorian get database -i --separators _      # also matches DATABASE_HOST in .env files`,
+		Args:          cobra.ExactArgs(1),
+		RunE:          runGet,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path")
+	cmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	cmd.Flags().StringSliceP("env", "e", nil, "Environments to show (default: all)")
+	cmd.Flags().Bool("show-secrets", false, "Show the values of secrets instead of masking them")
+	cmd.Flags().BoolP("ignore-case", "i", false, "Match keys case-insensitively")
+	cmd.Flags().String("separators", "", "Characters that also separate keys (e.g. _ for environment variables)")
+	cmd.Flags().StringSlice("strip-prefix", nil, "Prefixes removed from keys before matching (e.g. APP_)")
+
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &ExitError{Code: 2, Err: err}
+	})
+
+	return cmd
+}
+
+// runGet executes the get command
+func runGet(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("command cannot be nil")}
+	}
+
+	// Extract and validate flags
+	flags, err := extractGetFlags(cmd, args)
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to extract flags: %w", err)}
+	}
+
+	// Execute query
+	matches, err := executeGet(flags, cmd.OutOrStdout())
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+	if matches == 0 {
+		return &ExitError{Code: 1, Err: fmt.Errorf("no keys match %s", flags.Pattern)}
+	}
+	return nil
+}
+
+// GetFlags represents get command flags
+type GetFlags struct {
+	ConfigPath   string
+	OutputFormat string
+	Pattern      string
+	Environments []string
+	ShowSecrets  bool
+	Keys         comparison.KeyOptions
+}
+
+// extractGetFlags extracts and validates flags from command
+func extractGetFlags(cmd *cobra.Command, args []string) (*GetFlags, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output flag: %w", err)
+	}
+
+	environments, err := cmd.Flags().GetStringSlice("env")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get env flag: %w", err)
+	}
+
+	showSecrets, err := cmd.Flags().GetBool("show-secrets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get show-secrets flag: %w", err)
+	}
+
+	ignoreCase, err := cmd.Flags().GetBool("ignore-case")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ignore-case flag: %w", err)
+	}
+
+	separators, err := cmd.Flags().GetString("separators")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get separators flag: %w", err)
+	}
+
+	stripPrefixes, err := cmd.Flags().GetStringSlice("strip-prefix")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strip-prefix flag: %w", err)
+	}
+
+	// Guard clause: validate output format
+	if outputFormat != "text" && outputFormat != "json" {
+		return nil, fmt.Errorf("invalid output format: %s, must be one of: [text json]", outputFormat)
+	}
+
+	return &GetFlags{
+		ConfigPath:   configPath,
+		OutputFormat: outputFormat,
+		Pattern:      args[0],
+		Environments: environments,
+		ShowSecrets:  showSecrets,
+		Keys: comparison.KeyOptions{
+			IgnoreCase:    ignoreCase,
+			Separators:    separators,
+			StripPrefixes: stripPrefixes,
+		},
+	}, nil
+}
+
+// executeGet prints the selected keys of every environment, returning how many keys match
+func executeGet(flags *GetFlags, out io.Writer) (int, error) {
+	// Guard clause: validate flags
+	if flags == nil {
+		return 0, fmt.Errorf("flags cannot be nil")
+	}
+
+	pattern, err := query.ParsePattern(flags.Pattern, flags.Keys)
+	if err != nil {
+		return 0, err
+	}
+
+	ws, err := loadWorkspace(flags.ConfigPath)
+	if err != nil {
+		return 0, err
+	}
+
+	environments, err := queryEnvironments(ws, flags.Environments)
+	if err != nil {
+		return 0, err
+	}
+
+	options := query.Options{Keys: flags.Keys}
+	if !flags.ShowSecrets {
+		detector, err := secrets.NewDetector(ws.config.Rules.Security)
+		if err != nil {
+			return 0, err
+		}
+		options.Secrets = detector
+	}
+
+	result := query.Query(environments, pattern, options)
+
+	if flags.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return len(result.Rows), encoder.Encode(result)
+	}
+
+	displayGetTable(out, result)
+	return len(result.Rows), nil
+}
+
+// queryEnvironments parses the files of the selected environments, or of every environment
+// in sorted order
+func queryEnvironments(ws *workspace, selected []string) ([]query.Environment, error) {
+	environments, err := ws.environments()
+	if err != nil {
+		return nil, err
+	}
+
+	// Guard clause: values are shown by environment
+	if len(environments) == 0 {
+		return nil, fmt.Errorf("no environments configured")
+	}
+
+	names := make([]string, 0, len(environments))
+	for name := range environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range selected {
+		if _, ok := environments[name]; !ok {
+			return nil, fmt.Errorf("unknown environment %q (configured: %v)", name, names)
+		}
+	}
+	if len(selected) > 0 {
+		names = selected
+	}
+
+	result := make([]query.Environment, 0, len(names))
+	for _, name := range names {
+		files, err := ws.parseAll(environments[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, query.Environment{Name: name, Files: files})
+	}
+	return result, nil
+}
+
+// displayGetTable prints the selected keys with their value in every environment
+func displayGetTable(out io.Writer, result query.Result) {
+	// Guard clause: nothing to show
+	if len(result.Rows) == 0 {
+		return
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "KEY\t%s\n", strings.Join(result.Environments, "\t"))
+	for _, row := range result.Rows {
+		cells := make([]string, len(row.Values))
+		for i, value := range row.Values {
+			cells[i] = diffCell(comparison.Value{Present: value.Present, Value: value.Value}, false)
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", row.Key, strings.Join(cells, "\t"))
+	}
+	_ = writer.Flush()
+}
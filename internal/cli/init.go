@@ -2,11 +2,32 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/adapters/loaders"
+	"github.com/syntropysoft/praetorian-go/internal/adapters/parsers"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/discovery"
 )
 
+// initConfigFile is the configuration file written by init
+const initConfigFile = "praetorian.yaml"
+
+// initIgnoredFiles are tool manifests that are never environment configuration
+var initIgnoredFiles = map[string]bool{
+	initConfigFile:      true,
+	"package.json":      true,
+	"package-lock.json": true,
+	"tsconfig.json":     true,
+	"composer.json":     true,
+	"pnpm-lock.yaml":    true,
+}
+
 // NewInitCommand creates the init command
 func NewInitCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -14,17 +35,21 @@ func NewInitCommand() *cobra.Command {
 		Short: "Initialize Praetorian configuration for your project",
 		Long: `Initialize Praetorian configuration for your project.
 
-This command creates a praetorian.yaml configuration file with sensible defaults
-for validating your configuration files across environments.
+This command scans the project for configuration files, infers environments from their
+names (config-prod.yaml, appsettings.Production.json, env.staging, values-dev.yaml or
+config/prod/app.yaml) and proposes the keys present in every environment as required
+keys. The proposed praetorian.yaml is shown and only written after confirmation.
 
 Examples:
-  praetorian init                    # Create basic configuration
-  praetorian init --devsecops        # Create DevSecOps optimized configuration`,
+  praetorian init                    # Propose a configuration and ask before writing it
+  praetorian init --force            # Write without asking, replacing an existing file
+  praetorian init --devsecops        # Also enable the security rules`,
 		RunE: runInit,
 	}
 
 	// Add flags
 	cmd.Flags().Bool("devsecops", false, "Initialize with DevSecOps optimizations")
+	cmd.Flags().BoolP("force", "f", false, "Write the configuration without asking, replacing an existing file")
 
 	return cmd
 }
@@ -43,12 +68,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute initialization
-	return executeInitialization(flags)
+	return executeInitialization(flags, cmd.InOrStdin(), cmd.OutOrStdout())
 }
 
 // InitFlags represents init command flags
 type InitFlags struct {
 	DevSecOps bool
+	Force     bool
 }
 
 // extractInitFlags extracts and validates flags from command
@@ -58,77 +84,232 @@ func extractInitFlags(cmd *cobra.Command) (*InitFlags, error) {
 		return nil, fmt.Errorf("failed to get devsecops flag: %w", err)
 	}
 
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get force flag: %w", err)
+	}
+
 	return &InitFlags{
 		DevSecOps: devsecops,
+		Force:     force,
 	}, nil
 }
 
+// initPlan is the configuration init proposes for a project
+type initPlan struct {
+	Files        []string
+	Proposal     discovery.Proposal
+	RequiredKeys []string
+	// GroupKeys maps each group to the keys in all of its files. Structure rules apply to
+	// every file, so only the keys of every group are required.
+	GroupKeys map[string][]string
+	Skipped   []string
+}
+
 // executeInitialization executes the initialization process
-func executeInitialization(flags *InitFlags) error {
+func executeInitialization(flags *InitFlags, in io.Reader, out io.Writer) error {
 	// Guard clause: validate flags
 	if flags == nil {
 		return fmt.Errorf("flags cannot be nil")
 	}
 
 	// Display initialization info
-	displayInitializationInfo(flags)
+	displayInitializationInfo(out, flags)
+
+	plan, err := buildInitPlan(".")
+	if err != nil {
+		return fmt.Errorf("failed to scan project: %w", err)
+	}
+	displayInitPlan(out, plan)
+
+	content := generateConfigContent(plan, flags.DevSecOps)
+	fmt.Fprintf(out, "\n%s\n", content)
+
+	if !flags.Force {
+		question := "Write " + initConfigFile + "?"
+		if _, err := os.Stat(initConfigFile); err == nil {
+			question = "Overwrite existing " + initConfigFile + "?"
+		}
+		if !confirm(in, out, question) {
+			fmt.Fprintf(out, "Aborted, %s not written\n", initConfigFile)
+			return nil
+		}
+	}
 
 	// Create configuration file
-	if err := createConfigFile(flags); err != nil {
+	if err := writeConfigFileContent(initConfigFile, content); err != nil {
 		return fmt.Errorf("failed to create config file: %w", err)
 	}
 
-	fmt.Printf("✅ Configuration initialized successfully!\n")
+	fmt.Fprintf(out, "✅ Configuration initialized successfully!\n")
 	return nil
 }
 
 // displayInitializationInfo displays initialization information
-func displayInitializationInfo(flags *InitFlags) {
-	fmt.Printf("🚀 Initializing Praetorian configuration...\n")
-	fmt.Printf("🛡️  DevSecOps mode: %t\n", flags.DevSecOps)
-	fmt.Printf("🔧 Creating config file...\n")
+func displayInitializationInfo(out io.Writer, flags *InitFlags) {
+	fmt.Fprintf(out, "🚀 Initializing Praetorian configuration...\n")
+	fmt.Fprintf(out, "🛡️  DevSecOps mode: %t\n", flags.DevSecOps)
+	fmt.Fprintf(out, "🔍 Scanning for configuration files...\n")
 }
 
-// createConfigFile creates the configuration file
-func createConfigFile(flags *InitFlags) error {
-	// Guard clause: validate flags
-	if flags == nil {
-		return fmt.Errorf("flags cannot be nil")
+// displayInitPlan displays the discovered files and environments
+func displayInitPlan(out io.Writer, plan *initPlan) {
+	fmt.Fprintf(out, "📁 Configuration files: %d\n", len(plan.Files))
+
+	names := plan.Proposal.EnvironmentNames()
+	if len(names) == 0 {
+		fmt.Fprintf(out, "🌍 No environments could be inferred from file names\n")
+	}
+	for _, name := range names {
+		fmt.Fprintf(out, "🌍 %s: %s\n", name, strings.Join(plan.Proposal.Environments[name], ", "))
+	}
+	if len(names) > 1 {
+		fmt.Fprintf(out, "🔑 Keys in every environment file: %d\n", len(plan.RequiredKeys))
+	}
+	if len(plan.GroupKeys) > 1 {
+		for _, group := range plan.Proposal.Groups {
+			fmt.Fprintf(out, "🔑 Keys in every %s file: %d\n", group, len(plan.GroupKeys[group]))
+		}
+	}
+	if len(names) > 0 && len(plan.Proposal.Unassigned) > 0 {
+		fmt.Fprintf(out, "📄 Not in an environment: %s\n", strings.Join(plan.Proposal.Unassigned, ", "))
+	}
+	for _, skipped := range plan.Skipped {
+		fmt.Fprintf(out, "⚠️  %s\n", skipped)
+	}
+}
+
+// buildInitPlan scans a project for configuration files, infers their environments and
+// proposes the keys present in every environment file as required keys
+func buildInitPlan(root string) (*initPlan, error) {
+	registry := parsers.NewParserRegistry()
+	files, err := loaders.ScanFiles(root, func(filename string) bool {
+		if initIgnoredFiles[filepath.Base(filename)] {
+			return false
+		}
+		_, err := registry.GetProcessor(filename)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate config content
-	content := generateConfigContent(flags.DevSecOps)
+	plan := &initPlan{Files: files, Proposal: discovery.Discover(files)}
+	if len(plan.Proposal.Environments) < 2 {
+		return plan, nil
+	}
 
-	// Write file
-	return writeConfigFileContent("praetorian.yaml", content)
+	ws := &workspace{config: &models.PraetorianConfig{}, baseDir: root, registry: registry}
+	plan.GroupKeys = make(map[string][]string)
+	var groupKeys [][]string
+	for _, group := range plan.Proposal.Groups {
+		var parsed []*models.ConfigData
+		for _, filename := range plan.Proposal.GroupFiles[group] {
+			data, err := ws.parse(filename)
+			if err != nil {
+				plan.Skipped = append(plan.Skipped, fmt.Sprintf("skipped for required keys: %v", err))
+				continue
+			}
+			parsed = append(parsed, models.ExpandDocuments([]*models.ConfigData{data})...)
+		}
+		// Guard clause: no file of the group could be read
+		if len(parsed) == 0 {
+			continue
+		}
+		plan.GroupKeys[group] = discovery.CommonKeys(parsed)
+		groupKeys = append(groupKeys, plan.GroupKeys[group])
+	}
+	plan.RequiredKeys = discovery.IntersectKeys(groupKeys)
+	return plan, nil
 }
 
 // generateConfigContent generates the configuration content
-func generateConfigContent(devsecops bool) string {
-	baseContent := `# Praetorian Configuration
-version: "1.0"
-files:
-  - "config/*.yaml"
-  - "config/*.json"
-  - "config/*.toml"
-
-environments:
-  dev: "config/dev"
-  staging: "config/staging"
-  prod: "config/prod"
-`
+func generateConfigContent(plan *initPlan, devsecops bool) string {
+	var builder strings.Builder
+	builder.WriteString("# Praetorian Configuration\n")
+	builder.WriteString("# Generated by praetorian init; review the environments and required keys\n")
+	builder.WriteString("version: \"2.0\"\n")
+
+	names := plan.Proposal.EnvironmentNames()
+
+	// Without environments, the discovered files are validated on their own
+	if len(names) == 0 && len(plan.Proposal.Unassigned) > 0 {
+		builder.WriteString("\nfiles:\n  include:\n")
+		for _, filename := range plan.Proposal.Unassigned {
+			fmt.Fprintf(&builder, "    - %s\n", yamlString(filepath.ToSlash(filename)))
+		}
+	}
+
+	builder.WriteString("\nenvironments:\n")
+	if len(names) == 0 {
+		builder.WriteString("  # dev: config/dev.yaml\n  # prod: config/prod.yaml\n")
+	}
+	for _, name := range names {
+		files := plan.Proposal.Environments[name]
+		if len(files) == 1 {
+			fmt.Fprintf(&builder, "  %s: %s\n", name, yamlString(filepath.ToSlash(files[0])))
+			continue
+		}
+		fmt.Fprintf(&builder, "  %s:\n", name)
+		for _, filename := range files {
+			fmt.Fprintf(&builder, "    - %s\n", yamlString(filepath.ToSlash(filename)))
+		}
+	}
+
+	builder.WriteString("\nrules:\n  structure:\n")
+	if len(plan.RequiredKeys) == 0 {
+		builder.WriteString("    required_keys: []\n")
+	} else {
+		builder.WriteString("    # Keys present in every environment file\n    required_keys:\n")
+		for _, key := range plan.RequiredKeys {
+			fmt.Fprintf(&builder, "      - %s\n", yamlString(key))
+		}
+	}
+	writeGroupKeys(&builder, plan)
 
 	if devsecops {
-		baseContent += `
-# DevSecOps specific configurations
-security:
-  secret_detection: true
-  vulnerability_scan: true
-  permission_check: true
-`
+		builder.WriteString(`
+  # DevSecOps specific configurations
+  security:
+    secret_detection: true
+    vulnerability_scan: true
+    permission_check: true
+`)
 	}
 
-	return baseContent
+	return builder.String()
+}
+
+// writeGroupKeys lists, as comments, the keys present in every file of one group only.
+// Required keys are checked in every file, so they cannot be required.
+func writeGroupKeys(builder *strings.Builder, plan *initPlan) {
+	required := make(map[string]bool, len(plan.RequiredKeys))
+	for _, key := range plan.RequiredKeys {
+		required[key] = true
+	}
+
+	for _, group := range plan.Proposal.Groups {
+		var keys []string
+		for _, key := range plan.GroupKeys[group] {
+			if !required[key] {
+				keys = append(keys, key)
+			}
+		}
+		// Guard clause: the group has no keys of its own
+		if len(keys) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(builder, "    # Also present in every %s file:\n", group)
+		for _, key := range keys {
+			fmt.Fprintf(builder, "    #   - %s\n", yamlString(key))
+		}
+	}
+}
+
+// yamlString quotes a string for a YAML document
+func yamlString(value string) string {
+	return strconv.Quote(value)
 }
 
 // writeConfigFileContent writes content to a file
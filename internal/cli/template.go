@@ -0,0 +1,301 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/adapters/parsers"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+	"github.com/syntropysoft/praetorian-go/internal/services/secrets"
+	"github.com/syntropysoft/praetorian-go/internal/services/templating"
+)
+
+// NewTemplateCommand creates the template command
+func NewTemplateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Generate configuration templates from the environments",
+		Long: `Generate configuration templates such as .env.example from the configured environments.
+
+Examples:
+  praetorian template generate --from prod --format env .env.example
+  praetorian template generate --format json appsettings.Template.json --check`,
+	}
+
+	cmd.AddCommand(NewTemplateGenerateCommand())
+	return cmd
+}
+
+// NewTemplateGenerateCommand creates the template generate command
+func NewTemplateGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate [file]",
+		Short: "Generate a template with the keys of every environment",
+		Long: `Generate a template containing the union of the keys of every environment.
+
+Values are replaced by type hints (<string>, <int>, <bool>, ...) or by --placeholder.
+Secrets (passwords, tokens, keys, credentials in URLs and the security custom_patterns)
+are always blanked. Type hints come from the --from environment first, then from the
+other environments in order.
+
+The template is printed, or written to file. With --check the file is not written;
+instead the command exits with code 1 when its keys no longer match the environments,
+so CI catches stale templates.
+
+Examples:
+  praetorian template generate --from prod --format env
+  praetorian template generate --from prod --format env .env.example
+  praetorian template generate --format env .env.example --check
+  praetorian template generate --format yaml --placeholder CHANGE_ME config/template.yaml`,
+		Args:          cobra.MaximumNArgs(1),
+		RunE:          runTemplateGenerate,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	// Add flags
+	cmd.Flags().StringP("config", "c", "praetorian.yaml", "Configuration file path")
+	cmd.Flags().String("from", "", "Environment whose values give the type hints first (default: first environment)")
+	cmd.Flags().StringP("format", "f", "env", fmt.Sprintf("Template format (%s)", strings.Join(parsers.SerializableFormats(), ", ")))
+	cmd.Flags().String("placeholder", "", "Value written instead of type hints")
+	cmd.Flags().Bool("check", false, "Fail when the keys of file do not match the environments, without writing it")
+
+	return cmd
+}
+
+// runTemplateGenerate executes the template generate command
+func runTemplateGenerate(cmd *cobra.Command, args []string) error {
+	// Guard clause: validate command
+	if cmd == nil {
+		return fmt.Errorf("command cannot be nil")
+	}
+
+	// Extract and validate flags
+	flags, err := extractTemplateFlags(cmd, args)
+	if err != nil {
+		return fmt.Errorf("failed to extract flags: %w", err)
+	}
+
+	// Execute generation
+	stale, err := executeTemplateGenerate(flags, cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	if stale {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+// TemplateFlags represents template generate command flags
+type TemplateFlags struct {
+	ConfigPath  string
+	From        string
+	Format      string
+	Placeholder string
+	Check       bool
+	Target      string
+}
+
+// extractTemplateFlags extracts and validates flags from command
+func extractTemplateFlags(cmd *cobra.Command, args []string) (*TemplateFlags, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from flag: %w", err)
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get format flag: %w", err)
+	}
+
+	placeholder, err := cmd.Flags().GetString("placeholder")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placeholder flag: %w", err)
+	}
+
+	check, err := cmd.Flags().GetBool("check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check flag: %w", err)
+	}
+
+	flags := &TemplateFlags{
+		ConfigPath:  configPath,
+		From:        from,
+		Format:      format,
+		Placeholder: placeholder,
+		Check:       check,
+	}
+	if len(args) > 0 {
+		flags.Target = args[0]
+	}
+
+	// Guard clause: check mode compares against a file
+	if flags.Check && flags.Target == "" {
+		return nil, fmt.Errorf("--check needs the template file to check")
+	}
+
+	return flags, nil
+}
+
+// executeTemplateGenerate generates the template and prints, writes or checks it. It
+// returns whether a checked template is stale.
+func executeTemplateGenerate(flags *TemplateFlags, out io.Writer) (bool, error) {
+	// Guard clause: validate flags
+	if flags == nil {
+		return false, fmt.Errorf("flags cannot be nil")
+	}
+
+	ws, err := loadWorkspace(flags.ConfigPath)
+	if err != nil {
+		return false, err
+	}
+
+	files, err := environmentFiles(ws, flags.From)
+	if err != nil {
+		return false, err
+	}
+
+	options, err := templateOptions(ws.config, flags.Placeholder)
+	if err != nil {
+		return false, err
+	}
+
+	template := templating.Build(files, options)
+	data, err := template.Data()
+	if err != nil {
+		return false, err
+	}
+	content, err := parsers.Serialize(flags.Format, data)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case flags.Target == "":
+		_, err := out.Write(content)
+		return false, err
+	case flags.Check:
+		return checkTemplate(ws, flags, content, out)
+	}
+
+	if err := os.WriteFile(flags.Target, content, 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", flags.Target, err)
+	}
+	fmt.Fprintf(out, "✅ Wrote %s: %d keys, %d secrets blanked\n", flags.Target, len(template.Keys), countSecrets(template))
+	return false, nil
+}
+
+// environmentFiles parses the files of every environment, starting with the from
+// environment and then the others in sorted order
+func environmentFiles(ws *workspace, from string) ([]*models.ConfigData, error) {
+	environments, err := ws.environments()
+	if err != nil {
+		return nil, err
+	}
+
+	// Guard clause: templates are built from environments
+	if len(environments) == 0 {
+		return nil, fmt.Errorf("no environments configured")
+	}
+
+	names := make([]string, 0, len(environments))
+	for name := range environments {
+		if name != from {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if from != "" {
+		if _, ok := environments[from]; !ok {
+			return nil, fmt.Errorf("unknown environment %q (configured: %v)", from, names)
+		}
+		names = append([]string{from}, names...)
+	}
+
+	var files []*models.ConfigData
+	for _, name := range names {
+		parsed, err := ws.parseAll(environments[name])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, parsed...)
+	}
+	return files, nil
+}
+
+// templateOptions builds the template options of a configuration
+func templateOptions(config *models.PraetorianConfig, placeholder string) (templating.Options, error) {
+	detector, err := secrets.NewDetector(config.Rules.Security)
+	if err != nil {
+		return templating.Options{}, err
+	}
+
+	inferenceConfig := models.TypeInferenceConfig{}
+	if config.Inference != nil {
+		inferenceConfig = *config.Inference
+	}
+	inference, err := parsers.NewTypeInference(inferenceConfig)
+	if err != nil {
+		return templating.Options{}, err
+	}
+
+	return templating.Options{Placeholder: placeholder, Secrets: detector, Infer: inference.Infer}, nil
+}
+
+// checkTemplate compares the keys of a template file with the generated template and
+// reports the keys that differ
+func checkTemplate(ws *workspace, flags *TemplateFlags, content []byte, out io.Writer) (bool, error) {
+	committed, err := ws.parse(flags.Target)
+	if err != nil {
+		return false, err
+	}
+
+	processor, err := parsers.NewProcessorForFormat(flags.Format, nil)
+	if err != nil {
+		return false, err
+	}
+	generated, err := models.ProcessNormalized(context.Background(), processor, flags.Target, content)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse generated template: %w", err)
+	}
+
+	result := comparison.Compare([]*models.ConfigData{generated, committed}, comparison.Options{StructureOnly: true})
+	differences := result.Differences()
+	for _, entry := range differences {
+		if entry.Status == comparison.StatusRemoved {
+			fmt.Fprintf(out, "- %s is missing from %s\n", entry.Values[0].Key, flags.Target)
+			continue
+		}
+		fmt.Fprintf(out, "+ %s is in no environment\n", entry.Values[1].Key)
+	}
+
+	if len(differences) > 0 {
+		fmt.Fprintf(out, "❌ %s is stale: %d keys differ, run praetorian template generate to update it\n", flags.Target, len(differences))
+		return true, nil
+	}
+	fmt.Fprintf(out, "✅ %s matches the %d keys of the environments\n", flags.Target, len(result.Entries))
+	return false, nil
+}
+
+// countSecrets counts the blanked keys of a template
+func countSecrets(template templating.Template) int {
+	count := 0
+	for _, key := range template.Keys {
+		if key.Secret {
+			count++
+		}
+	}
+	return count
+}
@@ -1,27 +1,36 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"gopkg.in/yaml.v3"
 )
 
 // NewValidateCommand creates the validate command
 func NewValidateCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "validate",
+		Use:   "validate [files...]",
 		Short: "Validate configuration files for consistency across environments",
 		Long: `Validate configuration files for consistency across environments.
 
-This command compares configuration files between different environments (dev, staging, prod)
-and reports missing keys, extra keys, and value differences.
+This command runs the configured rules against the configured files (or the given files)
+and reports their issues. Every document of a multi-document file (e.g. deploy.yaml#2 or
+deploy.yaml#Deployment/api) is validated on its own.
+
+Exits with code 1 when a file has errors.
 
 Examples:
   praetorian validate                           # Validate current directory
   praetorian validate --config praetorian.yaml # Use specific config file
   praetorian validate --output json            # Output in JSON format
   praetorian validate --pipeline               # CI/CD friendly output`,
-		RunE: runValidate,
+		RunE:          runValidate,
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
 	// Add flags
@@ -40,13 +49,13 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Extract and validate flags
-	flags, err := extractValidateFlags(cmd)
+	flags, err := extractValidateFlags(cmd, args)
 	if err != nil {
 		return fmt.Errorf("failed to extract flags: %w", err)
 	}
 
 	// Execute validation
-	return executeValidation(flags)
+	return executeValidation(flags, cmd.OutOrStdout())
 }
 
 // ValidateFlags represents validation command flags
@@ -54,10 +63,11 @@ type ValidateFlags struct {
 	ConfigPath   string
 	OutputFormat string
 	PipelineMode bool
+	Files        []string
 }
 
 // extractValidateFlags extracts and validates flags from command
-func extractValidateFlags(cmd *cobra.Command) (*ValidateFlags, error) {
+func extractValidateFlags(cmd *cobra.Command, args []string) (*ValidateFlags, error) {
 	configPath, err := cmd.Flags().GetString("config")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config flag: %w", err)
@@ -87,42 +97,106 @@ func extractValidateFlags(cmd *cobra.Command) (*ValidateFlags, error) {
 		ConfigPath:   configPath,
 		OutputFormat: outputFormat,
 		PipelineMode: pipelineMode,
+		Files:        args,
 	}, nil
 }
 
-// executeValidation executes the validation process
-func executeValidation(flags *ValidateFlags) error {
+// executeValidation validates the files and reports their issues
+func executeValidation(flags *ValidateFlags, out io.Writer) error {
 	// Guard clause: validate flags
 	if flags == nil {
 		return fmt.Errorf("flags cannot be nil")
 	}
 
-	// Display validation info
-	displayValidationInfo(flags)
-
-	// TODO: Implement actual validation logic
-	fmt.Printf("✅ Validation completed successfully!\n")
+	ws, err := loadWorkspace(flags.ConfigPath)
+	if err != nil {
+		return err
+	}
+	files, err := ws.files(flags.Files)
+	if err != nil {
+		return err
+	}
+	configs, err := ws.parseFiles(files)
+	if err != nil {
+		return err
+	}
 
-	// Handle pipeline output
-	if flags.PipelineMode {
-		displayPipelineOutput()
+	result := mergeValidationResults(ws.validate(configs))
+
+	switch flags.OutputFormat {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	case "yaml":
+		encoded, err := yaml.Marshal(result)
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(encoded); err != nil {
+			return err
+		}
+	default:
+		displayValidationInfo(out, flags)
+		displayValidationResult(out, len(files), result)
+
+		// Handle pipeline output
+		if flags.PipelineMode {
+			displayPipelineOutput(out, result.Success)
+		}
 	}
 
+	if !result.Success {
+		return &ExitError{Code: 1}
+	}
 	return nil
 }
 
+// mergeValidationResults combines the results of every rule and file into one result
+func mergeValidationResults(results []models.ValidationResult) models.ValidationResult {
+	merged := models.ValidationResult{Success: true}
+	for _, result := range results {
+		merged.Errors = append(merged.Errors, result.Errors...)
+		merged.Warnings = append(merged.Warnings, result.Warnings...)
+	}
+	merged.Success = len(merged.Errors) == 0
+	return merged
+}
+
 // displayValidationInfo displays validation information
-func displayValidationInfo(flags *ValidateFlags) {
-	fmt.Printf("🔍 Validating configuration files...\n")
-	fmt.Printf("📁 Config: %s\n", flags.ConfigPath)
-	fmt.Printf("📤 Output: %s\n", flags.OutputFormat)
-	
+func displayValidationInfo(out io.Writer, flags *ValidateFlags) {
+	fmt.Fprintf(out, "🔍 Validating configuration files...\n")
+	fmt.Fprintf(out, "📁 Config: %s\n", flags.ConfigPath)
+	fmt.Fprintf(out, "📤 Output: %s\n", flags.OutputFormat)
+
 	if flags.PipelineMode {
-		fmt.Printf("🚀 Pipeline mode: enabled\n")
+		fmt.Fprintf(out, "🚀 Pipeline mode: enabled\n")
+	}
+}
+
+// displayValidationResult displays the issues of the validated files
+func displayValidationResult(out io.Writer, files int, result models.ValidationResult) {
+	for _, issue := range result.Errors {
+		fmt.Fprintf(out, "%s error %s %s\n", issueLocation(issue.File, issue.Line, issue.Column), issue.Code, issue.Message)
+	}
+	for _, issue := range result.Warnings {
+		fmt.Fprintf(out, "%s warning %s %s\n", issueLocation(issue.File, issue.Line, issue.Column), issue.Code, issue.Message)
 	}
+
+	if result.Success {
+		fmt.Fprintf(out, "✅ Validation completed successfully! %d files, %d warnings\n", files, len(result.Warnings))
+		return
+	}
+	fmt.Fprintf(out, "❌ Validation failed: %d errors and %d warnings in %d files\n", len(result.Errors), len(result.Warnings), files)
 }
 
 // displayPipelineOutput displays pipeline-friendly output
-func displayPipelineOutput() {
-	fmt.Printf("PRAETORIAN_VALIDATION_STATUS=success\n")
+func displayPipelineOutput(out io.Writer, success bool) {
+	status := "success"
+	if !success {
+		status = "failure"
+	}
+	fmt.Fprintf(out, "PRAETORIAN_VALIDATION_STATUS=%s\n", status)
 }
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/syntropysoft/praetorian-go/internal/adapters/loaders"
+	"github.com/syntropysoft/praetorian-go/internal/adapters/parsers"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/domain/rules"
+)
+
+// workspace is a loaded praetorian.yaml configuration with the parser registry it configures.
+// Paths in the configuration are relative to the directory of the configuration file.
+type workspace struct {
+	config   *models.PraetorianConfig
+	baseDir  string
+	registry *parsers.ParserRegistry
+}
+
+// loadWorkspace loads a configuration and builds its parser registry
+func loadWorkspace(configPath string) (*workspace, error) {
+	config, err := loaders.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := parsers.NewParserRegistry()
+	if err := registry.ApplyFormatRules(config.Formats); err != nil {
+		return nil, fmt.Errorf("invalid formats: %w", err)
+	}
+	if config.Inference != nil {
+		if err := registry.ApplyTypeInference(*config.Inference); err != nil {
+			return nil, fmt.Errorf("invalid type_inference: %w", err)
+		}
+	}
+	baseDir := filepath.Dir(configPath)
+	if err := registry.ApplyParserPlugins(config.Parsers, baseDir); err != nil {
+		return nil, fmt.Errorf("invalid parsers: %w", err)
+	}
+
+	return &workspace{config: config, baseDir: baseDir, registry: registry}, nil
+}
+
+// loadOptionalWorkspace loads a configuration when it exists (or is required), and otherwise
+// returns a workspace with an empty configuration and the built-in parsers
+func loadOptionalWorkspace(configPath string, required bool) (*workspace, error) {
+	if _, err := os.Stat(configPath); err != nil && !required {
+		return &workspace{config: &models.PraetorianConfig{}, baseDir: ".", registry: parsers.NewParserRegistry()}, nil
+	}
+	return loadWorkspace(configPath)
+}
+
+// files returns the explicitly given files, or else the files the configuration selects
+func (w *workspace) files(explicit []string) ([]string, error) {
+	if len(explicit) > 0 {
+		return explicit, nil
+	}
+	return loaders.ResolveFiles(w.config, w.baseDir)
+}
+
+// environments returns the files of every configured environment
+func (w *workspace) environments() (map[string][]string, error) {
+	return loaders.ResolveEnvironments(w.config, w.baseDir)
+}
+
+// parse reads and parses a file, detecting its format and encoding
+func (w *workspace) parse(filename string) (*models.ConfigData, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	// Content sniffing needs decoded text
+	normalized, _, err := models.NormalizeEncoding(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filename, err)
+	}
+
+	processor, err := w.registry.DetectProcessor(filename, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := models.ProcessNormalized(context.Background(), processor, filename, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return data, nil
+}
+
+// parseFiles parses files, keeping multi-document files whole
+func (w *workspace) parseFiles(filenames []string) ([]*models.ConfigData, error) {
+	configs := make([]*models.ConfigData, 0, len(filenames))
+	for _, filename := range filenames {
+		data, err := w.parse(filename)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, data)
+	}
+	return configs, nil
+}
+
+// parseAll parses files, expanding multi-document files into their documents
+func (w *workspace) parseAll(filenames []string) ([]*models.ConfigData, error) {
+	configs, err := w.parseFiles(filenames)
+	if err != nil {
+		return nil, err
+	}
+	return models.ExpandDocuments(configs), nil
+}
+
+// validate runs the configured rules against parsed files. Key rules check every document
+// of multi-document files, while the encoding of a file is checked once.
+func (w *workspace) validate(configs []*models.ConfigData) []models.ValidationResult {
+	fileRules := []models.ValidationRule{
+		rules.NewStructureRule(w.config.Rules.Structure),
+		rules.NewTerraformVariablesRule(),
+		rules.NewMergeOverrideRule(),
+	}
+
+	var results []models.ValidationResult
+	for _, data := range models.ExpandDocuments(configs) {
+		for _, rule := range fileRules {
+			results = append(results, rule.Validate(data))
+		}
+	}
+	results = append(results, rules.NewEncodingConsistencyRule().ValidateFiles(configs))
+	return results
+}
@@ -6,13 +6,20 @@ import (
 
 // PraetorianConfig represents the main configuration structure
 type PraetorianConfig struct {
-	Version      string                    `yaml:"version" json:"version"`
-	Files        FilePatterns              `yaml:"files" json:"files"`
-	Environments map[string]string         `yaml:"environments" json:"environments"`
-	Rules        ValidationRules           `yaml:"rules" json:"rules"`
-	Output       OutputConfig              `yaml:"output" json:"output"`
-	Performance  PerformanceConfig         `yaml:"performance" json:"performance"`
-	Integrations IntegrationConfig         `yaml:"integrations" json:"integrations"`
+	Version      string                      `yaml:"version" json:"version"`
+	Extends      StringList                  `yaml:"extends,omitempty" json:"extends,omitempty"`
+	Presets      StringList                  `yaml:"presets,omitempty" json:"presets,omitempty"`
+	Files        FilePatterns                `yaml:"files" json:"files"`
+	Formats      []FileFormatRule            `yaml:"formats,omitempty" json:"formats,omitempty"`
+	Inference    *TypeInferenceConfig        `yaml:"type_inference,omitempty" json:"type_inference,omitempty"`
+	Parsers      []ParserPlugin              `yaml:"parsers,omitempty" json:"parsers,omitempty"`
+	Environments map[string]EnvironmentFiles `yaml:"environments" json:"environments"`
+	Rules        ValidationRules             `yaml:"rules" json:"rules"`
+	Output       OutputConfig                `yaml:"output" json:"output"`
+	Performance  PerformanceConfig           `yaml:"performance" json:"performance"`
+	Integrations IntegrationConfig           `yaml:"integrations" json:"integrations"`
+	// Sources are the absolute paths of the configuration file and the files it extends
+	Sources []string `yaml:"-" json:"-"`
 }
 
 // FilePatterns defines file patterns and exclusions
@@ -21,6 +28,79 @@ type FilePatterns struct {
 	Exclude []string `yaml:"exclude" json:"exclude"`
 }
 
+// UnmarshalYAML accepts either the include/exclude mapping or a plain list of include
+// patterns (files: ["config/*.yaml"])
+func (p *FilePatterns) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var include []string
+	if err := unmarshal(&include); err == nil {
+		*p = FilePatterns{Include: include}
+		return nil
+	}
+
+	type plain FilePatterns
+	return unmarshal((*plain)(p))
+}
+
+// StringList is a list of strings that can also be written as a single string
+// (extends: base.yaml)
+type StringList []string
+
+// UnmarshalYAML accepts either a single string or a list of strings
+func (l *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*l = StringList{single}
+		return nil
+	}
+
+	var entries []string
+	if err := unmarshal(&entries); err != nil {
+		return err
+	}
+	*l = entries
+	return nil
+}
+
+// MarshalYAML writes a single entry as a plain string
+func (l StringList) MarshalYAML() (interface{}, error) {
+	if len(l) == 1 {
+		return l[0], nil
+	}
+	return []string(l), nil
+}
+
+// EnvironmentFiles lists the files, directories or globs of an environment. A single entry
+// can be written as a plain string (prod: config/prod.yaml).
+type EnvironmentFiles = StringList
+
+// FileFormatRule forces the format of files matching a glob pattern, with optional
+// format-specific parser options (e.g. expand_keys for properties)
+type FileFormatRule struct {
+	Pattern string                 `yaml:"pattern" json:"pattern"`
+	Format  string                 `yaml:"format" json:"format"`
+	Options map[string]interface{} `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+// ParserPlugin declares an external parser for files matching Patterns. Command is run
+// with the file content on stdin and must print a JSON tree on stdout (see
+// parsers.PluginProtocolVersion); Timeout bounds each invocation (10s by default).
+type ParserPlugin struct {
+	Name     string        `yaml:"name" json:"name"`
+	Patterns []string      `yaml:"patterns" json:"patterns"`
+	Command  []string      `yaml:"command" json:"command"`
+	Timeout  time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// TypeInferenceConfig configures type inference for formats whose values are always strings
+// (env, properties and ini by default). Types selects the inferred types among bool, int,
+// float, duration and null (all by default); KeepStrings lists key path globs whose values
+// stay strings (e.g. "*.zip_code" or "app.version").
+type TypeInferenceConfig struct {
+	Formats     []string `yaml:"formats,omitempty" json:"formats,omitempty"`
+	Types       []string `yaml:"types,omitempty" json:"types,omitempty"`
+	KeepStrings []string `yaml:"keep_strings,omitempty" json:"keep_strings,omitempty"`
+}
+
 // ValidationRules defines validation rules
 type ValidationRules struct {
 	Structure  StructureRules  `yaml:"structure" json:"structure"`
@@ -28,25 +108,31 @@ type ValidationRules struct {
 	Compliance ComplianceRules `yaml:"compliance" json:"compliance"`
 }
 
-// StructureRules defines structure validation rules
+// StructureRules defines structure validation rules.
+// DeprecatedKeys maps deprecated key paths to their replacements; BooleanKeys are globs of
+// keys whose values must be booleans; Placeholder is the value `praetorian fix` writes for
+// missing required keys ("CHANGE_ME" by default).
 type StructureRules struct {
-	RequiredKeys []string `yaml:"required_keys" json:"required_keys"`
-	ForbiddenKeys []string `yaml:"forbidden_keys" json:"forbidden_keys"`
-	IgnoreKeys   []string `yaml:"ignore_keys" json:"ignore_keys"`
+	RequiredKeys   []string          `yaml:"required_keys" json:"required_keys"`
+	ForbiddenKeys  []string          `yaml:"forbidden_keys" json:"forbidden_keys"`
+	IgnoreKeys     []string          `yaml:"ignore_keys" json:"ignore_keys"`
+	DeprecatedKeys map[string]string `yaml:"deprecated_keys,omitempty" json:"deprecated_keys,omitempty"`
+	BooleanKeys    []string          `yaml:"boolean_keys,omitempty" json:"boolean_keys,omitempty"`
+	Placeholder    string            `yaml:"placeholder,omitempty" json:"placeholder,omitempty"`
 }
 
 // SecurityRules defines security validation rules
 type SecurityRules struct {
-	SecretDetection    bool     `yaml:"secret_detection" json:"secret_detection"`
-	VulnerabilityScan  bool     `yaml:"vulnerability_scan" json:"vulnerability_scan"`
-	PermissionCheck    bool     `yaml:"permission_check" json:"permission_check"`
-	CustomPatterns     []string `yaml:"custom_patterns" json:"custom_patterns"`
+	SecretDetection   bool     `yaml:"secret_detection" json:"secret_detection"`
+	VulnerabilityScan bool     `yaml:"vulnerability_scan" json:"vulnerability_scan"`
+	PermissionCheck   bool     `yaml:"permission_check" json:"permission_check"`
+	CustomPatterns    []string `yaml:"custom_patterns" json:"custom_patterns"`
 }
 
 // ComplianceRules defines compliance validation rules
 type ComplianceRules struct {
-	Standards []string         `yaml:"standards" json:"standards"`
-	Policies  []string         `yaml:"policies" json:"policies"`
+	Standards []string               `yaml:"standards" json:"standards"`
+	Policies  []string               `yaml:"policies" json:"policies"`
 	Custom    map[string]interface{} `yaml:"custom" json:"custom"`
 }
 
@@ -60,16 +146,16 @@ type OutputConfig struct {
 
 // PerformanceConfig defines performance settings
 type PerformanceConfig struct {
-	Concurrent   bool          `yaml:"concurrent" json:"concurrent"`
-	MaxWorkers   int           `yaml:"max_workers" json:"max_workers"`
-	Timeout      time.Duration `yaml:"timeout" json:"timeout"`
-	MemoryLimit  string        `yaml:"memory_limit" json:"memory_limit"`
+	Concurrent  bool          `yaml:"concurrent" json:"concurrent"`
+	MaxWorkers  int           `yaml:"max_workers" json:"max_workers"`
+	Timeout     time.Duration `yaml:"timeout" json:"timeout"`
+	MemoryLimit string        `yaml:"memory_limit" json:"memory_limit"`
 }
 
 // IntegrationConfig defines integration settings
 type IntegrationConfig struct {
 	Notifications NotificationConfig `yaml:"notifications" json:"notifications"`
-	Storage       StorageConfig       `yaml:"storage" json:"storage"`
+	Storage       StorageConfig      `yaml:"storage" json:"storage"`
 }
 
 // NotificationConfig defines notification settings
@@ -93,16 +179,17 @@ func DefaultConfig() *PraetorianConfig {
 			Include: []string{"configs/*.yaml", "configs/*.json", "configs/*.toml"},
 			Exclude: []string{"configs/*.local.*", "configs/*.test.*"},
 		},
-		Environments: map[string]string{
-			"dev":     "configs/dev/*",
-			"staging": "configs/staging/*",
-			"prod":    "configs/prod/*",
+		Environments: map[string]EnvironmentFiles{
+			"dev":     {"configs/dev/*"},
+			"staging": {"configs/staging/*"},
+			"prod":    {"configs/prod/*"},
 		},
 		Rules: ValidationRules{
 			Structure: StructureRules{
 				RequiredKeys:  []string{"database.host", "api.port"},
 				ForbiddenKeys: []string{"debug", "test"},
 				IgnoreKeys:    []string{"timestamp", "version"},
+				Placeholder:   "CHANGE_ME",
 			},
 			Security: SecurityRules{
 				SecretDetection:   true,
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DocumentSeparator separates a filename from a document reference (e.g. deploy.yaml#2)
+const DocumentSeparator = "#"
+
+// IsMultiDocument reports whether the config data holds more than one document
+func (c *ConfigData) IsMultiDocument() bool {
+	return c != nil && len(c.Documents) > 1
+}
+
+// DocumentIndex returns the 1-based position of the document within its file, or 0
+func (c *ConfigData) DocumentIndex() int {
+	if c == nil || c.Metadata == nil {
+		return 0
+	}
+	index, _ := c.Metadata["document_index"].(int)
+	return index
+}
+
+// DocumentName returns the document name (kind/name for Kubernetes objects), or ""
+func (c *ConfigData) DocumentName() string {
+	if c == nil || c.Metadata == nil {
+		return ""
+	}
+	name, _ := c.Metadata["document_name"].(string)
+	return name
+}
+
+// DocumentRef returns the reference used to address the document: its name when
+// available, its index otherwise
+func (c *ConfigData) DocumentRef() string {
+	if name := c.DocumentName(); name != "" {
+		return name
+	}
+	if index := c.DocumentIndex(); index > 0 {
+		return strconv.Itoa(index)
+	}
+	return ""
+}
+
+// Document returns the document addressed by ref, either its 1-based index ("2")
+// or its name ("Deployment/api")
+func (c *ConfigData) Document(ref string) (*ConfigData, bool) {
+	// Guard clause: nothing to look up
+	if c == nil || ref == "" {
+		return nil, false
+	}
+
+	// Single-document data only answers to the first index
+	if len(c.Documents) == 0 {
+		if ref == "1" {
+			return c, true
+		}
+		return nil, false
+	}
+
+	for _, doc := range c.Documents {
+		if doc.DocumentName() == ref || strconv.Itoa(doc.DocumentIndex()) == ref {
+			return doc, true
+		}
+	}
+
+	return nil, false
+}
+
+// ExpandDocuments replaces every multi-document entry with one entry per document,
+// so that rules and comparisons operate per document
+func ExpandDocuments(configs []*ConfigData) []*ConfigData {
+	expanded := make([]*ConfigData, 0, len(configs))
+	for _, config := range configs {
+		if config != nil && len(config.Documents) > 0 {
+			expanded = append(expanded, config.Documents...)
+			continue
+		}
+		expanded = append(expanded, config)
+	}
+	return expanded
+}
+
+// DocumentFilename builds the addressable name of a document (e.g. deploy.yaml#2)
+func DocumentFilename(filename, ref string) string {
+	if ref == "" {
+		return filename
+	}
+	return filename + DocumentSeparator + ref
+}
+
+// SplitDocumentFilename splits an addressable name into filename and document reference
+func SplitDocumentFilename(name string) (string, string) {
+	index := strings.LastIndex(name, DocumentSeparator)
+	if index == -1 {
+		return name, ""
+	}
+	return name[:index], name[index+1:]
+}
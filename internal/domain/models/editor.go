@@ -0,0 +1,25 @@
+package models
+
+import "errors"
+
+// ErrPathNotFound is returned (wrapped) by editors when a key path does not exist
+var ErrPathNotFound = errors.New("path not found")
+
+// ConfigEditor edits the source of a configuration file while preserving comments, key
+// order and formatting, so that edits produce minimal diffs. Paths use the key path syntax
+// (see ParsePath); values are plain Go values as found in ConfigData.Data.
+type ConfigEditor interface {
+	// Set replaces the value at path, creating the key (and missing parents) when needed
+	Set(path string, value interface{}) error
+	// Delete removes the key or array item at path
+	Delete(path string) error
+	// Rename renames the last key of path, keeping its value and position
+	Rename(path, newKey string) error
+	// Bytes returns the edited file content
+	Bytes() ([]byte, error)
+}
+
+// EditorResolver returns the editor for a file from its name and content
+type EditorResolver interface {
+	GetEditor(filename string, content []byte) (ConfigEditor, error)
+}
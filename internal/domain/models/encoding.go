@@ -0,0 +1,231 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encodings detected by NormalizeEncoding
+const (
+	EncodingUTF8    = "utf-8"
+	EncodingUTF16LE = "utf-16le"
+	EncodingUTF16BE = "utf-16be"
+	EncodingLatin1  = "iso-8859-1"
+)
+
+// Line ending styles detected by NormalizeEncoding
+const (
+	LineEndingsLF    = "lf"
+	LineEndingsCRLF  = "crlf"
+	LineEndingsCR    = "cr"
+	LineEndingsMixed = "mixed"
+	LineEndingsNone  = "none"
+)
+
+// EncodingInfo describes the encoding and line endings of a file before normalisation
+type EncodingInfo struct {
+	Encoding    string `json:"encoding"`
+	BOM         bool   `json:"bom"`
+	LineEndings string `json:"line_endings"`
+}
+
+// NormalizeEncoding converts file content to BOM-less UTF-8 with LF line endings.
+// UTF-16 is recognised by its BOM or, without one, by the NUL bytes of ASCII text;
+// content that is not valid UTF-8 is decoded as ISO-8859-1.
+func NormalizeEncoding(content []byte) ([]byte, EncodingInfo, error) {
+	info := EncodingInfo{Encoding: EncodingUTF8}
+
+	decoded, err := decodeContent(content, &info)
+	if err != nil {
+		return nil, info, err
+	}
+
+	info.LineEndings = detectLineEndings(decoded)
+	if info.LineEndings != LineEndingsLF && info.LineEndings != LineEndingsNone {
+		decoded = bytes.ReplaceAll(decoded, []byte("\r\n"), []byte("\n"))
+		decoded = bytes.ReplaceAll(decoded, []byte("\r"), []byte("\n"))
+	}
+
+	return decoded, info, nil
+}
+
+// EncodeContent converts normalised content (UTF-8 with LF line endings) back to the
+// encoding, byte order mark and line endings described by info. Mixed line endings are
+// written as LF.
+func EncodeContent(content []byte, info EncodingInfo) ([]byte, error) {
+	switch info.LineEndings {
+	case LineEndingsCRLF:
+		content = bytes.ReplaceAll(content, []byte("\n"), []byte("\r\n"))
+	case LineEndingsCR:
+		content = bytes.ReplaceAll(content, []byte("\n"), []byte("\r"))
+	}
+
+	var encoded []byte
+	switch info.Encoding {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		var order binary.AppendByteOrder = binary.LittleEndian
+		if info.Encoding == EncodingUTF16BE {
+			order = binary.BigEndian
+		}
+		if info.BOM {
+			encoded = order.AppendUint16(encoded, 0xFEFF)
+		}
+		for _, unit := range utf16.Encode([]rune(string(content))) {
+			encoded = order.AppendUint16(encoded, unit)
+		}
+	case EncodingLatin1:
+		for _, r := range string(content) {
+			if r > 0xFF {
+				return nil, fmt.Errorf("character %q cannot be encoded as %s", r, EncodingLatin1)
+			}
+			encoded = append(encoded, byte(r))
+		}
+	default:
+		if info.BOM {
+			encoded = append(encoded, 0xEF, 0xBB, 0xBF)
+		}
+		encoded = append(encoded, content...)
+	}
+
+	return encoded, nil
+}
+
+// ProcessNormalized normalises the encoding of content, processes it into the canonical
+// tree (see Normalize) and records the detected encoding in the metadata ("encoding",
+// "bom", "line_endings") of the result and of each of its documents
+func ProcessNormalized(ctx context.Context, processor FileProcessor, filename string, content []byte) (*ConfigData, error) {
+	normalized, info, err := NormalizeEncoding(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filename, err)
+	}
+
+	configData, err := processor.Process(ctx, filename, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	configData.Normalize()
+	info.Record(configData)
+	for _, document := range configData.Documents {
+		info.Record(document)
+	}
+
+	return configData, nil
+}
+
+// Record stores the encoding information in the metadata of config data
+func (i EncodingInfo) Record(data *ConfigData) {
+	// Guard clause: nothing to record into
+	if data == nil {
+		return
+	}
+
+	if data.Metadata == nil {
+		data.Metadata = make(map[string]interface{})
+	}
+	data.Metadata["encoding"] = i.Encoding
+	data.Metadata["bom"] = i.BOM
+	data.Metadata["line_endings"] = i.LineEndings
+}
+
+// EncodingOf returns the encoding information recorded in config data metadata
+func EncodingOf(data *ConfigData) (EncodingInfo, bool) {
+	if data == nil || data.Metadata == nil {
+		return EncodingInfo{}, false
+	}
+
+	encoding, ok := data.Metadata["encoding"].(string)
+	if !ok {
+		return EncodingInfo{}, false
+	}
+	bom, _ := data.Metadata["bom"].(bool)
+	lineEndings, _ := data.Metadata["line_endings"].(string)
+
+	return EncodingInfo{Encoding: encoding, BOM: bom, LineEndings: lineEndings}, true
+}
+
+// decodeContent decodes content to UTF-8, filling in the detected encoding and BOM
+func decodeContent(content []byte, info *EncodingInfo) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xEF, 0xBB, 0xBF}):
+		info.BOM = true
+		content = content[3:]
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		info.Encoding, info.BOM = EncodingUTF16LE, true
+		return decodeUTF16(content[2:], binary.LittleEndian)
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		info.Encoding, info.BOM = EncodingUTF16BE, true
+		return decodeUTF16(content[2:], binary.BigEndian)
+	case len(content) >= 2 && content[0] != 0 && content[1] == 0:
+		info.Encoding = EncodingUTF16LE
+		return decodeUTF16(content, binary.LittleEndian)
+	case len(content) >= 2 && content[0] == 0 && content[1] != 0:
+		info.Encoding = EncodingUTF16BE
+		return decodeUTF16(content, binary.BigEndian)
+	}
+
+	if utf8.Valid(content) {
+		return content, nil
+	}
+
+	info.Encoding = EncodingLatin1
+	decoded := make([]rune, len(content))
+	for i, b := range content {
+		decoded[i] = rune(b)
+	}
+	return []byte(string(decoded)), nil
+}
+
+// decodeUTF16 decodes UTF-16 content in the given byte order
+func decodeUTF16(content []byte, order binary.ByteOrder) ([]byte, error) {
+	// Guard clause: UTF-16 content is made of 2-byte units
+	if len(content)%2 != 0 {
+		return nil, fmt.Errorf("invalid UTF-16 content: odd number of bytes")
+	}
+
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[2*i:])
+	}
+
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+// detectLineEndings reports the line ending style of decoded content
+func detectLineEndings(content []byte) string {
+	var lf, crlf, cr int
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '\n':
+			lf++
+		case '\r':
+			if i+1 < len(content) && content[i+1] == '\n' {
+				crlf++
+				i++
+			} else {
+				cr++
+			}
+		}
+	}
+
+	styles := 0
+	style := LineEndingsNone
+	for _, candidate := range []struct {
+		count int
+		name  string
+	}{{lf, LineEndingsLF}, {crlf, LineEndingsCRLF}, {cr, LineEndingsCR}} {
+		if candidate.count > 0 {
+			styles++
+			style = candidate.name
+		}
+	}
+
+	if styles > 1 {
+		return LineEndingsMixed
+	}
+	return style
+}
@@ -0,0 +1,31 @@
+package models
+
+// FixAction is the kind of change a fix makes
+type FixAction string
+
+const (
+	// FixSet sets Path to Value, creating the key when it is missing
+	FixSet FixAction = "set"
+	// FixDelete removes Path
+	FixDelete FixAction = "delete"
+	// FixRename renames the last key of Path to NewKey
+	FixRename FixAction = "rename"
+	// FixManual describes a change that cannot be applied safely and must be made by hand
+	FixManual FixAction = "manual"
+)
+
+// Fix is the remediation a rule declares for an issue it reports (see ValidationError.Fix).
+// File is the file to edit; when empty it is the file of the issue.
+type Fix struct {
+	Action      FixAction   `json:"action"`
+	File        string      `json:"file,omitempty"`
+	Path        string      `json:"path,omitempty"`
+	Value       interface{} `json:"value,omitempty"`
+	NewKey      string      `json:"new_key,omitempty"`
+	Description string      `json:"description"`
+}
+
+// IsManual checks if a fix has to be applied by hand
+func (f Fix) IsManual() bool {
+	return f.Action == FixManual
+}
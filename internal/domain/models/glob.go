@@ -0,0 +1,47 @@
+package models
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MatchGlob checks if a slash-separated path matches a glob pattern. Patterns without a
+// "/" match the base name, and "**" matches any number of directories.
+func MatchGlob(pattern, name string) bool {
+	name = strings.TrimPrefix(filepath.ToSlash(name), "./")
+	pattern = strings.TrimPrefix(pattern, "./")
+
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(name))
+		return matched
+	}
+	return MatchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"), MatchSegment)
+}
+
+// MatchSegments matches segments (directories, keys) against pattern segments. A "**"
+// pattern segment matches any number of segments; match compares the other segments.
+func MatchSegments(pattern, name []string, match func(pattern, segment string) bool) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if MatchSegments(pattern[1:], name[i:], match) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 || !match(pattern[0], name[0]) {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// MatchSegment matches one segment against a path.Match pattern
+func MatchSegment(pattern, segment string) bool {
+	matched, _ := path.Match(pattern, segment)
+	return matched
+}
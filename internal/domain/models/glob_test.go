@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+// TestMatchGlob tests glob matching with base names and ** segments
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		matched bool
+	}{
+		{pattern: "*.yaml", name: "config/app.yaml", matched: true},
+		{pattern: "config/*.yaml", name: "config/dev/app.yaml", matched: false},
+		{pattern: "config/**/*.yaml", name: "config/dev/app.yaml", matched: true},
+		{pattern: "config/**/*.yaml", name: "config/app.yaml", matched: true},
+		{pattern: "**/values.yaml", name: "./deploy/values.yaml", matched: true},
+		{pattern: "deploy/**", name: "deploy/a/b.json", matched: true},
+		{pattern: "deploy/**/b.json", name: "other/a/b.json", matched: false},
+	}
+
+	for _, tt := range tests {
+		if matched := MatchGlob(tt.pattern, tt.name); matched != tt.matched {
+			t.Errorf("MatchGlob(%q, %q) = %t, want %t", tt.pattern, tt.name, matched, tt.matched)
+		}
+	}
+}
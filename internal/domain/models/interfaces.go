@@ -20,6 +20,12 @@ type ValidationRule interface {
 	Severity() SeverityLevel
 }
 
+// CrossFileRule defines a validation rule that compares a set of files (e.g. environments)
+type CrossFileRule interface {
+	ValidationRule
+	ValidateFiles(files []*ConfigData) ValidationResult
+}
+
 // AuditEngine defines the interface for audit engines
 type AuditEngine interface {
 	Type() AuditType
@@ -34,48 +40,91 @@ type OutputFormatter interface {
 	SupportsFormat(format string) bool
 }
 
-// ConfigData represents parsed configuration data
+// ConfigData represents parsed configuration data.
+// Documents is only set for multi-document files (e.g. YAML streams separated by ---).
+// Positions maps key paths (see JoinPath) to their location in the source file.
+// Comments holds source comments for formats that preserve them (e.g. JSONC).
 type ConfigData struct {
 	Filename  string                 `json:"filename"`
 	Format    string                 `json:"format"`
 	Data      map[string]interface{} `json:"data"`
 	Metadata  map[string]interface{} `json:"metadata"`
 	Timestamp time.Time              `json:"timestamp"`
+	Documents []*ConfigData          `json:"documents,omitempty"`
+	Positions map[string]Position    `json:"positions,omitempty"`
+	Comments  []Comment              `json:"comments,omitempty"`
+}
+
+// Comment represents a source comment. Path is the key the comment documents: the key on
+// the same line for trailing comments, otherwise the next key (empty at the end of a file).
+type Comment struct {
+	Text     string   `json:"text"`
+	Path     string   `json:"path,omitempty"`
+	Position Position `json:"position"`
+}
+
+// MergeOverride records a key that overrides a key inherited through a YAML merge key (<<).
+// Inherited is the value the key would have had without the override.
+type MergeOverride struct {
+	Path      string      `json:"path"`
+	Anchor    string      `json:"anchor,omitempty"`
+	Position  Position    `json:"position"`
+	Value     interface{} `json:"value"`
+	Inherited interface{} `json:"inherited"`
+}
+
+// TerraformVariable is a variable declared in a Terraform variables.tf file.
+// Type holds the source text of the type constraint ("" when unconstrained) and Required
+// is set for variables without a default.
+type TerraformVariable struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type,omitempty"`
+	Required bool     `json:"required"`
+	File     string   `json:"file"`
+	Position Position `json:"position"`
+}
+
+// Position represents a 1-based line/column location in a source file
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
 }
 
 // ValidationResult represents the result of a validation
 type ValidationResult struct {
-	Success      bool                    `json:"success"`
-	Errors       []ValidationError       `json:"errors,omitempty"`
-	Warnings     []ValidationWarning     `json:"warnings,omitempty"`
-	Summary      ValidationSummary       `json:"summary"`
-	Metadata     map[string]interface{}  `json:"metadata,omitempty"`
-	Timestamp    time.Time               `json:"timestamp"`
-	Duration     time.Duration           `json:"duration"`
+	Success   bool                   `json:"success"`
+	Errors    []ValidationError      `json:"errors,omitempty"`
+	Warnings  []ValidationWarning    `json:"warnings,omitempty"`
+	Summary   ValidationSummary      `json:"summary"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Duration  time.Duration          `json:"duration"`
 }
 
 // ValidationError represents a validation error
 type ValidationError struct {
-	Code        string `json:"code"`
-	Message     string `json:"message"`
-	Key         string `json:"key,omitempty"`
-	Value       string `json:"value,omitempty"`
-	Severity    SeverityLevel `json:"severity"`
-	File        string `json:"file,omitempty"`
-	Line        int    `json:"line,omitempty"`
-	Column      int    `json:"column,omitempty"`
+	Code     string        `json:"code"`
+	Message  string        `json:"message"`
+	Key      string        `json:"key,omitempty"`
+	Value    string        `json:"value,omitempty"`
+	Severity SeverityLevel `json:"severity"`
+	File     string        `json:"file,omitempty"`
+	Line     int           `json:"line,omitempty"`
+	Column   int           `json:"column,omitempty"`
+	Fix      *Fix          `json:"fix,omitempty"`
 }
 
 // ValidationWarning represents a validation warning
 type ValidationWarning struct {
-	Code        string `json:"code"`
-	Message     string `json:"message"`
-	Key         string `json:"key,omitempty"`
-	Value       string `json:"value,omitempty"`
-	Severity    SeverityLevel `json:"severity"`
-	File        string `json:"file,omitempty"`
-	Line        int    `json:"line,omitempty"`
-	Column      int    `json:"column,omitempty"`
+	Code     string        `json:"code"`
+	Message  string        `json:"message"`
+	Key      string        `json:"key,omitempty"`
+	Value    string        `json:"value,omitempty"`
+	Severity SeverityLevel `json:"severity"`
+	File     string        `json:"file,omitempty"`
+	Line     int           `json:"line,omitempty"`
+	Column   int           `json:"column,omitempty"`
+	Fix      *Fix          `json:"fix,omitempty"`
 }
 
 // ValidationSummary represents validation summary statistics
@@ -90,38 +139,38 @@ type ValidationSummary struct {
 
 // AuditResult represents the result of an audit
 type AuditResult struct {
-	Type        AuditType              `json:"type"`
-	Success     bool                   `json:"success"`
-	Score       float64                `json:"score"`
-	Grade       string                 `json:"grade"`
-	Issues      []AuditIssue           `json:"issues,omitempty"`
-	Metrics     AuditMetrics           `json:"metrics"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	Timestamp   time.Time              `json:"timestamp"`
-	Duration    time.Duration          `json:"duration"`
+	Type      AuditType              `json:"type"`
+	Success   bool                   `json:"success"`
+	Score     float64                `json:"score"`
+	Grade     string                 `json:"grade"`
+	Issues    []AuditIssue           `json:"issues,omitempty"`
+	Metrics   AuditMetrics           `json:"metrics"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Duration  time.Duration          `json:"duration"`
 }
 
 // AuditIssue represents an audit issue
 type AuditIssue struct {
-	Type        string        `json:"type"`
-	Severity    SeverityLevel `json:"severity"`
-	Title       string        `json:"title"`
-	Description string        `json:"description"`
-	File        string        `json:"file,omitempty"`
-	Line        int           `json:"line,omitempty"`
-	Column      int           `json:"column,omitempty"`
-	Recommendation string     `json:"recommendation,omitempty"`
+	Type           string        `json:"type"`
+	Severity       SeverityLevel `json:"severity"`
+	Title          string        `json:"title"`
+	Description    string        `json:"description"`
+	File           string        `json:"file,omitempty"`
+	Line           int           `json:"line,omitempty"`
+	Column         int           `json:"column,omitempty"`
+	Recommendation string        `json:"recommendation,omitempty"`
 }
 
 // AuditMetrics represents audit metrics
 type AuditMetrics struct {
-	TotalChecks     int     `json:"total_checks"`
-	PassedChecks    int     `json:"passed_checks"`
-	FailedChecks    int     `json:"failed_checks"`
-	WarningChecks   int     `json:"warning_checks"`
-	CriticalIssues  int     `json:"critical_issues"`
-	SecurityIssues  int     `json:"security_issues"`
-	ComplianceIssues int    `json:"compliance_issues"`
+	TotalChecks      int     `json:"total_checks"`
+	PassedChecks     int     `json:"passed_checks"`
+	FailedChecks     int     `json:"failed_checks"`
+	WarningChecks    int     `json:"warning_checks"`
+	CriticalIssues   int     `json:"critical_issues"`
+	SecurityIssues   int     `json:"security_issues"`
+	ComplianceIssues int     `json:"compliance_issues"`
 	PerformanceScore float64 `json:"performance_score"`
 }
 
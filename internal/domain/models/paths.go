@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Key path syntax: object keys are separated by dots and array indexes are written
+// in brackets (e.g. servers[0].host). Dots, brackets and backslashes that are part of
+// a key are escaped with a backslash (e.g. logging.level\.root).
+
+// pathKeyEscaper escapes the characters that have a meaning in key paths
+var pathKeyEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`, `[`, `\[`, `]`, `\]`)
+
+// EscapePathKey escapes a single object key for use in a key path
+func EscapePathKey(key string) string {
+	return pathKeyEscaper.Replace(key)
+}
+
+// JoinPath appends an object key to a key path
+func JoinPath(parent, key string) string {
+	escaped := EscapePathKey(key)
+	if parent == "" {
+		return escaped
+	}
+	return parent + "." + escaped
+}
+
+// IndexPath appends an array index to a key path
+func IndexPath(parent string, index int) string {
+	return parent + "[" + strconv.Itoa(index) + "]"
+}
+
+// ConcatPath appends an already escaped key path below a parent path
+func ConcatPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// PositionOf returns the source position recorded for a key path
+func (c *ConfigData) PositionOf(path string) (Position, bool) {
+	if c == nil || c.Positions == nil {
+		return Position{}, false
+	}
+	position, ok := c.Positions[path]
+	return position, ok
+}
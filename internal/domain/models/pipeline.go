@@ -27,6 +27,11 @@ type FileProcessor interface {
 	GetSupportedExtensions() []string
 }
 
+// ProcessorResolver selects the processor for a file from its name and content
+type ProcessorResolver interface {
+	DetectProcessor(filename string, content []byte) (FileProcessor, error)
+}
+
 // Pipeline defines the interface for file processing pipeline
 type Pipeline interface {
 	ProcessFiles(ctx context.Context, filenames []string) ([]*ConfigData, error)
@@ -37,12 +42,12 @@ type Pipeline interface {
 
 // PipelineResult represents the result of pipeline processing
 type PipelineResult struct {
-	Success      bool                   `json:"success"`
-	Processed    []*ConfigData          `json:"processed"`
-	Failed       []*ProcessingError     `json:"failed,omitempty"`
-	Summary      PipelineSummary        `json:"summary"`
-	Duration     time.Duration          `json:"duration"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Success   bool                   `json:"success"`
+	Processed []*ConfigData          `json:"processed"`
+	Failed    []*ProcessingError     `json:"failed,omitempty"`
+	Summary   PipelineSummary        `json:"summary"`
+	Duration  time.Duration          `json:"duration"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // ProcessingError represents an error during file processing
@@ -54,10 +59,10 @@ type ProcessingError struct {
 
 // PipelineSummary represents pipeline processing summary
 type PipelineSummary struct {
-	TotalFiles    int `json:"total_files"`
-	Processed     int `json:"processed"`
-	Failed        int `json:"failed"`
-	ByFormat      map[string]int `json:"by_format"`
+	TotalFiles int            `json:"total_files"`
+	Processed  int            `json:"processed"`
+	Failed     int            `json:"failed"`
+	ByFormat   map[string]int `json:"by_format"`
 }
 
 // PipelineConfig represents pipeline configuration
@@ -0,0 +1,445 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SkipChildren can be returned by a WalkFunc to skip the children of a map or list
+var SkipChildren = errors.New("skip children")
+
+// WalkFunc is called for every node of a configuration tree. Returning SkipChildren
+// skips the children of the node; any other error stops the walk.
+type WalkFunc func(path Path, value interface{}) error
+
+// PathSegment is one step of a key path: an object key or an array index
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// KeySegment creates an object key segment
+func KeySegment(key string) PathSegment {
+	return PathSegment{Key: key}
+}
+
+// IndexSegment creates an array index segment
+func IndexSegment(index int) PathSegment {
+	return PathSegment{Index: index, IsIndex: true}
+}
+
+// Path is a typed key path
+type Path []PathSegment
+
+// ParsePath parses a key path written in the key path syntax (e.g. servers[0].host or
+// logging.level\.root)
+func ParsePath(path string) (Path, error) {
+	var parsed Path
+	var key strings.Builder
+	pendingKey, afterIndex := false, false
+
+	flushKey := func() {
+		if pendingKey {
+			parsed = append(parsed, KeySegment(key.String()))
+			key.Reset()
+			pendingKey = false
+		}
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\\':
+			if afterIndex {
+				return nil, fmt.Errorf("invalid path %q: missing '.' after index at offset %d", path, i)
+			}
+			if i+1 >= len(path) {
+				return nil, fmt.Errorf("invalid path %q: trailing backslash", path)
+			}
+			i++
+			key.WriteByte(path[i])
+			pendingKey = true
+		case '.':
+			if !pendingKey && (i == 0 || path[i-1] != ']') {
+				return nil, fmt.Errorf("invalid path %q: empty key at offset %d", path, i)
+			}
+			flushKey()
+			afterIndex = false
+			if i+1 >= len(path) {
+				return nil, fmt.Errorf("invalid path %q: empty key at the end", path)
+			}
+		case '[':
+			flushKey()
+			end := strings.IndexByte(path[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: unterminated index", path)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, path[i+1:i+end])
+			}
+			parsed = append(parsed, IndexSegment(index))
+			afterIndex = true
+			i += end
+		case ']':
+			return nil, fmt.Errorf("invalid path %q: unexpected ']' at offset %d", path, i)
+		default:
+			if afterIndex {
+				return nil, fmt.Errorf("invalid path %q: missing '.' after index at offset %d", path, i)
+			}
+			key.WriteByte(c)
+			pendingKey = true
+		}
+	}
+	flushKey()
+
+	return parsed, nil
+}
+
+// String formats the path in the key path syntax
+func (p Path) String() string {
+	var path string
+	for _, segment := range p {
+		if segment.IsIndex {
+			path = IndexPath(path, segment.Index)
+		} else {
+			path = JoinPath(path, segment.Key)
+		}
+	}
+	return path
+}
+
+// Dotted joins the keys of the path with dots and writes indexes as [i], without
+// escaping. A flat key "database.host" and the nested keys database, host give the same
+// dotted key.
+func (p Path) Dotted() string {
+	var builder strings.Builder
+	for i, segment := range p {
+		if segment.IsIndex {
+			builder.WriteString("[" + strconv.Itoa(segment.Index) + "]")
+			continue
+		}
+		if i > 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(segment.Key)
+	}
+	return builder.String()
+}
+
+// Key returns a copy of the path extended with an object key
+func (p Path) Key(key string) Path {
+	return append(append(Path{}, p...), KeySegment(key))
+}
+
+// Index returns a copy of the path extended with an array index
+func (p Path) Index(index int) Path {
+	return append(append(Path{}, p...), IndexSegment(index))
+}
+
+// Normalize converts Data (and the data of every document) into the canonical tree:
+// maps become map[string]interface{}, slices []interface{}, integers int and floats float64
+func (c *ConfigData) Normalize() {
+	// Guard clause: nothing to normalize
+	if c == nil {
+		return
+	}
+
+	if c.Data != nil {
+		c.Data = NormalizeValue(c.Data).(map[string]interface{})
+	}
+	for _, document := range c.Documents {
+		document.Normalize()
+	}
+}
+
+// Get returns the value at a key path; the empty path returns Data
+func (c *ConfigData) Get(path string) (interface{}, bool) {
+	parsed, err := ParsePath(path)
+	if err != nil || c == nil {
+		return nil, false
+	}
+
+	var node interface{} = c.Data
+	for _, segment := range parsed {
+		child, ok := childNode(node, segment)
+		if !ok {
+			return nil, false
+		}
+		node = child
+	}
+
+	return node, true
+}
+
+// Set stores a value at a key path, creating missing maps (and lists for index segments).
+// An index may address an existing element or append one at the end of a list.
+func (c *ConfigData) Set(path string, value interface{}) error {
+	parsed, err := ParsePath(path)
+	if err != nil {
+		return err
+	}
+
+	// Guard clause: the root must be a map
+	if len(parsed) == 0 || parsed[0].IsIndex {
+		return fmt.Errorf("invalid path %q: must start with a key", path)
+	}
+
+	if c.Data == nil {
+		c.Data = make(map[string]interface{})
+	}
+
+	updated, err := setNode(c.Data, parsed, NormalizeValue(value), nil)
+	if err != nil {
+		return fmt.Errorf("cannot set %q: %w", path, err)
+	}
+	c.Data = updated.(map[string]interface{})
+	return nil
+}
+
+// Walk visits every node below Data depth-first, maps in sorted key order
+func (c *ConfigData) Walk(fn WalkFunc) error {
+	// Guard clause: nothing to walk
+	if c == nil || c.Data == nil {
+		return nil
+	}
+
+	err := walkNode(Path{}, c.Data, fn, true)
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+	return err
+}
+
+// Flatten returns every leaf value by key path. Empty maps and lists are leaves.
+func (c *ConfigData) Flatten() map[string]interface{} {
+	flat := make(map[string]interface{})
+	_ = c.Walk(func(path Path, value interface{}) error {
+		if len(path) > 0 && isLeaf(value) {
+			flat[path.String()] = value
+		}
+		return nil
+	})
+	return flat
+}
+
+// Paths returns the sorted key paths of every leaf value
+func (c *ConfigData) Paths() []string {
+	flat := c.Flatten()
+	paths := make([]string, 0, len(flat))
+	for path := range flat {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// NormalizeValue converts a decoded value into the canonical tree representation
+func NormalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, string, bool, int, float64:
+		return v
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = NormalizeValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = NormalizeValue(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprint(key)] = NormalizeValue(item)
+		}
+		return normalized
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return normalizeInteger(v)
+	case float32:
+		return float64(v)
+	}
+
+	// Other maps and slices (map[string]string, []map[string]interface{}, ...)
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		normalized := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			normalized[fmt.Sprint(iter.Key().Interface())] = NormalizeValue(iter.Value().Interface())
+		}
+		return normalized
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return value
+		}
+		normalized := make([]interface{}, rv.Len())
+		for i := range normalized {
+			normalized[i] = NormalizeValue(rv.Index(i).Interface())
+		}
+		return normalized
+	}
+
+	return value
+}
+
+// normalizeInteger converts sized integers to int when they fit
+func normalizeInteger(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := rv.Uint(); u <= uint64(^uint(0)>>1) {
+			return int(u)
+		}
+		return value
+	}
+
+	i := rv.Int()
+	if int64(int(i)) == i {
+		return int(i)
+	}
+	return value
+}
+
+// canonicalNode converts a node that is not yet in the canonical representation.
+// Canonical maps and lists are returned as they are, without visiting their children.
+func canonicalNode(node interface{}) interface{} {
+	switch node.(type) {
+	case map[string]interface{}, []interface{}:
+		return node
+	}
+	return NormalizeValue(node)
+}
+
+// childNode returns the child of a map or list node
+func childNode(node interface{}, segment PathSegment) (interface{}, bool) {
+	node = canonicalNode(node)
+
+	if segment.IsIndex {
+		items, ok := node.([]interface{})
+		if !ok || segment.Index >= len(items) {
+			return nil, false
+		}
+		return items[segment.Index], true
+	}
+
+	entries, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	child, exists := entries[segment.Key]
+	return child, exists
+}
+
+// setNode stores value below node and returns the (possibly new) node
+func setNode(node interface{}, path Path, value interface{}, walked Path) (interface{}, error) {
+	// Guard clause: reached the target
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	segment := path[0]
+	walked = append(walked, segment)
+	node = canonicalNode(node)
+
+	if segment.IsIndex {
+		if node == nil {
+			node = []interface{}{}
+		}
+		items, ok := node.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not a list", Path(walked[:len(walked)-1]).String())
+		}
+		if segment.Index > len(items) {
+			return nil, fmt.Errorf("index %d is out of range (list has %d elements)", segment.Index, len(items))
+		}
+
+		var child interface{}
+		if segment.Index < len(items) {
+			child = items[segment.Index]
+		}
+		updated, err := setNode(child, path[1:], value, walked)
+		if err != nil {
+			return nil, err
+		}
+		if segment.Index == len(items) {
+			return append(items, updated), nil
+		}
+		items[segment.Index] = updated
+		return items, nil
+	}
+
+	if node == nil {
+		node = make(map[string]interface{})
+	}
+	entries, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not a map", Path(walked[:len(walked)-1]).String())
+	}
+
+	updated, err := setNode(entries[segment.Key], path[1:], value, walked)
+	if err != nil {
+		return nil, err
+	}
+	entries[segment.Key] = updated
+	return entries, nil
+}
+
+// walkNode calls fn for a node and its children
+func walkNode(path Path, node interface{}, fn WalkFunc, root bool) error {
+	node = canonicalNode(node)
+
+	if !root {
+		if err := fn(path, node); err != nil {
+			return err
+		}
+	}
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := walkChild(path.Key(key), v[key], fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if err := walkChild(path.Index(i), item, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkChild walks a child node, absorbing SkipChildren returned for it
+func walkChild(path Path, node interface{}, fn WalkFunc) error {
+	err := walkNode(path, node, fn, false)
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+	return err
+}
+
+// isLeaf reports whether a value is a scalar, an empty map or an empty list
+func isLeaf(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParsePath tests typed segments and escaping
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Path
+	}{
+		{path: "", expected: nil},
+		{path: "server.port", expected: Path{KeySegment("server"), KeySegment("port")}},
+		{path: "servers[0].host", expected: Path{KeySegment("servers"), IndexSegment(0), KeySegment("host")}},
+		{path: "matrix[1][2]", expected: Path{KeySegment("matrix"), IndexSegment(1), IndexSegment(2)}},
+		{path: `logging.level\.root`, expected: Path{KeySegment("logging"), KeySegment("level.root")}},
+		{path: `weird\[0\]\\key`, expected: Path{KeySegment(`weird[0]\key`)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			parsed, err := ParsePath(tt.path)
+			if err != nil {
+				t.Fatalf("ParsePath() error = %v", err)
+			}
+			if !reflect.DeepEqual(parsed, tt.expected) {
+				t.Errorf("ParsePath() = %#v, want %#v", parsed, tt.expected)
+			}
+			if parsed.String() != tt.path {
+				t.Errorf("String() = %q, want %q", parsed.String(), tt.path)
+			}
+		})
+	}
+
+	for _, invalid := range []string{".a", "a..b", "a.", "a[x]", "a[-1]", "a[0", "a]", "a[0]b", `a\`} {
+		if _, err := ParsePath(invalid); err == nil {
+			t.Errorf("ParsePath(%q) should fail", invalid)
+		}
+	}
+}
+
+// TestConfigDataTree tests Get, Set, Walk, Flatten and Paths on a mixed tree
+func TestConfigDataTree(t *testing.T) {
+	data := &ConfigData{Data: map[string]interface{}{
+		"server": map[interface{}]interface{}{"port": int64(8080), "hosts": []string{"a", "b"}},
+		"logging": map[string]interface{}{
+			"level.root": "INFO",
+		},
+		"empty":   map[string]interface{}{},
+		"timeout": 5 * time.Second,
+		"ratio":   float32(0.5),
+	}}
+
+	if value, ok := data.Get("server.port"); !ok || value != 8080 {
+		t.Errorf("Get(server.port) = %#v, %v, want 8080", value, ok)
+	}
+	if value, ok := data.Get("server.hosts[1]"); !ok || value != "b" {
+		t.Errorf("Get(server.hosts[1]) = %#v, %v, want b", value, ok)
+	}
+	if value, ok := data.Get(`logging.level\.root`); !ok || value != "INFO" {
+		t.Errorf("Get(logging.level\\.root) = %#v, %v, want INFO", value, ok)
+	}
+	for _, missing := range []string{"server.hosts[2]", "server.port.x", "nope", "server[0]"} {
+		if _, ok := data.Get(missing); ok {
+			t.Errorf("Get(%s) should not find a value", missing)
+		}
+	}
+
+	expected := map[string]interface{}{
+		"server.port":         8080,
+		"server.hosts[0]":     "a",
+		"server.hosts[1]":     "b",
+		`logging.level\.root`: "INFO",
+		"empty":               map[string]interface{}{},
+		"timeout":             5 * time.Second,
+		"ratio":               0.5,
+	}
+	if flat := data.Flatten(); !reflect.DeepEqual(flat, expected) {
+		t.Errorf("Flatten() = %#v, want %#v", flat, expected)
+	}
+
+	paths := []string{"empty", `logging.level\.root`, "ratio", "server.hosts[0]", "server.hosts[1]", "server.port", "timeout"}
+	if got := data.Paths(); !reflect.DeepEqual(got, paths) {
+		t.Errorf("Paths() = %v, want %v", got, paths)
+	}
+
+	var visited []string
+	err := data.Walk(func(path Path, value interface{}) error {
+		visited = append(visited, path.String())
+		if path.String() == "server" {
+			return SkipChildren
+		}
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(visited, []string{"empty", "logging", `logging.level\.root`, "ratio", "server", "timeout"}) {
+		t.Errorf("Walk() visited %v (error %v)", visited, err)
+	}
+
+	stop := errors.New("stop")
+	if err := data.Walk(func(Path, interface{}) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("Walk() error = %v, want stop", err)
+	}
+
+	sets := []struct {
+		path  string
+		value interface{}
+	}{
+		{path: "server.port", value: 9090},
+		{path: "server.hosts[2]", value: "c"},
+		{path: "database.replicas[0].host", value: "db1"},
+		{path: `feature\.flags.beta`, value: true},
+	}
+	for _, set := range sets {
+		if err := data.Set(set.path, set.value); err != nil {
+			t.Fatalf("Set(%s) error = %v", set.path, err)
+		}
+		if value, ok := data.Get(set.path); !ok || !reflect.DeepEqual(value, set.value) {
+			t.Errorf("Get(%s) after Set = %#v, want %#v", set.path, value, set.value)
+		}
+	}
+
+	for _, invalid := range []string{"server.hosts[5]", "server.port.x", "[0]", ""} {
+		if err := data.Set(invalid, 1); err == nil {
+			t.Errorf("Set(%q) should fail", invalid)
+		}
+	}
+
+	data.Normalize()
+	if _, ok := data.Data["server"].(map[string]interface{}); !ok {
+		t.Errorf("Normalize() server = %T, want map[string]interface{}", data.Data["server"])
+	}
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// EncodingConsistencyRule enforces one encoding and line-ending style across files.
+// Empty options expect the style used by most of the validated files.
+type EncodingConsistencyRule struct {
+	options EncodingConsistencyOptions
+}
+
+// EncodingConsistencyOptions configures the expected style
+type EncodingConsistencyOptions struct {
+	// Encoding is the required encoding (e.g. "utf-8"); a BOM is only allowed when AllowBOM is set
+	Encoding string
+	// LineEndings is the required line-ending style ("lf" or "crlf")
+	LineEndings string
+	// AllowBOM accepts a byte order mark
+	AllowBOM bool
+}
+
+// NewEncodingConsistencyRule creates a rule that expects the majority style
+func NewEncodingConsistencyRule() *EncodingConsistencyRule {
+	return NewEncodingConsistencyRuleWithOptions(EncodingConsistencyOptions{})
+}
+
+// NewEncodingConsistencyRuleWithOptions creates a rule with an explicit expected style
+func NewEncodingConsistencyRuleWithOptions(options EncodingConsistencyOptions) *EncodingConsistencyRule {
+	return &EncodingConsistencyRule{options: options}
+}
+
+// ID returns the rule identifier
+func (r *EncodingConsistencyRule) ID() string {
+	return "encoding-consistency"
+}
+
+// Name returns the rule name
+func (r *EncodingConsistencyRule) Name() string {
+	return "Encoding consistency"
+}
+
+// Description returns the rule description
+func (r *EncodingConsistencyRule) Description() string {
+	return "Enforces one encoding and line-ending style across environments"
+}
+
+// Severity returns the rule severity
+func (r *EncodingConsistencyRule) Severity() models.SeverityLevel {
+	return models.SeverityMedium
+}
+
+// Validate checks a single file against the configured style; without configured options
+// only mixed line endings are reported
+func (r *EncodingConsistencyRule) Validate(data *models.ConfigData) models.ValidationResult {
+	return r.ValidateFiles([]*models.ConfigData{data})
+}
+
+// ValidateFiles checks that every file uses the expected encoding and line endings
+func (r *EncodingConsistencyRule) ValidateFiles(files []*models.ConfigData) models.ValidationResult {
+	result := models.ValidationResult{Success: true, Timestamp: time.Now()}
+
+	var infos []models.EncodingInfo
+	var recorded []*models.ConfigData
+	for _, data := range files {
+		if info, ok := models.EncodingOf(data); ok {
+			infos = append(infos, info)
+			recorded = append(recorded, data)
+		}
+	}
+
+	// Guard clause: nothing to compare
+	if len(infos) == 0 {
+		return result
+	}
+
+	encoding := r.options.Encoding
+	if encoding == "" {
+		encoding = majority(infos, func(info models.EncodingInfo) string { return info.Encoding })
+	}
+	lineEndings := r.options.LineEndings
+	if lineEndings == "" {
+		lineEndings = majority(infos, func(info models.EncodingInfo) string { return info.LineEndings })
+	}
+	allowBOM, requireBOM := r.options.AllowBOM, false
+	if r.options.Encoding == "" && !allowBOM {
+		requireBOM = majority(infos, func(info models.EncodingInfo) string { return fmt.Sprint(info.BOM) }) == "true"
+		allowBOM = requireBOM
+	}
+
+	for i, info := range infos {
+		filename := recorded[i].Filename
+
+		if info.Encoding != encoding {
+			result.Errors = append(result.Errors, r.error("ENCODING_MISMATCH", filename, info.Encoding,
+				fmt.Sprintf("file is encoded as %s, expected %s", info.Encoding, encoding),
+				"re-save the file as "+encoding))
+		} else if info.BOM && !allowBOM {
+			result.Errors = append(result.Errors, r.error("ENCODING_BOM", filename, info.Encoding,
+				"file starts with a byte order mark", "re-save the file without a byte order mark"))
+		} else if !info.BOM && requireBOM {
+			result.Errors = append(result.Errors, r.error("ENCODING_BOM", filename, info.Encoding,
+				"file has no byte order mark while other files do", "re-save the file with a byte order mark"))
+		}
+
+		switch {
+		case info.LineEndings == models.LineEndingsMixed:
+			result.Errors = append(result.Errors, r.error("LINE_ENDINGS_MIXED", filename, info.LineEndings,
+				"file mixes line-ending styles", "convert the line endings to "+lineEndings))
+		case info.LineEndings != models.LineEndingsNone && lineEndings != models.LineEndingsMixed &&
+			lineEndings != models.LineEndingsNone && info.LineEndings != lineEndings:
+			result.Errors = append(result.Errors, r.error("LINE_ENDINGS_MISMATCH", filename, info.LineEndings,
+				fmt.Sprintf("file uses %s line endings, expected %s", info.LineEndings, lineEndings),
+				"convert the line endings to "+lineEndings))
+		}
+	}
+
+	result.Success = len(result.Errors) == 0
+	return result
+}
+
+// error builds a validation error for a file; re-encoding is left to the user
+func (r *EncodingConsistencyRule) error(code, filename, value, message, remedy string) models.ValidationError {
+	return models.ValidationError{
+		Code:     code,
+		Message:  message,
+		Value:    value,
+		Severity: r.Severity(),
+		File:     filename,
+		Fix:      &models.Fix{Action: models.FixManual, Description: remedy},
+	}
+}
+
+// majority returns the most common value, preferring the earliest on ties
+func majority(infos []models.EncodingInfo, value func(models.EncodingInfo) string) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, info := range infos {
+		v := value(info)
+		counts[v]++
+		if best == "" || counts[v] > counts[best] {
+			best = v
+		}
+	}
+	return best
+}
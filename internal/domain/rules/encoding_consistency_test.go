@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestNormalizeEncoding tests BOM, UTF-16 and line ending normalisation
+func TestNormalizeEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		expected models.EncodingInfo
+	}{
+		{name: "utf-8", content: []byte("A=1\nB=2\n"), expected: models.EncodingInfo{Encoding: "utf-8", LineEndings: "lf"}},
+		{name: "utf-8 bom crlf", content: []byte("\xEF\xBB\xBFA=1\r\nB=2\r\n"), expected: models.EncodingInfo{Encoding: "utf-8", BOM: true, LineEndings: "crlf"}},
+		{name: "utf-16le bom", content: []byte("\xFF\xFEA\x00=\x001\x00\r\x00\n\x00B\x00=\x002\x00\n\x00"), expected: models.EncodingInfo{Encoding: "utf-16le", BOM: true, LineEndings: "mixed"}},
+		{name: "utf-16be", content: []byte("\x00A\x00=\x001\x00\n\x00B\x00=\x002\x00\n"), expected: models.EncodingInfo{Encoding: "utf-16be", LineEndings: "lf"}},
+		{name: "cr", content: []byte("A=1\rB=2\r"), expected: models.EncodingInfo{Encoding: "utf-8", LineEndings: "cr"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, info, err := models.NormalizeEncoding(tt.content)
+			if err != nil {
+				t.Fatalf("NormalizeEncoding() error = %v", err)
+			}
+			if string(normalized) != "A=1\nB=2\n" {
+				t.Errorf("NormalizeEncoding() content = %q, want %q", normalized, "A=1\nB=2\n")
+			}
+			if !reflect.DeepEqual(info, tt.expected) {
+				t.Errorf("NormalizeEncoding() info = %+v, want %+v", info, tt.expected)
+			}
+		})
+	}
+
+	normalized, info, err := models.NormalizeEncoding([]byte("name=Jos\xe9\n"))
+	if err != nil || string(normalized) != "name=José\n" || info.Encoding != models.EncodingLatin1 {
+		t.Errorf("NormalizeEncoding() latin-1 = %q, %+v, %v", normalized, info, err)
+	}
+
+	if _, _, err := models.NormalizeEncoding([]byte("\xFF\xFEA")); err == nil {
+		t.Error("NormalizeEncoding() should fail on truncated UTF-16")
+	}
+}
+
+// TestEncodingConsistencyRule tests majority and configured styles across environments
+func TestEncodingConsistencyRule(t *testing.T) {
+	file := func(name string, info models.EncodingInfo) *models.ConfigData {
+		data := &models.ConfigData{Filename: name}
+		info.Record(data)
+		return data
+	}
+
+	files := []*models.ConfigData{
+		file("dev.env", models.EncodingInfo{Encoding: "utf-8", LineEndings: "lf"}),
+		file("staging.env", models.EncodingInfo{Encoding: "utf-8", LineEndings: "lf"}),
+		file("prod.env", models.EncodingInfo{Encoding: "utf-8", BOM: true, LineEndings: "crlf"}),
+		file("qa.env", models.EncodingInfo{Encoding: "utf-16le", BOM: true, LineEndings: "mixed"}),
+		{Filename: "unrecorded.env"},
+	}
+
+	codes := func(result models.ValidationResult) []string {
+		var codes []string
+		for _, validationError := range result.Errors {
+			codes = append(codes, validationError.File+":"+validationError.Code)
+		}
+		return codes
+	}
+
+	result := NewEncodingConsistencyRule().ValidateFiles(files)
+	expected := []string{"prod.env:ENCODING_BOM", "prod.env:LINE_ENDINGS_MISMATCH", "qa.env:ENCODING_MISMATCH", "qa.env:LINE_ENDINGS_MIXED"}
+	if result.Success || !reflect.DeepEqual(codes(result), expected) {
+		t.Errorf("ValidateFiles() errors = %v, want %v", codes(result), expected)
+	}
+
+	crlf := NewEncodingConsistencyRuleWithOptions(EncodingConsistencyOptions{Encoding: "utf-8", LineEndings: "crlf", AllowBOM: true})
+	result = crlf.ValidateFiles(files[:3])
+	expected = []string{"dev.env:LINE_ENDINGS_MISMATCH", "staging.env:LINE_ENDINGS_MISMATCH"}
+	if !reflect.DeepEqual(codes(result), expected) {
+		t.Errorf("ValidateFiles() with options errors = %v, want %v", codes(result), expected)
+	}
+
+	if result := NewEncodingConsistencyRule().Validate(files[2]); !result.Success {
+		t.Errorf("Validate() single file errors = %v, want none", codes(result))
+	}
+}
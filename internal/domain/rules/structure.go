@@ -0,0 +1,256 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// defaultPlaceholder is the value written for missing required keys
+const defaultPlaceholder = "CHANGE_ME"
+
+// booleanSpellings maps boolean spellings to their values
+var booleanSpellings = map[string]bool{
+	"true": true, "yes": true, "on": true, "y": true, "1": true,
+	"false": false, "no": false, "off": false, "n": false, "0": false,
+}
+
+// StructureRule checks the key structure of every file: required keys must be present,
+// forbidden keys (globs such as "*.debug") must be absent, deprecated keys should be
+// renamed and boolean keys should hold true or false rather than spellings such as "yes".
+// Every issue declares the fix `praetorian fix` applies.
+type StructureRule struct {
+	config models.StructureRules
+}
+
+// NewStructureRule creates a structure rule from the rules of a praetorian.yaml configuration
+func NewStructureRule(config models.StructureRules) *StructureRule {
+	if config.Placeholder == "" {
+		config.Placeholder = defaultPlaceholder
+	}
+	return &StructureRule{config: config}
+}
+
+// ID returns the rule identifier
+func (r *StructureRule) ID() string {
+	return "structure"
+}
+
+// Name returns the rule name
+func (r *StructureRule) Name() string {
+	return "Structure"
+}
+
+// Description returns the rule description
+func (r *StructureRule) Description() string {
+	return "Checks required, forbidden, deprecated and boolean keys"
+}
+
+// Severity returns the rule severity
+func (r *StructureRule) Severity() models.SeverityLevel {
+	return models.SeverityHigh
+}
+
+// Validate checks the keys of a file
+func (r *StructureRule) Validate(data *models.ConfigData) models.ValidationResult {
+	result := models.ValidationResult{Success: true, Timestamp: time.Now()}
+
+	// Guard clause: nothing to validate
+	if data == nil {
+		return result
+	}
+
+	keys := dottedKeys(data)
+	for _, key := range r.config.RequiredKeys {
+		if _, ok := findKey(data, keys, key); ok {
+			continue
+		}
+		result.Errors = append(result.Errors, models.ValidationError{
+			Code:     "STRUCTURE_MISSING_KEY",
+			Message:  fmt.Sprintf("required key %q is missing", key),
+			Key:      key,
+			Severity: r.Severity(),
+			File:     data.Filename,
+			Fix: &models.Fix{
+				Action:      models.FixSet,
+				Path:        key,
+				Value:       r.config.Placeholder,
+				Description: fmt.Sprintf("add %s with placeholder %q", key, r.config.Placeholder),
+			},
+		})
+	}
+
+	_ = data.Walk(func(keyPath models.Path, _ interface{}) error {
+		key := keyPath.String()
+		if !r.forbidden(key) && !r.forbidden(keyPath.Dotted()) {
+			return nil
+		}
+
+		position := data.Positions[key]
+		result.Errors = append(result.Errors, models.ValidationError{
+			Code:     "STRUCTURE_FORBIDDEN_KEY",
+			Message:  fmt.Sprintf("key %q is forbidden", key),
+			Key:      key,
+			Severity: r.Severity(),
+			File:     data.Filename,
+			Line:     position.Line,
+			Column:   position.Column,
+			Fix:      &models.Fix{Action: models.FixDelete, Path: key, Description: "remove " + key},
+		})
+
+		// Keys below a forbidden key are removed with it
+		return models.SkipChildren
+	})
+
+	for _, deprecated := range sortedKeys(r.config.DeprecatedKeys) {
+		key, ok := findKey(data, keys, deprecated)
+		if !ok {
+			continue
+		}
+		replacement := r.config.DeprecatedKeys[deprecated]
+		position := data.Positions[key]
+		result.Warnings = append(result.Warnings, models.ValidationWarning{
+			Code:     "STRUCTURE_DEPRECATED_KEY",
+			Message:  fmt.Sprintf("key %q is deprecated, use %q", key, replacement),
+			Key:      key,
+			Severity: models.SeverityLow,
+			File:     data.Filename,
+			Line:     position.Line,
+			Column:   position.Column,
+			Fix:      r.renameFix(data, keys, key, replacement),
+		})
+	}
+
+	_ = data.Walk(func(keyPath models.Path, value interface{}) error {
+		key := keyPath.String()
+		if !r.boolean(key) && !r.boolean(keyPath.Dotted()) {
+			return nil
+		}
+		if warning, ok := r.booleanWarning(data, key, value); ok {
+			result.Warnings = append(result.Warnings, warning)
+		}
+		return nil
+	})
+
+	result.Success = len(result.Errors) == 0
+	return result
+}
+
+// dottedKeys maps the dotted form of every key path of a file to the key path, so that
+// the flat key "database.host" of a properties file is found as database.host
+func dottedKeys(data *models.ConfigData) map[string]string {
+	keys := make(map[string]string)
+	_ = data.Walk(func(keyPath models.Path, _ interface{}) error {
+		dotted := keyPath.Dotted()
+		if _, exists := keys[dotted]; !exists {
+			keys[dotted] = keyPath.String()
+		}
+		return nil
+	})
+	return keys
+}
+
+// findKey returns the key path of a file that a configured key names, either as a nested
+// path or as a flat dotted key
+func findKey(data *models.ConfigData, keys map[string]string, key string) (string, bool) {
+	if _, ok := data.Get(key); ok {
+		return key, true
+	}
+
+	parsed, err := models.ParsePath(key)
+	if err != nil {
+		return "", false
+	}
+	found, ok := keys[parsed.Dotted()]
+	return found, ok
+}
+
+// forbidden checks if a key path matches a forbidden key pattern
+func (r *StructureRule) forbidden(key string) bool {
+	for _, pattern := range r.config.ForbiddenKeys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// boolean checks if a key path matches a boolean key pattern
+func (r *StructureRule) boolean(key string) bool {
+	for _, pattern := range r.config.BooleanKeys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// booleanWarning reports a boolean key holding something other than true or false. Known
+// spellings ("yes", "True", "off") are normalised; other values have to be fixed by hand.
+// Strings "true" and "false" are accepted, as formats such as .env have no booleans.
+func (r *StructureRule) booleanWarning(data *models.ConfigData, key string, value interface{}) (models.ValidationWarning, bool) {
+	text := fmt.Sprint(value)
+	if _, ok := value.(bool); ok || text == "true" || text == "false" {
+		return models.ValidationWarning{}, false
+	}
+
+	// Guard clause: mappings and lists are checked through their keys
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return models.ValidationWarning{}, false
+	}
+
+	fix := &models.Fix{Action: models.FixManual, Path: key, Description: fmt.Sprintf("change %s to true or false", key)}
+	if normalized, ok := booleanSpellings[strings.ToLower(strings.TrimSpace(text))]; ok {
+		fix = &models.Fix{
+			Action:      models.FixSet,
+			Path:        key,
+			Value:       normalized,
+			Description: fmt.Sprintf("normalise %s to %t", key, normalized),
+		}
+	}
+
+	position := data.Positions[key]
+	return models.ValidationWarning{
+		Code:     "STRUCTURE_BOOLEAN_VALUE",
+		Message:  fmt.Sprintf("key %q should be true or false, found %q", key, text),
+		Key:      key,
+		Severity: models.SeverityLow,
+		File:     data.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+		Fix:      fix,
+	}, true
+}
+
+// renameFix renames a deprecated key in place when the replacement only changes its last
+// key; moves and renames onto existing keys have to be done by hand
+func (r *StructureRule) renameFix(data *models.ConfigData, keys map[string]string, key, replacement string) *models.Fix {
+	manual := &models.Fix{Action: models.FixManual, Path: key, Description: fmt.Sprintf("move %s to %s", key, replacement)}
+
+	if _, exists := findKey(data, keys, replacement); exists {
+		manual.Description = fmt.Sprintf("merge %s into the existing %s", key, replacement)
+		return manual
+	}
+
+	from, fromErr := models.ParsePath(key)
+	to, toErr := models.ParsePath(replacement)
+	if fromErr != nil || toErr != nil || len(from) != len(to) || len(to) == 0 || to[len(to)-1].IsIndex {
+		return manual
+	}
+	for i := range from[:len(from)-1] {
+		if from[i] != to[i] {
+			return manual
+		}
+	}
+
+	return &models.Fix{
+		Action:      models.FixRename,
+		Path:        key,
+		NewKey:      to[len(to)-1].Key,
+		Description: fmt.Sprintf("rename %s to %s", key, replacement),
+	}
+}
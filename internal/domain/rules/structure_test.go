@@ -0,0 +1,173 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestStructureRule tests required, forbidden and deprecated keys and their fixes
+func TestStructureRule(t *testing.T) {
+	data := &models.ConfigData{
+		Filename: "prod.yaml",
+		Data: map[string]interface{}{
+			"database": map[string]interface{}{"host": "db", "debug": true},
+			"server":   map[string]interface{}{"hostname": "example.com", "port": 8080},
+			"api":      map[string]interface{}{"debug": map[string]interface{}{"level": 2}},
+			"legacy":   1,
+			"old_name": "x",
+			"new_name": "y",
+		},
+		Positions: map[string]models.Position{"database.debug": {Line: 3, Column: 3}},
+	}
+
+	rule := NewStructureRule(models.StructureRules{
+		RequiredKeys:  []string{"database.host", "database.port"},
+		ForbiddenKeys: []string{"*.debug"},
+		DeprecatedKeys: map[string]string{
+			"server.hostname": "server.host",
+			"legacy":          "server.legacy",
+			"old_name":        "new_name",
+			"missing":         "present",
+		},
+	})
+	result := rule.Validate(data)
+	if result.Success {
+		t.Error("Validate() should fail")
+	}
+
+	errors := map[string]*models.Fix{}
+	for _, validationError := range result.Errors {
+		errors[validationError.Code+" "+validationError.Key] = validationError.Fix
+	}
+	expectedErrors := map[string]models.Fix{
+		"STRUCTURE_MISSING_KEY database.port":    {Action: models.FixSet, Path: "database.port", Value: "CHANGE_ME"},
+		"STRUCTURE_FORBIDDEN_KEY database.debug": {Action: models.FixDelete, Path: "database.debug"},
+		"STRUCTURE_FORBIDDEN_KEY api.debug":      {Action: models.FixDelete, Path: "api.debug"},
+	}
+	if len(errors) != len(expectedErrors) {
+		t.Fatalf("Validate() errors = %+v, want %d", result.Errors, len(expectedErrors))
+	}
+	for key, expected := range expectedErrors {
+		fix := errors[key]
+		if fix == nil || fix.Action != expected.Action || fix.Path != expected.Path || fix.Value != expected.Value {
+			t.Errorf("Validate() fix for %s = %+v, want %+v", key, fix, expected)
+		}
+	}
+
+	expectedWarnings := map[string]models.FixAction{
+		"server.hostname": models.FixRename,
+		"legacy":          models.FixManual,
+		"old_name":        models.FixManual,
+	}
+	if len(result.Warnings) != len(expectedWarnings) {
+		t.Fatalf("Validate() warnings = %+v, want %d", result.Warnings, len(expectedWarnings))
+	}
+	for _, warning := range result.Warnings {
+		if warning.Fix == nil || warning.Fix.Action != expectedWarnings[warning.Key] {
+			t.Errorf("Validate() warning fix for %s = %+v, want %s", warning.Key, warning.Fix, expectedWarnings[warning.Key])
+		}
+	}
+	if fix := result.Warnings[2].Fix; fix.NewKey != "host" {
+		t.Errorf("Validate() rename fix = %+v, want new key host", fix)
+	}
+}
+
+// TestStructureRulePlaceholder tests custom placeholders for missing keys
+func TestStructureRulePlaceholder(t *testing.T) {
+	rule := NewStructureRule(models.StructureRules{RequiredKeys: []string{"token"}, Placeholder: "TODO"})
+	result := rule.Validate(&models.ConfigData{Data: map[string]interface{}{}})
+	if len(result.Errors) != 1 || result.Errors[0].Fix.Value != "TODO" {
+		t.Errorf("Validate() errors = %+v, want token set to TODO", result.Errors)
+	}
+}
+
+// TestStructureRuleFlatKeys tests the flat dotted keys of properties files without
+// expand_keys
+func TestStructureRuleFlatKeys(t *testing.T) {
+	data := &models.ConfigData{
+		Filename: "application.properties",
+		Format:   "properties",
+		Data: map[string]interface{}{
+			"database.host": "prod-db",
+			"logging.level": "DEBUG",
+			"server.name":   "web",
+		},
+	}
+
+	rule := NewStructureRule(models.StructureRules{
+		RequiredKeys:   []string{"database.host", "database.port"},
+		ForbiddenKeys:  []string{"logging.*"},
+		DeprecatedKeys: map[string]string{"server.name": "server.hostname"},
+	})
+	result := rule.Validate(data)
+
+	codes := map[string]string{}
+	for _, validationError := range result.Errors {
+		codes[validationError.Key] = validationError.Code
+	}
+	expected := map[string]string{
+		"database.port":  "STRUCTURE_MISSING_KEY",
+		`logging\.level`: "STRUCTURE_FORBIDDEN_KEY",
+	}
+	if len(codes) != len(expected) {
+		t.Fatalf("Validate() errors = %+v, want %v", result.Errors, expected)
+	}
+	for key, code := range expected {
+		if codes[key] != code {
+			t.Errorf("Validate() error for %s = %q, want %q", key, codes[key], code)
+		}
+	}
+
+	if len(result.Warnings) != 1 || result.Warnings[0].Key != `server\.name` || result.Warnings[0].Fix.Action != models.FixManual {
+		t.Errorf("Validate() warnings = %+v, want a manual fix for server\\.name", result.Warnings)
+	}
+}
+
+// TestStructureRuleBooleanKeys tests that boolean spellings are normalised and other
+// values of boolean keys are left to be fixed by hand
+func TestStructureRuleBooleanKeys(t *testing.T) {
+	data := &models.ConfigData{
+		Filename: "app.yaml",
+		Data: map[string]interface{}{
+			"cache":   map[string]interface{}{"enabled": "yes"},
+			"metrics": map[string]interface{}{"enabled": "True"},
+			"tracing": map[string]interface{}{"enabled": true},
+			"logging": map[string]interface{}{"enabled": "false"},
+			"queue":   map[string]interface{}{"enabled": "sometimes"},
+		},
+		Positions: map[string]models.Position{"cache.enabled": {Line: 2, Column: 12}},
+	}
+
+	rule := NewStructureRule(models.StructureRules{BooleanKeys: []string{"*.enabled"}})
+	result := rule.Validate(data)
+	if !result.Success {
+		t.Errorf("Validate() errors = %+v, want none", result.Errors)
+	}
+
+	fixes := map[string]models.Fix{}
+	for _, warning := range result.Warnings {
+		if warning.Code != "STRUCTURE_BOOLEAN_VALUE" || warning.Fix == nil {
+			t.Fatalf("Validate() warning = %+v, want a boolean value warning with a fix", warning)
+		}
+		fixes[warning.Key] = *warning.Fix
+	}
+
+	expected := map[string]models.Fix{
+		"cache.enabled":   {Action: models.FixSet, Path: "cache.enabled", Value: true},
+		"metrics.enabled": {Action: models.FixSet, Path: "metrics.enabled", Value: true},
+		"queue.enabled":   {Action: models.FixManual, Path: "queue.enabled"},
+	}
+	if len(fixes) != len(expected) {
+		t.Fatalf("Validate() warnings = %+v, want %v", result.Warnings, expected)
+	}
+	for key, want := range expected {
+		fix := fixes[key]
+		if fix.Action != want.Action || fix.Path != want.Path || fix.Value != want.Value {
+			t.Errorf("Validate() fix for %s = %+v, want %+v", key, fix, want)
+		}
+	}
+	if result.Warnings[0].Line != 2 {
+		t.Errorf("Validate() warning line = %d, want 2", result.Warnings[0].Line)
+	}
+}
@@ -0,0 +1,378 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// terraformType is a parsed Terraform type constraint
+// (string, number, bool, any, list(T), set(T), map(T), tuple([...]) or object({...}))
+type terraformType struct {
+	kind       string
+	elem       *terraformType
+	elems      []*terraformType
+	attributes map[string]*terraformType
+	optional   map[string]bool
+}
+
+// parseTerraformType parses the source text of a variable type constraint
+func parseTerraformType(source string) (*terraformType, error) {
+	parser := &terraformTypeParser{input: source}
+	parsed, err := parser.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %w", source, err)
+	}
+
+	parser.skipSpace()
+	if parser.pos < len(parser.input) {
+		return nil, fmt.Errorf("invalid type %q: unexpected %q", source, parser.input[parser.pos:])
+	}
+
+	return parsed, nil
+}
+
+// terraformTypeParser is a recursive-descent parser for type constraints
+type terraformTypeParser struct {
+	input string
+	pos   int
+}
+
+// parseType parses one type expression
+func (p *terraformTypeParser) parseType() (*terraformType, error) {
+	p.skipSpace()
+
+	// Terraform 0.11 quoted types ("string", "list", "map")
+	if p.peek() == '"' {
+		end := strings.IndexByte(p.input[p.pos+1:], '"')
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		name := p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+
+		switch name {
+		case "string":
+			return &terraformType{kind: "string"}, nil
+		case "list", "map":
+			return &terraformType{kind: name, elem: &terraformType{kind: "any"}}, nil
+		}
+		return nil, fmt.Errorf("unknown legacy type %q", name)
+	}
+
+	name := p.identifier()
+	switch name {
+	case "string", "number", "bool", "any":
+		return &terraformType{kind: name}, nil
+	case "list", "set", "map":
+		elem, err := p.parseArguments(p.parseType)
+		if err != nil {
+			return nil, err
+		}
+		return &terraformType{kind: name, elem: elem}, nil
+	case "tuple":
+		var elems []*terraformType
+		_, err := p.parseArguments(func() (*terraformType, error) {
+			var err error
+			elems, err = p.parseTupleElements()
+			return nil, err
+		})
+		return &terraformType{kind: "tuple", elems: elems}, err
+	case "object":
+		parsed := &terraformType{kind: "object", attributes: make(map[string]*terraformType), optional: make(map[string]bool)}
+		_, err := p.parseArguments(func() (*terraformType, error) {
+			return nil, p.parseObjectAttributes(parsed)
+		})
+		return parsed, err
+	case "":
+		return nil, fmt.Errorf("expected a type at offset %d", p.pos)
+	}
+
+	return nil, fmt.Errorf("unknown type %q", name)
+}
+
+// parseArguments parses "(" inner ")"
+func (p *terraformTypeParser) parseArguments(inner func() (*terraformType, error)) (*terraformType, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	parsed, err := inner()
+	if err != nil {
+		return nil, err
+	}
+	return parsed, p.expect(')')
+}
+
+// parseTupleElements parses "[" type, ... "]"
+func (p *terraformTypeParser) parseTupleElements() ([]*terraformType, error) {
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+
+	var elems []*terraformType
+	for {
+		p.skipSpace()
+		if p.peek() == ']' {
+			p.pos++
+			return elems, nil
+		}
+
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+
+		p.skipSpace()
+		if p.peek() == ',' {
+			p.pos++
+		}
+	}
+}
+
+// parseObjectAttributes parses "{" name = type, name = optional(type[, default]) ... "}"
+func (p *terraformTypeParser) parseObjectAttributes(parsed *terraformType) error {
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipSpace()
+		switch p.peek() {
+		case '}':
+			p.pos++
+			return nil
+		case ',', '\n':
+			p.pos++
+			continue
+		}
+
+		name := p.identifier()
+		if name == "" {
+			return fmt.Errorf("expected an attribute name at offset %d", p.pos)
+		}
+		p.skipSpace()
+		if c := p.peek(); c != '=' && c != ':' {
+			return fmt.Errorf("expected '=' after attribute %q", name)
+		}
+		p.pos++
+
+		attrType, optional, err := p.parseAttributeType()
+		if err != nil {
+			return err
+		}
+		parsed.attributes[name] = attrType
+		parsed.optional[name] = optional
+	}
+}
+
+// parseAttributeType parses an attribute type, unwrapping optional(type[, default])
+func (p *terraformTypeParser) parseAttributeType() (*terraformType, bool, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.identifier() != "optional" {
+		p.pos = start
+		attrType, err := p.parseType()
+		return attrType, false, err
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, false, err
+	}
+	attrType, err := p.parseType()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Skip the default value expression up to the closing parenthesis
+	depth := 0
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '(', '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case ')':
+			if depth == 0 {
+				p.pos++
+				return attrType, true, nil
+			}
+			depth--
+		}
+		p.pos++
+	}
+
+	return nil, false, fmt.Errorf("unterminated optional()")
+}
+
+// identifier reads an identifier (possibly empty)
+func (p *terraformTypeParser) identifier() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := rune(p.input[p.pos])
+		if c != '_' && c != '-' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// expect consumes an expected character
+func (p *terraformTypeParser) expect(c byte) error {
+	p.skipSpace()
+	if p.peek() != c {
+		return fmt.Errorf("expected %q at offset %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// peek returns the current character or 0 at the end
+func (p *terraformTypeParser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// skipSpace skips whitespace (including newlines) and # / // comments
+func (p *terraformTypeParser) skipSpace() {
+	for p.pos < len(p.input) {
+		switch {
+		case p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\r' || p.input[p.pos] == '\n':
+			p.pos++
+		case p.input[p.pos] == '#' || strings.HasPrefix(p.input[p.pos:], "//"):
+			end := strings.IndexByte(p.input[p.pos:], '\n')
+			if end == -1 {
+				p.pos = len(p.input)
+				return
+			}
+			p.pos += end
+		default:
+			return
+		}
+	}
+}
+
+// conform checks that a value can be converted to the type the way Terraform converts
+// input variables (numbers and booleans convert to strings and back when possible).
+// It returns a description of the first mismatch, or "" when the value conforms.
+func (t *terraformType) conform(value interface{}, path string) string {
+	// Null is accepted for every type
+	if value == nil || t.kind == "any" {
+		return ""
+	}
+
+	switch t.kind {
+	case "string":
+		switch value.(type) {
+		case string, bool, int, int64, float64:
+			return ""
+		}
+	case "number":
+		switch v := value.(type) {
+		case int, int64, float64:
+			return ""
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return ""
+			}
+		}
+	case "bool":
+		switch v := value.(type) {
+		case bool:
+			return ""
+		case string:
+			if v == "true" || v == "false" {
+				return ""
+			}
+		}
+	case "list", "set":
+		if items, ok := value.([]interface{}); ok {
+			for i, item := range items {
+				if reason := t.elem.conform(item, fmt.Sprintf("%s[%d]", path, i)); reason != "" {
+					return reason
+				}
+			}
+			return ""
+		}
+	case "tuple":
+		if items, ok := value.([]interface{}); ok {
+			if len(items) != len(t.elems) {
+				return fmt.Sprintf("%s: expected a tuple of %d elements, got %d", describePath(path), len(t.elems), len(items))
+			}
+			for i, item := range items {
+				if reason := t.elems[i].conform(item, fmt.Sprintf("%s[%d]", path, i)); reason != "" {
+					return reason
+				}
+			}
+			return ""
+		}
+	case "map":
+		if entries, ok := value.(map[string]interface{}); ok {
+			for _, key := range sortedKeys(entries) {
+				if reason := t.elem.conform(entries[key], path+"."+key); reason != "" {
+					return reason
+				}
+			}
+			return ""
+		}
+	case "object":
+		if entries, ok := value.(map[string]interface{}); ok {
+			for _, name := range sortedKeys(t.attributes) {
+				entry, exists := entries[name]
+				if !exists {
+					if t.optional[name] {
+						continue
+					}
+					return fmt.Sprintf("%s: missing required attribute %q", describePath(path), name)
+				}
+				if reason := t.attributes[name].conform(entry, path+"."+name); reason != "" {
+					return reason
+				}
+			}
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("%s: expected %s, got %s", describePath(path), t.kind, describeValue(value))
+}
+
+// describePath names the value a mismatch refers to
+func describePath(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return path
+}
+
+// describeValue names the type of a decoded value
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return "bool"
+	case int, int64, float64:
+		return "number"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](entries map[string]V) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TerraformVariablesRule checks a tfvars file against the variable declarations attached by
+// the tfvars processor: values for undeclared variables are warnings, while required
+// variables without a value and values that do not match the declared type are errors.
+type TerraformVariablesRule struct{}
+
+// NewTerraformVariablesRule creates a new Terraform variables rule
+func NewTerraformVariablesRule() *TerraformVariablesRule {
+	return &TerraformVariablesRule{}
+}
+
+// ID returns the rule identifier
+func (r *TerraformVariablesRule) ID() string {
+	return "terraform-variables"
+}
+
+// Name returns the rule name
+func (r *TerraformVariablesRule) Name() string {
+	return "Terraform variables"
+}
+
+// Description returns the rule description
+func (r *TerraformVariablesRule) Description() string {
+	return "Checks tfvars values against the variables declared in variables.tf"
+}
+
+// Severity returns the rule severity
+func (r *TerraformVariablesRule) Severity() models.SeverityLevel {
+	return models.SeverityHigh
+}
+
+// Validate checks every declared and every assigned variable of a tfvars file
+func (r *TerraformVariablesRule) Validate(data *models.ConfigData) models.ValidationResult {
+	result := models.ValidationResult{Success: true, Timestamp: time.Now()}
+
+	// Guard clause: nothing to validate
+	if data == nil {
+		return result
+	}
+
+	// Guard clause: the file was processed without declarations
+	variables, ok := data.Metadata["terraform_variables"].([]models.TerraformVariable)
+	if !ok {
+		return result
+	}
+
+	declared := make(map[string]models.TerraformVariable, len(variables))
+	for _, variable := range variables {
+		declared[variable.Name] = variable
+	}
+
+	for _, name := range sortedKeys(data.Data) {
+		if _, ok := declared[name]; ok {
+			continue
+		}
+		position := data.Positions[name]
+		result.Warnings = append(result.Warnings, models.ValidationWarning{
+			Code:     "TFVARS_UNDECLARED_VARIABLE",
+			Message:  fmt.Sprintf("variable %q is not declared", name),
+			Key:      name,
+			Severity: models.SeverityMedium,
+			File:     data.Filename,
+			Line:     position.Line,
+			Column:   position.Column,
+			Fix:      &models.Fix{Action: models.FixDelete, Path: name, Description: "remove undeclared variable " + name},
+		})
+	}
+
+	for _, variable := range variables {
+		value, assigned := data.Data[variable.Name]
+		if !assigned {
+			if variable.Required {
+				missing := r.error("TFVARS_MISSING_REQUIRED", variable,
+					fmt.Sprintf("required variable %q (declared in %s:%d) has no value", variable.Name, variable.File, variable.Position.Line),
+					data.Filename, models.Position{})
+				missing.Fix = &models.Fix{Action: models.FixManual, Path: variable.Name, Description: "set a value for " + variable.Name}
+				result.Errors = append(result.Errors, missing)
+			}
+			continue
+		}
+
+		if err := r.checkType(variable, value); err != "" {
+			mismatch := r.error("TFVARS_TYPE_MISMATCH", variable, err, data.Filename, data.Positions[variable.Name])
+			mismatch.Fix = r.typeFix(variable, value)
+			result.Errors = append(result.Errors, mismatch)
+		}
+	}
+
+	result.Success = len(result.Errors) == 0
+	return result
+}
+
+// checkType describes why a value does not match the declared type ("" when it does)
+func (r *TerraformVariablesRule) checkType(variable models.TerraformVariable, value interface{}) string {
+	// Guard clause: unconstrained variable
+	if variable.Type == "" {
+		return ""
+	}
+
+	constraint, err := parseTerraformType(variable.Type)
+	if err != nil {
+		return fmt.Sprintf("variable %q: %v", variable.Name, err)
+	}
+
+	if reason := constraint.conform(value, variable.Name); reason != "" {
+		return fmt.Sprintf("variable %q does not match type %s: %s", variable.Name, variable.Type, reason)
+	}
+	return ""
+}
+
+// typeFix normalises boolean spellings of bool variables; other mismatches are manual
+func (r *TerraformVariablesRule) typeFix(variable models.TerraformVariable, value interface{}) *models.Fix {
+	normalized, ok := booleanSpellings[strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))]
+	if variable.Type != "bool" || !ok {
+		return &models.Fix{
+			Action:      models.FixManual,
+			Path:        variable.Name,
+			Description: fmt.Sprintf("change %s to a %s value", variable.Name, variable.Type),
+		}
+	}
+
+	return &models.Fix{
+		Action:      models.FixSet,
+		Path:        variable.Name,
+		Value:       normalized,
+		Description: fmt.Sprintf("normalise %s to %t", variable.Name, normalized),
+	}
+}
+
+// error builds a validation error for a variable
+func (r *TerraformVariablesRule) error(code string, variable models.TerraformVariable, message, file string, position models.Position) models.ValidationError {
+	return models.ValidationError{
+		Code:     code,
+		Message:  message,
+		Key:      variable.Name,
+		Severity: r.Severity(),
+		File:     file,
+		Line:     position.Line,
+		Column:   position.Column,
+	}
+}
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestTerraformVariablesRule tests undeclared, missing and mistyped variables
+func TestTerraformVariablesRule(t *testing.T) {
+	variables := []models.TerraformVariable{
+		{Name: "region", Type: "string", Required: true, File: "variables.tf", Position: models.Position{Line: 1, Column: 1}},
+		{Name: "instance_count", Type: "number", File: "variables.tf", Position: models.Position{Line: 5, Column: 1}},
+		{Name: "subnets", Type: "list(object({ cidr = string, public = optional(bool, false) }))", File: "variables.tf"},
+		{Name: "db_password", Required: true, File: "variables.tf", Position: models.Position{Line: 12, Column: 1}},
+	}
+
+	data := &models.ConfigData{
+		Filename: "prod.tfvars",
+		Data: map[string]interface{}{
+			"region":         "eu-west-1",
+			"instance_count": "three",
+			"subnets": []interface{}{
+				map[string]interface{}{"cidr": "10.0.1.0/24", "public": "true"},
+				map[string]interface{}{"public": true},
+			},
+			"legacy_flag": true,
+		},
+		Positions: map[string]models.Position{
+			"instance_count": {Line: 2, Column: 1},
+			"legacy_flag":    {Line: 9, Column: 1},
+		},
+		Metadata: map[string]interface{}{"terraform_variables": variables},
+	}
+
+	result := NewTerraformVariablesRule().Validate(data)
+	if result.Success {
+		t.Error("Validate() should fail")
+	}
+
+	if len(result.Warnings) != 1 || result.Warnings[0].Key != "legacy_flag" || result.Warnings[0].Line != 9 {
+		t.Errorf("Validate() warnings = %+v, want legacy_flag at line 9", result.Warnings)
+	}
+	if fix := result.Warnings[0].Fix; fix == nil || fix.Action != models.FixDelete || fix.Path != "legacy_flag" {
+		t.Errorf("Validate() warning fix = %+v, want delete legacy_flag", fix)
+	}
+
+	expected := map[string]string{
+		"instance_count": `expected number, got string "three"`,
+		"subnets":        `subnets[1]: missing required attribute "cidr"`,
+		"db_password":    "has no value",
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Validate() errors = %+v, want %d", result.Errors, len(expected))
+	}
+	for _, validationError := range result.Errors {
+		if !strings.Contains(validationError.Message, expected[validationError.Key]) {
+			t.Errorf("Validate() error for %s = %q, want it to contain %q", validationError.Key, validationError.Message, expected[validationError.Key])
+		}
+		if validationError.Fix == nil || !validationError.Fix.IsManual() {
+			t.Errorf("Validate() error fix for %s = %+v, want a manual fix", validationError.Key, validationError.Fix)
+		}
+	}
+
+	if result := NewTerraformVariablesRule().Validate(&models.ConfigData{Data: map[string]interface{}{"a": 1}}); !result.Success || len(result.Warnings) != 0 {
+		t.Errorf("Validate() without declarations = %+v, want success", result)
+	}
+}
+
+// TestTerraformTypeConform tests type constraint parsing and Terraform-style conversions
+func TestTerraformTypeConform(t *testing.T) {
+	tests := []struct {
+		constraint string
+		value      interface{}
+		valid      bool
+	}{
+		{constraint: "string", value: 42, valid: true},
+		{constraint: "number", value: "42.5", valid: true},
+		{constraint: "number", value: true, valid: false},
+		{constraint: "bool", value: "false", valid: true},
+		{constraint: "bool", value: "yes", valid: false},
+		{constraint: "any", value: []interface{}{1, "a"}, valid: true},
+		{constraint: `"list"`, value: []interface{}{1}, valid: true},
+		{constraint: "set(number)", value: []interface{}{1, "x"}, valid: false},
+		{constraint: "map(bool)", value: map[string]interface{}{"a": true}, valid: true},
+		{constraint: "map(bool)", value: []interface{}{true}, valid: false},
+		{constraint: "tuple([string, number])", value: []interface{}{"a", 1}, valid: true},
+		{constraint: "tuple([string, number])", value: []interface{}{"a"}, valid: false},
+		{constraint: "object({\n  name = string\n  size = optional(number)\n})", value: map[string]interface{}{"name": "a", "extra": 1}, valid: true},
+		{constraint: "string", value: nil, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			parsed, err := parseTerraformType(tt.constraint)
+			if err != nil {
+				t.Fatalf("parseTerraformType() error = %v", err)
+			}
+			if reason := parsed.conform(tt.value, "var"); (reason == "") != tt.valid {
+				t.Errorf("conform(%v) = %q, want valid %v", tt.value, reason, tt.valid)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"strng", "list(", "map(string) extra"} {
+		if _, err := parseTerraformType(invalid); err == nil {
+			t.Errorf("parseTerraformType(%q) should fail", invalid)
+		}
+	}
+}
+
+// TestTerraformTypeFix tests the fixes declared for type mismatches
+func TestTerraformTypeFix(t *testing.T) {
+	tests := []struct {
+		variableType string
+		value        interface{}
+		action       models.FixAction
+		fixed        interface{}
+	}{
+		{variableType: "bool", value: "yes", action: models.FixSet, fixed: true},
+		{variableType: "bool", value: "Off", action: models.FixSet, fixed: false},
+		{variableType: "bool", value: "maybe", action: models.FixManual},
+		{variableType: "number", value: "three", action: models.FixManual},
+	}
+
+	rule := NewTerraformVariablesRule()
+	for _, tt := range tests {
+		fix := rule.typeFix(models.TerraformVariable{Name: "flag", Type: tt.variableType}, tt.value)
+		if fix.Action != tt.action || fix.Value != tt.fixed {
+			t.Errorf("typeFix(%s, %v) = %+v, want %s %v", tt.variableType, tt.value, fix, tt.action, tt.fixed)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// MergeOverrideRule warns when a key silently shadows a key inherited through a YAML merge
+// key (<<: *anchor). Merge keys are shallow, so overriding a nested mapping also drops every
+// nested key that is not repeated.
+type MergeOverrideRule struct{}
+
+// NewMergeOverrideRule creates a new merge override rule
+func NewMergeOverrideRule() *MergeOverrideRule {
+	return &MergeOverrideRule{}
+}
+
+// ID returns the rule identifier
+func (r *MergeOverrideRule) ID() string {
+	return "yaml-merge-override"
+}
+
+// Name returns the rule name
+func (r *MergeOverrideRule) Name() string {
+	return "YAML merge override"
+}
+
+// Description returns the rule description
+func (r *MergeOverrideRule) Description() string {
+	return "Warns when a key overrides a different value inherited through a YAML merge key"
+}
+
+// Severity returns the rule severity
+func (r *MergeOverrideRule) Severity() models.SeverityLevel {
+	return models.SeverityLow
+}
+
+// Validate reports every override recorded by the YAML processor whose value differs
+// from the inherited one
+func (r *MergeOverrideRule) Validate(data *models.ConfigData) models.ValidationResult {
+	result := models.ValidationResult{Success: true, Timestamp: time.Now()}
+
+	// Guard clause: nothing to validate
+	if data == nil {
+		return result
+	}
+
+	overrides, _ := data.Metadata["merge_overrides"].([]models.MergeOverride)
+	for _, override := range overrides {
+		// Repeating the inherited value does not shadow anything
+		if reflect.DeepEqual(override.Value, override.Inherited) {
+			continue
+		}
+
+		result.Warnings = append(result.Warnings, models.ValidationWarning{
+			Code:     "YAML_MERGE_OVERRIDE",
+			Message:  r.message(override),
+			Key:      override.Path,
+			Severity: r.Severity(),
+			File:     data.Filename,
+			Line:     override.Position.Line,
+			Column:   override.Position.Column,
+		})
+	}
+
+	return result
+}
+
+// message describes an override, listing nested keys that are no longer inherited
+func (r *MergeOverrideRule) message(override models.MergeOverride) string {
+	source := "a merge key"
+	if override.Anchor != "" {
+		source = fmt.Sprintf("anchor %q", override.Anchor)
+	}
+	message := fmt.Sprintf("key %q overrides the value inherited from %s", override.Path, source)
+
+	lost := droppedKeys(override.Inherited, override.Value)
+	if len(lost) > 0 {
+		message += fmt.Sprintf("; nested keys %s are no longer inherited (merge keys are shallow)", strings.Join(lost, ", "))
+	}
+
+	return message
+}
+
+// droppedKeys returns the keys of an inherited mapping that are missing from its override
+func droppedKeys(inherited, value interface{}) []string {
+	inheritedMap, ok := inherited.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var lost []string
+	for key := range inheritedMap {
+		if _, exists := valueMap[key]; !exists {
+			lost = append(lost, key)
+		}
+	}
+	sort.Strings(lost)
+	return lost
+}
@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestMergeOverrideRule tests warnings for keys shadowing inherited values
+func TestMergeOverrideRule(t *testing.T) {
+	data := &models.ConfigData{
+		Filename: "database.yml",
+		Metadata: map[string]interface{}{
+			"merge_overrides": []models.MergeOverride{
+				{Path: "development.pool", Anchor: "defaults", Position: models.Position{Line: 13, Column: 3}, Value: 5, Inherited: 5},
+				{Path: "production.pool", Anchor: "defaults", Position: models.Position{Line: 16, Column: 3}, Value: 20, Inherited: 5},
+				{
+					Path:      "production.options",
+					Anchor:    "defaults",
+					Position:  models.Position{Line: 17, Column: 3},
+					Value:     map[string]interface{}{"ssl": false},
+					Inherited: map[string]interface{}{"ssl": true, "timeout": 30},
+				},
+			},
+		},
+	}
+
+	result := NewMergeOverrideRule().Validate(data)
+	if !result.Success {
+		t.Error("Validate() should not fail on warnings")
+	}
+	if len(result.Warnings) != 2 {
+		t.Fatalf("Validate() warnings = %+v, want 2", result.Warnings)
+	}
+
+	first := result.Warnings[0]
+	if first.Key != "production.pool" || first.Line != 16 || first.File != "database.yml" {
+		t.Errorf("Validate() first warning = %+v, want production.pool at line 16", first)
+	}
+	if !strings.Contains(result.Warnings[1].Message, "nested keys timeout are no longer inherited") {
+		t.Errorf("Validate() second warning message = %q, want dropped nested keys", result.Warnings[1].Message)
+	}
+
+	if result := NewMergeOverrideRule().Validate(&models.ConfigData{Metadata: map[string]interface{}{}}); len(result.Warnings) != 0 {
+		t.Errorf("Validate() without overrides warnings = %+v, want none", result.Warnings)
+	}
+}
@@ -0,0 +1,224 @@
+package comparison
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// Status is the difference of a key between the compared files
+type Status string
+
+const (
+	// StatusAdded marks keys missing from the first file
+	StatusAdded Status = "added"
+	// StatusRemoved marks keys of the first file missing from another file
+	StatusRemoved Status = "removed"
+	// StatusChanged marks keys present everywhere with different values
+	StatusChanged Status = "changed"
+	// StatusUnchanged marks keys present everywhere with the same value
+	StatusUnchanged Status = "unchanged"
+)
+
+// KeyOptions controls how keys are normalised before files are compared. Dotted keys
+// always match nested keys (database.host in a properties file matches database: host:).
+type KeyOptions struct {
+	// IgnoreCase compares keys case-insensitively
+	IgnoreCase bool
+	// Separators lists characters that also separate keys, e.g. "_" to match DATABASE_HOST
+	// with database.host
+	Separators string
+	// StripPrefixes are removed from the start of keys, e.g. APP_ for environment files
+	StripPrefixes []string
+}
+
+// Options configures a comparison
+type Options struct {
+	Keys KeyOptions
+	// StructureOnly compares which keys exist and ignores their values
+	StructureOnly bool
+}
+
+// Value is the value of a key in one file
+type Value struct {
+	Present bool        `json:"present"`
+	Key     string      `json:"key,omitempty"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+// Text returns the value as compared and displayed
+func (v Value) Text() string {
+	return valueText(v.Value)
+}
+
+// Entry is one normalised key with its value in every file
+type Entry struct {
+	Key    string  `json:"key"`
+	Status Status  `json:"status"`
+	Values []Value `json:"values"`
+}
+
+// Result is the comparison of a set of files
+type Result struct {
+	Files   []string `json:"files"`
+	Entries []Entry  `json:"entries"`
+}
+
+// Differences returns the entries that are not unchanged
+func (r Result) Differences() []Entry {
+	var differences []Entry
+	for _, entry := range r.Entries {
+		if entry.Status != StatusUnchanged {
+			differences = append(differences, entry)
+		}
+	}
+	return differences
+}
+
+// Count returns the number of entries with a status
+func (r Result) Count(status Status) int {
+	count := 0
+	for _, entry := range r.Entries {
+		if entry.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Compare compares the leaf keys of files against the first file. Values are compared by
+// their text, so 8080 in a YAML file equals "8080" in an env file.
+func Compare(files []*models.ConfigData, options Options) Result {
+	result := Result{Files: make([]string, len(files))}
+
+	normalized := make([]map[string]Value, len(files))
+	keys := make(map[string]bool)
+	for i, file := range files {
+		result.Files[i] = file.Filename
+		normalized[i] = normalizeKeys(file, options.Keys)
+		for key := range normalized[i] {
+			keys[key] = true
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		entry := Entry{Key: key, Values: make([]Value, len(files))}
+		for i := range files {
+			entry.Values[i] = normalized[i][key]
+		}
+		entry.Status = status(entry.Values, options.StructureOnly)
+		result.Entries = append(result.Entries, entry)
+	}
+	return result
+}
+
+// status classifies the values of a key
+func status(values []Value, structureOnly bool) Status {
+	if !values[0].Present {
+		return StatusAdded
+	}
+	for _, value := range values[1:] {
+		if !value.Present {
+			return StatusRemoved
+		}
+	}
+	if structureOnly {
+		return StatusUnchanged
+	}
+	for _, value := range values[1:] {
+		if value.Text() != values[0].Text() {
+			return StatusChanged
+		}
+	}
+	return StatusUnchanged
+}
+
+// normalizeKeys returns the leaf values of a file by normalised key. When several keys
+// normalise to the same key, the first one in sorted order is kept.
+func normalizeKeys(file *models.ConfigData, options KeyOptions) map[string]Value {
+	values := make(map[string]Value)
+	for _, key := range file.Paths() {
+		value, _ := file.Get(key)
+		normalized := NormalizeKey(key, options)
+		if _, exists := values[normalized]; !exists {
+			values[normalized] = Value{Present: true, Key: key, Value: value}
+		}
+	}
+	return values
+}
+
+// NormalizeKey returns the key a key path is compared by
+func NormalizeKey(key string, options KeyOptions) string {
+	parsed, err := models.ParsePath(key)
+	if err != nil {
+		parsed = models.Path{models.KeySegment(key)}
+	}
+
+	var builder strings.Builder
+	for i, segment := range parsed {
+		if segment.IsIndex {
+			builder.WriteString("[" + strconv.Itoa(segment.Index) + "]")
+			continue
+		}
+
+		name := segment.Key
+		if i == 0 {
+			name = stripPrefix(name, options)
+		}
+		if options.IgnoreCase {
+			name = strings.ToLower(name)
+		}
+		for _, separator := range options.Separators {
+			name = strings.ReplaceAll(name, string(separator), ".")
+		}
+
+		if builder.Len() > 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(name)
+	}
+	return builder.String()
+}
+
+// stripPrefix removes the first matching prefix from the first key of a path
+func stripPrefix(key string, options KeyOptions) string {
+	for _, prefix := range options.StripPrefixes {
+		if len(key) <= len(prefix) {
+			continue
+		}
+		if strings.HasPrefix(key, prefix) || (options.IgnoreCase && strings.EqualFold(key[:len(prefix)], prefix)) {
+			return key[len(prefix):]
+		}
+	}
+	return key
+}
+
+// valueText formats a leaf value as text. Floats are written without exponents, so that a
+// JSON 1000000 (a float) equals a YAML 1000000 (an integer).
+func valueText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
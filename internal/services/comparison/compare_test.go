@@ -0,0 +1,92 @@
+package comparison
+
+import (
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestCompare tests added, removed, changed and unchanged keys
+func TestCompare(t *testing.T) {
+	dev := &models.ConfigData{Filename: "dev.yaml", Data: map[string]interface{}{
+		"database": map[string]interface{}{"host": "localhost", "port": 5432},
+		"debug":    true,
+		"tags":     []interface{}{"a"},
+		// JSON numbers are decoded as float64
+		"limit": float64(1000000),
+	}}
+	prod := &models.ConfigData{Filename: "prod.properties", Data: map[string]interface{}{
+		"database.host": "db",
+		"database.port": "5432",
+		"api.key":       "x",
+		"tags":          []interface{}{"a"},
+		"limit":         1000000,
+	}}
+
+	tests := []struct {
+		name          string
+		structureOnly bool
+		expected      map[string]Status
+	}{
+		{
+			name: "values",
+			expected: map[string]Status{
+				"api.key":       StatusAdded,
+				"database.host": StatusChanged,
+				"database.port": StatusUnchanged,
+				"debug":         StatusRemoved,
+				"limit":         StatusUnchanged,
+				"tags[0]":       StatusUnchanged,
+			},
+		},
+		{
+			name:          "structure only",
+			structureOnly: true,
+			expected: map[string]Status{
+				"api.key":       StatusAdded,
+				"database.host": StatusUnchanged,
+				"database.port": StatusUnchanged,
+				"debug":         StatusRemoved,
+				"limit":         StatusUnchanged,
+				"tags[0]":       StatusUnchanged,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Compare([]*models.ConfigData{dev, prod}, Options{StructureOnly: tt.structureOnly})
+			if len(result.Entries) != len(tt.expected) {
+				t.Fatalf("Compare() = %+v, want %d entries", result.Entries, len(tt.expected))
+			}
+			for _, entry := range result.Entries {
+				if entry.Status != tt.expected[entry.Key] {
+					t.Errorf("Compare() status of %s = %s, want %s", entry.Key, entry.Status, tt.expected[entry.Key])
+				}
+			}
+		})
+	}
+}
+
+// TestNormalizeKey tests key normalisation options
+func TestNormalizeKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		options  KeyOptions
+		expected string
+	}{
+		{key: "database.host", expected: "database.host"},
+		{key: `database\.host`, expected: "database.host"},
+		{key: "DATABASE_HOST", options: KeyOptions{IgnoreCase: true, Separators: "_"}, expected: "database.host"},
+		{key: "APP_DATABASE_HOST", options: KeyOptions{Separators: "_", StripPrefixes: []string{"APP_"}}, expected: "DATABASE.HOST"},
+		{key: "app_port", options: KeyOptions{IgnoreCase: true, StripPrefixes: []string{"APP_"}}, expected: "port"},
+		{key: "APP_", options: KeyOptions{StripPrefixes: []string{"APP_"}}, expected: "APP_"},
+		{key: "servers[1].name", options: KeyOptions{IgnoreCase: true}, expected: "servers[1].name"},
+	}
+
+	for _, tt := range tests {
+		if normalized := NormalizeKey(tt.key, tt.options); normalized != tt.expected {
+			t.Errorf("NormalizeKey(%q) = %q, want %q", tt.key, normalized, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package conversion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+)
+
+// Options configures a conversion
+type Options struct {
+	// Nest expands dotted keys (database.host) into nested keys
+	Nest bool
+	// Infer converts string values into typed values. A converted value is only kept when
+	// it reads back as the same text ("8080" becomes 8080, "1.50" stays a string), so the
+	// converted file has no differences with its source.
+	Infer func(value string) interface{}
+}
+
+// Result is a converted configuration tree with the warnings of the conversion
+type Result struct {
+	Data     map[string]interface{}
+	Warnings []string
+}
+
+// Convert prepares the tree of a parsed file for writing in another format
+func Convert(data *models.ConfigData, options Options) Result {
+	converter := &converter{options: options}
+	tree, _ := converter.value(data.Data, "").(map[string]interface{})
+	if tree == nil {
+		tree = make(map[string]interface{})
+	}
+	return Result{Data: tree, Warnings: converter.warnings}
+}
+
+// converter converts a tree, collecting warnings
+type converter struct {
+	options  Options
+	warnings []string
+}
+
+// value converts a value and everything below it
+func (c *converter) value(value interface{}, path string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return c.mapping(v, path)
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = c.value(item, models.IndexPath(path, i))
+		}
+		return converted
+	case string:
+		return c.infer(v)
+	}
+	return value
+}
+
+// mapping converts a map. Plain keys are set first, so a dotted key that conflicts with
+// them stays flat.
+func (c *converter) mapping(values map[string]interface{}, path string) map[string]interface{} {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]interface{}, len(values))
+	var dotted []string
+	for _, key := range keys {
+		if c.options.Nest && nestable(key) {
+			dotted = append(dotted, key)
+			continue
+		}
+		result[key] = c.value(values[key], models.JoinPath(path, key))
+	}
+
+	for _, key := range dotted {
+		value := c.value(values[key], models.JoinPath(path, key))
+		if err := insert(result, strings.Split(key, "."), value); err != nil {
+			c.warnings = append(c.warnings, fmt.Sprintf("%s is kept as a dotted key: %v", models.JoinPath(path, key), err))
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// infer converts a string when the converted value reads back as the same text
+func (c *converter) infer(text string) interface{} {
+	// Guard clause: inference is disabled
+	if c.options.Infer == nil {
+		return text
+	}
+
+	inferred := c.options.Infer(text)
+	if (comparison.Value{Value: inferred}).Text() != text {
+		return text
+	}
+	return inferred
+}
+
+// nestable reports whether a key is a dotted key with no empty segments
+func nestable(key string) bool {
+	if !strings.Contains(key, ".") {
+		return false
+	}
+	for _, segment := range strings.Split(key, ".") {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// insert sets a value below nested maps, merging maps that already exist
+func insert(target map[string]interface{}, segments []string, value interface{}) error {
+	key := segments[0]
+	existing, exists := target[key]
+
+	if len(segments) > 1 {
+		child, isMap := existing.(map[string]interface{})
+		switch {
+		case !exists:
+			child = make(map[string]interface{})
+			target[key] = child
+		case !isMap:
+			return fmt.Errorf("%s already has a value", key)
+		}
+		return insert(child, segments[1:], value)
+	}
+
+	// Guard clause: new key
+	if !exists {
+		target[key] = value
+		return nil
+	}
+
+	existingMap, existingIsMap := existing.(map[string]interface{})
+	valueMap, valueIsMap := value.(map[string]interface{})
+	if !existingIsMap || !valueIsMap {
+		return fmt.Errorf("%s already has a value", key)
+	}
+	for childKey, childValue := range valueMap {
+		if err := insert(existingMap, []string{childKey}, childValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
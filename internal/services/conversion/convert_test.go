@@ -0,0 +1,87 @@
+package conversion
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestConvert tests nesting dotted keys and inferring types that round-trip
+func TestConvert(t *testing.T) {
+	infer := func(value string) interface{} {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+		return value
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]interface{}
+		options  Options
+		expected map[string]interface{}
+		warnings []string
+	}{
+		{
+			name:    "nesting",
+			data:    map[string]interface{}{"server.port": "8080", "server.host": "localhost", "name": "demo"},
+			options: Options{Nest: true},
+			expected: map[string]interface{}{
+				"server": map[string]interface{}{"port": "8080", "host": "localhost"},
+				"name":   "demo",
+			},
+		},
+		{
+			name:    "dotted key merges with a nested key",
+			data:    map[string]interface{}{"server": map[string]interface{}{"host": "localhost"}, "server.port": "8080"},
+			options: Options{Nest: true},
+			expected: map[string]interface{}{
+				"server": map[string]interface{}{"port": "8080", "host": "localhost"},
+			},
+		},
+		{
+			name:     "conflict keeps the dotted key",
+			data:     map[string]interface{}{"server": "localhost", "server.port": "8080"},
+			options:  Options{Nest: true},
+			expected: map[string]interface{}{"server": "localhost", "server.port": "8080"},
+			warnings: []string{`server\.port is kept as a dotted key: server already has a value`},
+		},
+		{
+			name:     "empty segments stay flat",
+			data:     map[string]interface{}{"a..b": "1", ".hidden": "2"},
+			options:  Options{Nest: true},
+			expected: map[string]interface{}{"a..b": "1", ".hidden": "2"},
+		},
+		{
+			name:     "no nesting",
+			data:     map[string]interface{}{"server.port": "8080"},
+			expected: map[string]interface{}{"server.port": "8080"},
+		},
+		{
+			name:     "inference keeps the text",
+			data:     map[string]interface{}{"port": "8080", "price": "1.50", "ratio": "0.5", "debug": "true", "flag": "TRUE", "ports": []interface{}{"80", "x"}},
+			options:  Options{Infer: infer},
+			expected: map[string]interface{}{"port": 8080, "price": "1.50", "ratio": 0.5, "debug": true, "flag": "TRUE", "ports": []interface{}{80, "x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Convert(&models.ConfigData{Data: tt.data}, tt.options)
+			if !reflect.DeepEqual(result.Data, tt.expected) {
+				t.Errorf("Convert() = %v, want %v", result.Data, tt.expected)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.warnings) {
+				t.Errorf("Convert() warnings = %q, want %q", result.Warnings, tt.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package discovery
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// environmentAliases maps the environment names found in file names to canonical names
+var environmentAliases = map[string]string{
+	"dev":         "dev",
+	"develop":     "dev",
+	"development": "dev",
+	"local":       "local",
+	"test":        "test",
+	"testing":     "test",
+	"qa":          "qa",
+	"uat":         "uat",
+	"stage":       "staging",
+	"staging":     "staging",
+	"stg":         "staging",
+	"preprod":     "preprod",
+	"prod":        "prod",
+	"production":  "prod",
+	"prd":         "prod",
+	"sandbox":     "sandbox",
+	"demo":        "demo",
+}
+
+// templateWords mark example files, which never belong to an environment
+var templateWords = map[string]bool{
+	"example":  true,
+	"sample":   true,
+	"template": true,
+	"dist":     true,
+	"tpl":      true,
+}
+
+// nameSeparators split file names into words
+const nameSeparators = "-_."
+
+// ConfigFile is a configuration file with the environment inferred from its name. Group is
+// the name with the environment replaced by "*" (config-prod.yaml is in group
+// config-*.yaml), so the files of one group are the same configuration per environment.
+type ConfigFile struct {
+	Path        string
+	Environment string
+	Group       string
+}
+
+// Proposal is the set of environments discovered in a project
+type Proposal struct {
+	// Environments maps each environment to its sorted files
+	Environments map[string][]string
+	// Groups lists the sorted groups that define the environments
+	Groups []string
+	// GroupFiles maps each group to its sorted files
+	GroupFiles map[string][]string
+	// Unassigned lists the files that belong to no environment
+	Unassigned []string
+}
+
+// EnvironmentNames returns the sorted environment names of a proposal
+func (p Proposal) EnvironmentNames() []string {
+	names := make([]string, 0, len(p.Environments))
+	for name := range p.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// InferEnvironment infers the environment of a file from its name (config-prod.yaml,
+// appsettings.Production.json, env.staging, values-dev.yaml) or else from the name of a
+// parent directory (config/prod/app.yaml)
+func InferEnvironment(filename string) (ConfigFile, bool) {
+	slashed := filepath.ToSlash(filename)
+	dir, base := path.Split(slashed)
+
+	words := splitWords(base)
+	for _, word := range words {
+		if templateWords[strings.ToLower(word.text)] {
+			return ConfigFile{}, false
+		}
+	}
+
+	// The last environment word of the name wins (app.dev.production.json is production)
+	for i := len(words) - 1; i >= 0; i-- {
+		if environment, ok := environmentAliases[strings.ToLower(words[i].text)]; ok {
+			group := base[:words[i].start] + "*" + base[words[i].end:]
+			return ConfigFile{Path: filename, Environment: environment, Group: dir + group}, true
+		}
+	}
+
+	segments := strings.Split(strings.TrimSuffix(dir, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if environment, ok := environmentAliases[strings.ToLower(segments[i])]; ok {
+			segments[i] = "*"
+			return ConfigFile{Path: filename, Environment: environment, Group: strings.Join(segments, "/") + "/" + base}, true
+		}
+	}
+	return ConfigFile{}, false
+}
+
+// Discover groups files by inferred environment. Groups with at least two environments
+// define the environments. Files of other groups (a lone appsettings.Production.json) are
+// not the same configuration as any other file and are left unassigned.
+func Discover(filenames []string) Proposal {
+	proposal := Proposal{Environments: make(map[string][]string), GroupFiles: make(map[string][]string)}
+
+	groups := make(map[string][]ConfigFile)
+	for _, filename := range filenames {
+		file, ok := InferEnvironment(filename)
+		if !ok {
+			proposal.Unassigned = append(proposal.Unassigned, filename)
+			continue
+		}
+		groups[file.Group] = append(groups[file.Group], file)
+	}
+
+	for _, group := range sortedGroups(groups) {
+		files := groups[group]
+		if countEnvironments(files) < 2 {
+			for _, file := range files {
+				proposal.Unassigned = append(proposal.Unassigned, file.Path)
+			}
+			continue
+		}
+		proposal.Groups = append(proposal.Groups, group)
+		for _, file := range files {
+			proposal.Environments[file.Environment] = append(proposal.Environments[file.Environment], file.Path)
+			proposal.GroupFiles[group] = append(proposal.GroupFiles[group], file.Path)
+		}
+		sort.Strings(proposal.GroupFiles[group])
+	}
+
+	for environment := range proposal.Environments {
+		sort.Strings(proposal.Environments[environment])
+	}
+	sort.Strings(proposal.Unassigned)
+	return proposal
+}
+
+// IntersectKeys returns the sorted keys present in every list of distinct keys
+func IntersectKeys(lists [][]string) []string {
+	// Guard clause: nothing to intersect
+	if len(lists) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, list := range lists {
+		for _, key := range list {
+			counts[key]++
+		}
+	}
+
+	var common []string
+	for key, count := range counts {
+		if count == len(lists) {
+			common = append(common, key)
+		}
+	}
+	sort.Strings(common)
+	return common
+}
+
+// CommonKeys returns the sorted key paths present in every file. Keys below arrays are
+// truncated to the array, as array lengths usually differ between environments.
+func CommonKeys(files []*models.ConfigData) []string {
+	lists := make([][]string, 0, len(files))
+	for _, file := range files {
+		keys := make(map[string]bool)
+		var list []string
+		for _, key := range file.Paths() {
+			if truncated := truncateAtIndex(key); truncated != "" && !keys[truncated] {
+				keys[truncated] = true
+				list = append(list, truncated)
+			}
+		}
+		lists = append(lists, list)
+	}
+	return IntersectKeys(lists)
+}
+
+// truncateAtIndex cuts a key path before its first array index
+func truncateAtIndex(key string) string {
+	parsed, err := models.ParsePath(key)
+	if err != nil {
+		return key
+	}
+	for i, segment := range parsed {
+		if segment.IsIndex {
+			return parsed[:i].String()
+		}
+	}
+	return key
+}
+
+// word is a word of a file name with its byte offsets
+type word struct {
+	text       string
+	start, end int
+}
+
+// splitWords splits a file name into words at separators
+func splitWords(name string) []word {
+	var words []word
+	start := 0
+	for i := 0; i <= len(name); i++ {
+		if i < len(name) && !strings.ContainsRune(nameSeparators, rune(name[i])) {
+			continue
+		}
+		if i > start {
+			words = append(words, word{text: name[start:i], start: start, end: i})
+		}
+		start = i + 1
+	}
+	return words
+}
+
+// countEnvironments counts the distinct environments of files
+func countEnvironments(files []ConfigFile) int {
+	environments := make(map[string]bool)
+	for _, file := range files {
+		environments[file.Environment] = true
+	}
+	return len(environments)
+}
+
+// sortedGroups returns the sorted group names
+func sortedGroups(groups map[string][]ConfigFile) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
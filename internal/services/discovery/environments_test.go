@@ -0,0 +1,93 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestInferEnvironment tests environment naming conventions
+func TestInferEnvironment(t *testing.T) {
+	tests := []struct {
+		filename    string
+		environment string
+		group       string
+	}{
+		{filename: "config/config-prod.yaml", environment: "prod", group: "config/config-*.yaml"},
+		{filename: "appsettings.Production.json", environment: "prod", group: "appsettings.*.json"},
+		{filename: "env.staging", environment: "staging", group: "env.*"},
+		{filename: ".env.development", environment: "dev", group: ".env.*"},
+		{filename: "helm/values-dev.yaml", environment: "dev", group: "helm/values-*.yaml"},
+		{filename: "settings_qa.ini", environment: "qa", group: "settings_*.ini"},
+		{filename: "config/prod/app.yaml", environment: "prod", group: "config/*/app.yaml"},
+		{filename: "config/app.yaml"},
+		{filename: "devices.yaml"},
+		{filename: "config-prod.yaml.example"},
+	}
+
+	for _, tt := range tests {
+		file, ok := InferEnvironment(tt.filename)
+		if ok != (tt.environment != "") || file.Environment != tt.environment || file.Group != tt.group {
+			t.Errorf("InferEnvironment(%q) = %+v, %t, want %s in %s", tt.filename, file, ok, tt.environment, tt.group)
+		}
+	}
+}
+
+// TestDiscover tests grouping files into environments
+func TestDiscover(t *testing.T) {
+	proposal := Discover([]string{
+		"appsettings.json",
+		"appsettings.Development.json",
+		"appsettings.Production.json",
+		"config-dev.yaml",
+		"config-prod.yaml",
+		"values-dev.yaml",
+		"env.staging",
+	})
+
+	expected := map[string][]string{
+		"dev":  {"appsettings.Development.json", "config-dev.yaml"},
+		"prod": {"appsettings.Production.json", "config-prod.yaml"},
+	}
+	if !reflect.DeepEqual(proposal.Environments, expected) {
+		t.Errorf("Discover() environments = %v, want %v", proposal.Environments, expected)
+	}
+	if !reflect.DeepEqual(proposal.Groups, []string{"appsettings.*.json", "config-*.yaml"}) {
+		t.Errorf("Discover() groups = %v", proposal.Groups)
+	}
+	if files := proposal.GroupFiles["config-*.yaml"]; !reflect.DeepEqual(files, []string{"config-dev.yaml", "config-prod.yaml"}) {
+		t.Errorf("Discover() files of config-*.yaml = %v", files)
+	}
+	if !reflect.DeepEqual(proposal.Unassigned, []string{"appsettings.json", "env.staging", "values-dev.yaml"}) {
+		t.Errorf("Discover() unassigned = %v", proposal.Unassigned)
+	}
+}
+
+// TestCommonKeys tests proposing keys present in every file
+func TestCommonKeys(t *testing.T) {
+	files := []*models.ConfigData{
+		{Data: map[string]interface{}{
+			"database": map[string]interface{}{"host": "a", "port": 1},
+			"servers":  []interface{}{map[string]interface{}{"name": "a"}},
+			"debug":    true,
+		}},
+		{Data: map[string]interface{}{
+			"database": map[string]interface{}{"host": "b"},
+			"servers":  []interface{}{"b", "c"},
+		}},
+	}
+
+	if keys := CommonKeys(files); !reflect.DeepEqual(keys, []string{"database.host", "servers"}) {
+		t.Errorf("CommonKeys() = %v, want [database.host servers]", keys)
+	}
+}
+
+// TestIntersectKeys tests keeping the keys of every list
+func TestIntersectKeys(t *testing.T) {
+	lists := [][]string{{"database.host", "database.port", "debug"}, {"database.port", "debug", "name"}}
+
+	if keys := IntersectKeys(lists); !reflect.DeepEqual(keys, []string{"database.port", "debug"}) {
+		t.Errorf("IntersectKeys() = %v, want [database.port debug]", keys)
+	}
+}
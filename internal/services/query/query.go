@@ -0,0 +1,192 @@
+package query
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+	"github.com/syntropysoft/praetorian-go/internal/services/secrets"
+)
+
+// Mask replaces the values of secrets
+const Mask = "********"
+
+// Pattern selects keys by path. Each key of the pattern may use * and ? wildcards,
+// [*] matches any list index and ** matches any number of keys. A pattern also selects
+// the keys below the paths it matches, so database selects database.host.
+type Pattern struct {
+	text     string
+	segments []string
+}
+
+// ParsePattern parses a pattern, normalising its keys as the compared keys are
+func ParsePattern(pattern string, options comparison.KeyOptions) (Pattern, error) {
+	// Guard clause: a pattern selects something
+	if strings.TrimSpace(pattern) == "" {
+		return Pattern{}, fmt.Errorf("empty key pattern")
+	}
+
+	segments := splitKey(comparison.NormalizeKey(pattern, options))
+	for _, segment := range segments {
+		if _, err := path.Match(indexPattern(segment), ""); err != nil {
+			return Pattern{}, fmt.Errorf("invalid key pattern %q: %w", pattern, err)
+		}
+	}
+	// A trailing ** selects the keys below the matched paths
+	return Pattern{text: pattern, segments: append(segments, "**")}, nil
+}
+
+// String returns the pattern as written
+func (p Pattern) String() string {
+	return p.text
+}
+
+// Match reports whether the pattern selects a normalised key
+func (p Pattern) Match(key string) bool {
+	return models.MatchSegments(p.segments, splitKey(key), matchSegment)
+}
+
+// Environment is a named set of parsed files. Later files override earlier ones.
+type Environment struct {
+	Name  string
+	Files []*models.ConfigData
+}
+
+// Options configures a query
+type Options struct {
+	Keys comparison.KeyOptions
+	// Secrets decides which values are masked; nil shows every value
+	Secrets *secrets.Detector
+}
+
+// Value is the value of a key in one environment
+type Value struct {
+	Environment string      `json:"environment"`
+	Present     bool        `json:"present"`
+	Value       interface{} `json:"value,omitempty"`
+	Secret      bool        `json:"secret,omitempty"`
+	// File is the file that sets the value
+	File string `json:"file,omitempty"`
+}
+
+// Row is one normalised key with its value in every environment
+type Row struct {
+	Key    string  `json:"key"`
+	Values []Value `json:"values"`
+}
+
+// Result is the selected keys of a set of environments
+type Result struct {
+	Pattern      string   `json:"pattern"`
+	Environments []string `json:"environments"`
+	Rows         []Row    `json:"keys"`
+}
+
+// Query returns the leaf keys selected by a pattern in every environment, in sorted key
+// order
+func Query(environments []Environment, pattern Pattern, options Options) Result {
+	result := Result{Pattern: pattern.String(), Environments: make([]string, len(environments))}
+
+	selected := make([]map[string]Value, len(environments))
+	keys := make(map[string]bool)
+	for i, environment := range environments {
+		result.Environments[i] = environment.Name
+		selected[i] = selectKeys(environment, pattern, options)
+		for key := range selected[i] {
+			keys[key] = true
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		row := Row{Key: key, Values: make([]Value, len(environments))}
+		for i, environment := range environments {
+			value, ok := selected[i][key]
+			if !ok {
+				value = Value{Environment: environment.Name}
+			}
+			row.Values[i] = value
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+// selectKeys returns the selected values of an environment by normalised key
+func selectKeys(environment Environment, pattern Pattern, options Options) map[string]Value {
+	values := make(map[string]Value)
+	for _, file := range environment.Files {
+		for keyPath, value := range file.Flatten() {
+			key := comparison.NormalizeKey(keyPath, options.Keys)
+			if !pattern.Match(key) {
+				continue
+			}
+
+			selected := Value{Environment: environment.Name, Present: true, Value: value, File: file.Filename}
+			if options.Secrets != nil && options.Secrets.IsSecret(keyPath, value) {
+				selected.Secret, selected.Value = true, Mask
+			}
+			values[key] = selected
+		}
+	}
+	return values
+}
+
+// splitKey splits a normalised key into its keys and [index] segments
+func splitKey(key string) []string {
+	var segments []string
+	for _, part := range strings.Split(key, ".") {
+		for {
+			open := strings.IndexByte(part, '[')
+			if open == -1 {
+				break
+			}
+			if open > 0 {
+				segments = append(segments, part[:open])
+			}
+			end := strings.IndexByte(part[open:], ']')
+			if end == -1 {
+				break
+			}
+			segments = append(segments, part[open:open+end+1])
+			part = part[open+end+1:]
+		}
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+// matchSegment matches one segment. An [index] pattern only matches indexes, while a
+// key pattern of * also matches them.
+func matchSegment(pattern, key string) bool {
+	if isIndex(pattern) {
+		if !isIndex(key) {
+			return false
+		}
+		pattern, key = indexPattern(pattern), indexPattern(key)
+	}
+	return models.MatchSegment(pattern, key)
+}
+
+// isIndex reports whether a segment is an [index]
+func isIndex(segment string) bool {
+	return strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]")
+}
+
+// indexPattern returns the index of an [index] segment, or the segment itself
+func indexPattern(segment string) string {
+	if isIndex(segment) {
+		return segment[1 : len(segment)-1]
+	}
+	return segment
+}
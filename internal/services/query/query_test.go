@@ -0,0 +1,112 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/comparison"
+	"github.com/syntropysoft/praetorian-go/internal/services/secrets"
+)
+
+// TestPatternMatch tests wildcards, list indexes and selecting the keys below a path
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		key      string
+		options  comparison.KeyOptions
+		expected bool
+	}{
+		{pattern: "database.pool.max", key: "database.pool.max", expected: true},
+		{pattern: "database", key: "database.pool.max", expected: true},
+		{pattern: "data", key: "database.pool.max", expected: false},
+		{pattern: "database.pool.max", key: "database.pool", expected: false},
+		{pattern: "feature.*", key: "feature.x", expected: true},
+		{pattern: "feature.?", key: "feature.xy", expected: false},
+		{pattern: "*.host", key: "database.host", expected: true},
+		{pattern: "servers[*].host", key: "servers[1].host", expected: true},
+		{pattern: "servers[1].host", key: "servers[0].host", expected: false},
+		{pattern: "servers[*]", key: "servers.host", expected: false},
+		{pattern: "servers.*.host", key: "servers[0].host", expected: true},
+		{pattern: "**.max", key: "database.pool.max", expected: true},
+		{pattern: "**.max", key: "max", expected: true},
+		{pattern: "**", key: "anything.below", expected: true},
+		{pattern: "DATABASE_*", key: "database.host", options: comparison.KeyOptions{IgnoreCase: true, Separators: "_"}, expected: true},
+		{pattern: "APP_DEBUG", key: "DEBUG", options: comparison.KeyOptions{StripPrefixes: []string{"APP_"}}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.key, func(t *testing.T) {
+			pattern, err := ParsePattern(tt.pattern, tt.options)
+			if err != nil {
+				t.Fatalf("ParsePattern() error = %v", err)
+			}
+			if got := pattern.Match(tt.key); got != tt.expected {
+				t.Errorf("Match(%q) = %v, want %v", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParsePatternInvalid tests patterns that cannot be parsed
+func TestParsePatternInvalid(t *testing.T) {
+	for _, pattern := range []string{"", "  ", "a[", "a.[b-"} {
+		if _, err := ParsePattern(pattern, comparison.KeyOptions{}); err == nil {
+			t.Errorf("ParsePattern(%q) error = nil, want an error", pattern)
+		}
+	}
+}
+
+// TestQuery tests the values of the selected keys by environment, with overriding files
+// and masked secrets
+func TestQuery(t *testing.T) {
+	environments := []Environment{
+		{Name: "dev", Files: []*models.ConfigData{
+			{Filename: "dev.yaml", Data: map[string]interface{}{
+				"database": map[string]interface{}{"host": "localhost", "password": "dev"},
+			}},
+		}},
+		{Name: "prod", Files: []*models.ConfigData{
+			{Filename: "base.properties", Data: map[string]interface{}{"database.host": "db", "database.port": "5432"}},
+			{Filename: "prod.yaml", Data: map[string]interface{}{
+				"database": map[string]interface{}{"host": "db.internal", "password": "s3cr3t"},
+			}},
+		}},
+	}
+	detector, err := secrets.NewDetector(models.SecurityRules{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern, err := ParsePattern("database", comparison.KeyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := Query(environments, pattern, Options{Secrets: detector})
+
+	expected := []Row{
+		{Key: "database.host", Values: []Value{
+			{Environment: "dev", Present: true, Value: "localhost", File: "dev.yaml"},
+			{Environment: "prod", Present: true, Value: "db.internal", File: "prod.yaml"},
+		}},
+		{Key: "database.password", Values: []Value{
+			{Environment: "dev", Present: true, Value: Mask, Secret: true, File: "dev.yaml"},
+			{Environment: "prod", Present: true, Value: Mask, Secret: true, File: "prod.yaml"},
+		}},
+		{Key: "database.port", Values: []Value{
+			{Environment: "dev"},
+			{Environment: "prod", Present: true, Value: "5432", File: "base.properties"},
+		}},
+	}
+	if !reflect.DeepEqual(result.Environments, []string{"dev", "prod"}) {
+		t.Errorf("Query() environments = %v, want [dev prod]", result.Environments)
+	}
+	if !reflect.DeepEqual(result.Rows, expected) {
+		t.Errorf("Query() rows = %+v, want %+v", result.Rows, expected)
+	}
+
+	unmasked := Query(environments, pattern, Options{})
+	if value := unmasked.Rows[1].Values[1]; value.Value != "s3cr3t" || value.Secret {
+		t.Errorf("Query() without secrets = %+v, want the unmasked value", value)
+	}
+}
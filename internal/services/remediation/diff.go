@@ -0,0 +1,141 @@
+package remediation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around changes
+const diffContext = 3
+
+// diffLine is one line of an edit script: ' ' (kept), '-' (removed) or '+' (added)
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns the unified diff between two contents ("" when they are equal)
+func UnifiedDiff(fromName, toName string, from, to []byte) string {
+	script := editScript(splitLines(string(from)), splitLines(string(to)))
+
+	var changes []int
+	for i, line := range script {
+		if line.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+
+	// Guard clause: no changes
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromName, toName)
+
+	for first := 0; first < len(changes); {
+		// Changes closer than twice the context share a hunk
+		last := first
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*diffContext {
+			last++
+		}
+		start := max(changes[first]-diffContext, 0)
+		end := min(changes[last]+diffContext+1, len(script))
+		writeHunk(&builder, script, start, end)
+		first = last + 1
+	}
+	return builder.String()
+}
+
+// writeHunk writes the lines [start, end) of an edit script as a hunk
+func writeHunk(builder *strings.Builder, script []diffLine, start, end int) {
+	fromLine, toLine := 1, 1
+	for _, line := range script[:start] {
+		if line.kind != '+' {
+			fromLine++
+		}
+		if line.kind != '-' {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+	for _, line := range script[start:end] {
+		if line.kind != '+' {
+			fromCount++
+		}
+		if line.kind != '-' {
+			toCount++
+		}
+	}
+
+	// Empty ranges are numbered after the line they follow
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+
+	fmt.Fprintf(builder, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+	for _, line := range script[start:end] {
+		builder.WriteByte(line.kind)
+		builder.WriteString(line.text)
+		if !strings.HasSuffix(line.text, "\n") {
+			builder.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the start and length of a hunk range
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// editScript computes a shortest edit script between two line slices from their longest
+// common subsequence
+func editScript(from, to []string) []diffLine {
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	script := make([]diffLine, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			script = append(script, diffLine{' ', from[i]})
+			i++
+			j++
+		case j == len(to) || (i < len(from) && common[i+1][j] >= common[i][j+1]):
+			script = append(script, diffLine{'-', from[i]})
+			i++
+		default:
+			script = append(script, diffLine{'+', to[j]})
+			j++
+		}
+	}
+	return script
+}
+
+// splitLines splits content into lines, keeping their line endings
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,47 @@
+package remediation
+
+import "testing"
+
+// TestUnifiedDiff tests unified diff hunks
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{name: "equal", from: "a\nb\n", to: "a\nb\n", expected: ""},
+		{
+			name:     "change",
+			from:     "a\nb\nc\n",
+			to:       "a\nB\nc\n",
+			expected: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:     "separate hunks",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			to:       "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name:     "insert into empty file",
+			from:     "",
+			to:       "a\n",
+			expected: "--- a\n+++ b\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name:     "missing final newline",
+			from:     "a\nb",
+			to:       "a\nc",
+			expected: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := UnifiedDiff("a", "b", []byte(tt.from), []byte(tt.to)); diff != tt.expected {
+				t.Errorf("UnifiedDiff() = %q, want %q", diff, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package remediation
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// ManualFix is a fix that has to be made by hand, with the reason it was not applied
+// (empty when the rule declared it manual)
+type ManualFix struct {
+	Fix    models.Fix
+	Reason string
+}
+
+// FileResult holds the outcome of fixing one file
+type FileResult struct {
+	File     string
+	Original []byte
+	Fixed    []byte
+	Applied  []models.Fix
+	Manual   []ManualFix
+}
+
+// Changed checks if fixing the file changed its content
+func (r FileResult) Changed() bool {
+	return !bytes.Equal(r.Original, r.Fixed)
+}
+
+// Remediator applies the fixes declared by rules through format-preserving editors
+type Remediator struct {
+	editors  models.EditorResolver
+	readFile func(filename string) ([]byte, error)
+}
+
+// NewRemediator creates a remediator reading files from disk
+func NewRemediator(editors models.EditorResolver) *Remediator {
+	return &Remediator{editors: editors, readFile: os.ReadFile}
+}
+
+// CollectFixes returns the fixes declared by the errors and warnings of validation results,
+// without duplicates, filling in the file of each fix from its issue
+func CollectFixes(results []models.ValidationResult) []models.Fix {
+	var fixes []models.Fix
+	seen := make(map[string]bool)
+
+	add := func(fix *models.Fix, file string) {
+		if fix == nil {
+			return
+		}
+		collected := *fix
+		if collected.File == "" {
+			collected.File = file
+		}
+
+		key := fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%v", collected.File, collected.Action, collected.Path, collected.NewKey, collected.Value)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		fixes = append(fixes, collected)
+	}
+
+	for _, result := range results {
+		for _, issue := range result.Errors {
+			add(issue.Fix, issue.File)
+		}
+		for _, issue := range result.Warnings {
+			add(issue.Fix, issue.File)
+		}
+	}
+	return fixes
+}
+
+// Plan applies fixes to the content of their files without writing anything; files are
+// returned in the order they are first referenced. Fixes that cannot be applied safely are
+// reported as manual.
+func (r *Remediator) Plan(fixes []models.Fix) []FileResult {
+	var order []string
+	byFile := make(map[string][]models.Fix)
+	for _, fix := range fixes {
+		if _, ok := byFile[fix.File]; !ok {
+			order = append(order, fix.File)
+		}
+		byFile[fix.File] = append(byFile[fix.File], fix)
+	}
+
+	results := make([]FileResult, 0, len(order))
+	for _, file := range order {
+		results = append(results, r.planFile(file, byFile[file]))
+	}
+	return results
+}
+
+// planFile applies the fixes of one file
+func (r *Remediator) planFile(file string, fixes []models.Fix) FileResult {
+	result := FileResult{File: file}
+
+	// Guard clause: documents of multi-document files cannot be edited separately
+	if _, document := models.SplitDocumentFilename(file); document != "" {
+		return manualOnly(result, fixes, "fixes in multi-document files are not applied automatically")
+	}
+
+	content, err := r.readFile(file)
+	if err != nil {
+		return manualOnly(result, fixes, err.Error())
+	}
+	result.Original, result.Fixed = content, content
+
+	editor, err := r.editors.GetEditor(file, content)
+	if err != nil {
+		return manualOnly(result, fixes, err.Error())
+	}
+
+	var applied []models.Fix
+	for _, fix := range fixes {
+		if fix.IsManual() {
+			result.Manual = append(result.Manual, ManualFix{Fix: fix})
+			continue
+		}
+		if err := applyFix(editor, fix); err != nil {
+			result.Manual = append(result.Manual, ManualFix{Fix: fix, Reason: err.Error()})
+			continue
+		}
+		applied = append(applied, fix)
+	}
+
+	// Guard clause: nothing changed
+	if len(applied) == 0 {
+		return result
+	}
+
+	fixed, err := editor.Bytes()
+	if err != nil {
+		return manualOnly(FileResult{File: file, Original: content, Fixed: content, Manual: result.Manual}, applied, err.Error())
+	}
+	result.Fixed = fixed
+	result.Applied = applied
+	return result
+}
+
+// applyFix applies one fix with an editor
+func applyFix(editor models.ConfigEditor, fix models.Fix) error {
+	switch fix.Action {
+	case models.FixSet:
+		return editor.Set(fix.Path, fix.Value)
+	case models.FixDelete:
+		return editor.Delete(fix.Path)
+	case models.FixRename:
+		return editor.Rename(fix.Path, fix.NewKey)
+	}
+	return fmt.Errorf("unknown fix action %q", fix.Action)
+}
+
+// manualOnly reports every fix of a file as manual for the same reason
+func manualOnly(result FileResult, fixes []models.Fix, reason string) FileResult {
+	for _, fix := range fixes {
+		manual := ManualFix{Fix: fix, Reason: reason}
+		if fix.IsManual() {
+			manual.Reason = ""
+		}
+		result.Manual = append(result.Manual, manual)
+	}
+	return result
+}
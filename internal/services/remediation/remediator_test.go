@@ -0,0 +1,81 @@
+package remediation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/adapters/parsers"
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// TestCollectFixes tests collecting fixes from validation results
+func TestCollectFixes(t *testing.T) {
+	deleteDebug := &models.Fix{Action: models.FixDelete, Path: "debug"}
+	results := []models.ValidationResult{
+		{Errors: []models.ValidationError{{File: "a.yaml", Fix: deleteDebug}, {File: "a.yaml"}}},
+		{Errors: []models.ValidationError{{File: "a.yaml", Fix: deleteDebug}}},
+		{Warnings: []models.ValidationWarning{{File: "b.yaml", Fix: &models.Fix{Action: models.FixManual, File: "c.yaml"}}}},
+	}
+
+	fixes := CollectFixes(results)
+	if len(fixes) != 2 {
+		t.Fatalf("CollectFixes() = %+v, want 2 fixes", fixes)
+	}
+	if fixes[0].File != "a.yaml" || fixes[1].File != "c.yaml" {
+		t.Errorf("CollectFixes() files = %s, %s, want a.yaml, c.yaml", fixes[0].File, fixes[1].File)
+	}
+}
+
+// TestRemediatorPlan tests applying fixes and reporting the ones that need a human
+func TestRemediatorPlan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return filename
+	}
+	config := write("app.yaml", "# app\nserver:\n  hostname: example.com # public\n  debug: true\n")
+	unsupported := write("app.xml", "<app/>\n")
+
+	fixes := []models.Fix{
+		{Action: models.FixRename, File: config, Path: "server.hostname", NewKey: "host"},
+		{Action: models.FixDelete, File: config, Path: "server.debug"},
+		{Action: models.FixSet, File: config, Path: "api.key", Value: "CHANGE_ME"},
+		{Action: models.FixDelete, File: config, Path: "server.missing"},
+		{Action: models.FixManual, File: config, Description: "move legacy"},
+		{Action: models.FixDelete, File: unsupported, Path: "app"},
+		{Action: models.FixDelete, File: config + "#2", Path: "kind"},
+	}
+
+	plan := NewRemediator(parsers.NewParserRegistry()).Plan(fixes)
+	if len(plan) != 3 {
+		t.Fatalf("Plan() = %d files, want 3", len(plan))
+	}
+
+	fixed := plan[0]
+	expected := "# app\nserver:\n  host: example.com # public\napi:\n  key: CHANGE_ME\n"
+	if string(fixed.Fixed) != expected {
+		t.Errorf("Plan() fixed content = %q, want %q", fixed.Fixed, expected)
+	}
+	if !fixed.Changed() || len(fixed.Applied) != 3 || len(fixed.Manual) != 2 {
+		t.Errorf("Plan() = %d applied, %d manual, want 3 and 2", len(fixed.Applied), len(fixed.Manual))
+	}
+	if fixed.Manual[0].Reason == "" || fixed.Manual[1].Reason != "" {
+		t.Errorf("Plan() manual reasons = %+v, want a reason only for the failed fix", fixed.Manual)
+	}
+
+	for _, result := range plan[1:] {
+		if result.Changed() || len(result.Manual) != 1 || result.Manual[0].Reason == "" {
+			t.Errorf("Plan() for %s = %+v, want one manual fix with a reason", result.File, result)
+		}
+	}
+
+	// Nothing is written while planning
+	if content, _ := os.ReadFile(config); strings.Contains(string(content), "api") {
+		t.Error("Plan() should not write files")
+	}
+}
@@ -0,0 +1,92 @@
+package secrets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+)
+
+// secretKeyWords mark keys that hold secrets. Keys are compared in lower case with
+// separators removed, so DB_PASSWORD, db.password and dbPassword all match "password".
+var secretKeyWords = []string{
+	"password", "passwd", "pwd", "secret", "token", "apikey", "accesskey", "privatekey",
+	"credential", "connectionstring", "clientsecret", "signingkey", "encryptionkey", "dsn",
+}
+
+// secretValuePatterns match values that are secrets whatever their key
+var secretValuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`),
+	regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`),
+}
+
+// Detector decides whether configuration values are secrets, from their key name or
+// their value
+type Detector struct {
+	custom []*regexp.Regexp
+}
+
+// NewDetector creates a detector with the custom patterns of the security rules, which
+// are matched against both keys and values
+func NewDetector(rules models.SecurityRules) (*Detector, error) {
+	detector := &Detector{}
+	for _, pattern := range rules.CustomPatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom pattern %q: %w", pattern, err)
+		}
+		detector.custom = append(detector.custom, compiled)
+	}
+	return detector, nil
+}
+
+// IsSecret reports whether the value at a key path is a secret
+func (d *Detector) IsSecret(keyPath string, value interface{}) bool {
+	if IsSecretKey(keyPath) {
+		return true
+	}
+
+	text, _ := value.(string)
+	for _, pattern := range secretValuePatterns {
+		if text != "" && pattern.MatchString(text) {
+			return true
+		}
+	}
+	for _, pattern := range d.custom {
+		if pattern.MatchString(keyPath) || (text != "" && pattern.MatchString(text)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecretKey reports whether the last key of a key path names a secret
+func IsSecretKey(keyPath string) bool {
+	key := keyPath
+	if parsed, err := models.ParsePath(keyPath); err == nil {
+		for i := len(parsed) - 1; i >= 0; i-- {
+			if !parsed[i].IsIndex {
+				key = parsed[i].Key
+				break
+			}
+		}
+	}
+
+	compact := strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+	for _, word := range secretKeyWords {
+		if strings.Contains(compact, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package templating
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/syntropysoft/praetorian-go/internal/domain/models"
+	"github.com/syntropysoft/praetorian-go/internal/services/secrets"
+)
+
+// Options configures template generation
+type Options struct {
+	// Placeholder replaces every value that is not a secret; when empty, values are
+	// replaced by type hints such as <int>
+	Placeholder string
+	// Secrets decides which values are blanked; secrets are always written empty
+	Secrets *secrets.Detector
+	// Infer converts string values before their type hint is chosen, so that "8080" in an
+	// env file is hinted as <int>; nil keeps strings as strings
+	Infer func(value string) interface{}
+}
+
+// Key is one key of a template
+type Key struct {
+	Path   string      `json:"path"`
+	Value  interface{} `json:"value"`
+	Secret bool        `json:"secret,omitempty"`
+	// Files lists the files that define the key
+	Files []string `json:"files"`
+}
+
+// Template is the union of the keys of a set of files
+type Template struct {
+	Keys []Key `json:"keys"`
+}
+
+// Build returns the union of the leaf keys of files, in sorted key order. The value of
+// a key is taken from the first file that defines it.
+func Build(files []*models.ConfigData, options Options) Template {
+	keys := make(map[string]*Key)
+	for _, file := range files {
+		for keyPath, value := range file.Flatten() {
+			if key, ok := keys[keyPath]; ok {
+				key.Files = append(key.Files, file.Filename)
+				continue
+			}
+
+			key := &Key{Path: keyPath, Files: []string{file.Filename}}
+			if options.Secrets != nil && options.Secrets.IsSecret(keyPath, value) {
+				key.Secret, key.Value = true, ""
+			} else {
+				key.Value = templateValue(value, options)
+			}
+			keys[keyPath] = key
+		}
+	}
+
+	template := Template{Keys: make([]Key, 0, len(keys))}
+	for _, key := range keys {
+		template.Keys = append(template.Keys, *key)
+	}
+	sort.Slice(template.Keys, func(i, j int) bool { return template.Keys[i].Path < template.Keys[j].Path })
+	return template
+}
+
+// Data returns the template as a configuration tree. Keys that are a value in one file
+// and a parent key in another cannot be combined and are reported.
+func (t Template) Data() (map[string]interface{}, error) {
+	tree := &models.ConfigData{Data: make(map[string]interface{})}
+	for _, key := range t.Keys {
+		if err := tree.Set(key.Path, key.Value); err != nil {
+			return nil, fmt.Errorf("key %s is defined differently across files (%v): %w", key.Path, key.Files, err)
+		}
+	}
+	return tree.Data, nil
+}
+
+// templateValue replaces a value by the placeholder or by its type hint. Empty maps and
+// lists are kept, as they have no type to hint.
+func templateValue(value interface{}, options Options) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}, []interface{}:
+		return v
+	}
+	if options.Placeholder != "" {
+		return options.Placeholder
+	}
+	return TypeHint(value, options.Infer)
+}
+
+// TypeHint returns the hint written for a value: <string>, <int>, <float>, <bool>,
+// <duration>, <date> or an empty string for null values
+func TypeHint(value interface{}, infer func(string) interface{}) string {
+	if text, ok := value.(string); ok && infer != nil && text != "" {
+		value = infer(text)
+	}
+
+	switch value.(type) {
+	case nil:
+		return ""
+	case bool:
+		return "<bool>"
+	case int, int64:
+		return "<int>"
+	case float64:
+		return "<float>"
+	case time.Duration:
+		return "<duration>"
+	case time.Time:
+		return "<date>"
+	}
+	return "<string>"
+}
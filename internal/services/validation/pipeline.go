@@ -14,6 +14,7 @@ import (
 type FilePipeline struct {
 	readers    []models.FileReader
 	processors []models.FileProcessor
+	resolver   models.ProcessorResolver
 	config     models.PipelineConfig
 }
 
@@ -34,12 +35,15 @@ func (p *FilePipeline) ProcessFiles(ctx context.Context, filenames []string) ([]
 	}
 
 	// Guard clause: check if we have processors
-	if len(p.processors) == 0 {
+	if len(p.processors) == 0 && p.resolver == nil {
 		return nil, fmt.Errorf("no processors registered")
 	}
 
 	// Process files concurrently
-	return p.processFilesConcurrently(ctx, filenames)
+	processed, err := p.processFilesConcurrently(ctx, filenames)
+
+	// Multi-document files are validated per document
+	return models.ExpandDocuments(processed), err
 }
 
 // ProcessFile processes a single file
@@ -49,6 +53,11 @@ func (p *FilePipeline) ProcessFile(ctx context.Context, filename string) (*model
 		return nil, fmt.Errorf("invalid filename: %w", err)
 	}
 
+	// Guard clause: resolve by filename rules and content when a resolver is set
+	if p.resolver != nil {
+		return p.processWithResolver(ctx, filename)
+	}
+
 	// Find appropriate processor
 	processor := p.findProcessor(filename)
 	if processor == nil {
@@ -61,8 +70,35 @@ func (p *FilePipeline) ProcessFile(ctx context.Context, filename string) (*model
 		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
 	}
 
-	// Process file
-	return processor.Process(ctx, filename, content)
+	// Process file with normalised encoding and line endings
+	return models.ProcessNormalized(ctx, processor, filename, content)
+}
+
+// SetResolver sets the resolver used to select processors by filename rules and content
+// (e.g. a parsers.ParserRegistry). Registered processors are not consulted when it is set.
+func (p *FilePipeline) SetResolver(resolver models.ProcessorResolver) {
+	p.resolver = resolver
+}
+
+// processWithResolver reads a file and processes it with the processor chosen by the resolver
+func (p *FilePipeline) processWithResolver(ctx context.Context, filename string) (*models.ConfigData, error) {
+	content, err := p.readFileContent(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	// Content sniffing needs decoded text
+	normalized, _, err := models.NormalizeEncoding(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file %s: %w", filename, err)
+	}
+
+	processor, err := p.resolver.DetectProcessor(filename, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("no processor found for file %s: %w", filename, err)
+	}
+
+	return models.ProcessNormalized(ctx, processor, filename, content)
 }
 
 // RegisterProcessor registers a new processor
@@ -219,13 +255,13 @@ func (p *FilePipeline) validateFilenames(filenames []string) error {
 	if len(filenames) == 0 {
 		return fmt.Errorf("filenames cannot be empty")
 	}
-	
+
 	for _, filename := range filenames {
 		if err := p.validateFilename(filename); err != nil {
 			return fmt.Errorf("invalid filename %s: %w", filename, err)
 		}
 	}
-	
+
 	return nil
 }
 
@@ -243,14 +279,14 @@ func (p *FilePipeline) validateFilename(filename string) error {
 // isProcessorRegistered checks if a processor is already registered
 func (p *FilePipeline) isProcessorRegistered(newProcessor models.FileProcessor) bool {
 	newExtensions := newProcessor.GetSupportedExtensions()
-	
+
 	for _, existing := range p.processors {
 		existingExtensions := existing.GetSupportedExtensions()
 		if p.hasExtensionOverlap(newExtensions, existingExtensions) {
 			return true
 		}
 	}
-	
+
 	return false
 }
 
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestConfigCommandIntegration tests validating and showing praetorian.yaml
+func TestConfigCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	writeFiles(t, map[string]string{
+		"praetorian.yaml": "version: \"2.0\"\nextends: base.yaml\n",
+		"base.yaml":       "rules:\n  structure:\n    required_keys: [database.host]\n",
+		"typo.yaml":       "version: \"2.0\"\nrules:\n  structure:\n    ignore_key: [x]\n",
+	})
+
+	t.Run("should validate a configuration", func(t *testing.T) {
+		output, err := executeCommand(cli.NewConfigCommand(), "", "validate")
+		if exitCode(err) != 0 {
+			t.Fatalf("Config validate command failed: %v", err)
+		}
+		if !containsString(output, "praetorian.yaml is valid (0 warnings)") {
+			t.Errorf("Expected the configuration to be valid, got:\n%s", output)
+		}
+	})
+
+	t.Run("should exit 1 with the position of errors", func(t *testing.T) {
+		output, err := executeCommand(cli.NewConfigCommand(), "", "validate", "--config", "typo.yaml")
+		if code := exitCode(err); code != 1 {
+			t.Fatalf("Config validate exit code = %d, want 1 (%v)", code, err)
+		}
+		if !containsString(output, `typo.yaml:4:5: error CONFIG_UNKNOWN_KEY unknown key "rules.structure.ignore_key", did you mean "ignore_keys"?`) {
+			t.Errorf("Expected the unknown key with a suggestion, got:\n%s", output)
+		}
+	})
+
+	t.Run("should show the resolved configuration", func(t *testing.T) {
+		output, err := executeCommand(cli.NewConfigCommand(), "", "show", "--resolved")
+		if exitCode(err) != 0 {
+			t.Fatalf("Config show command failed: %v", err)
+		}
+		for _, expected := range []string{"required_keys:\n            - database.host\n", "placeholder: CHANGE_ME"} {
+			if !containsString(output, expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+			}
+		}
+		if containsString(output, "extends") {
+			t.Errorf("Expected extends to be applied, got:\n%s", output)
+		}
+	})
+}
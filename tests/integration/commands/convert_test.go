@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestConvertCommandIntegration tests converting files with warnings for lossy conversions
+func TestConvertCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	source := "# app\nserver.port=8080\nserver.host=localhost\nfeature.enabled=true\nserver.port=9090\n"
+	writeFiles(t, map[string]string{
+		"app.properties": source,
+		"keys.yaml":      "1: one\nport: 80\n",
+		"limits.json5":   "{max: Infinity, min: -Infinity, ratio: NaN}\n",
+	})
+
+	t.Run("should print the converted file and warn on stderr", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		cmd := cli.NewConvertCommand()
+		cmd.SetArgs([]string{"app.properties", "--to", "yaml"})
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Convert command failed: %v", err)
+		}
+
+		expected := "feature:\n  enabled: true\nserver:\n  host: localhost\n  port: 9090\n"
+		if stdout.String() != expected {
+			t.Errorf("Converted =\n%s\nwant\n%s", stdout.String(), expected)
+		}
+		for _, warning := range []string{"1 comments are dropped", "duplicate key server.port: only the last value is kept"} {
+			if !containsString(stderr.String(), warning) {
+				t.Errorf("Expected the warning %q, got:\n%s", warning, stderr.String())
+			}
+		}
+	})
+
+	t.Run("should write the output file", func(t *testing.T) {
+		output, err := executeCommand(cli.NewConvertCommand(), "", "app.properties", "--to", "json", "app.json")
+		if err != nil {
+			t.Fatalf("Convert command failed: %v", err)
+		}
+
+		if !containsString(output, "Converted app.properties (properties) to app.json (json) with 2 warnings") {
+			t.Errorf("Expected the output to report the conversion, got:\n%s", output)
+		}
+		assertFileContent(t, "app.json", "{\n  \"feature\": {\n    \"enabled\": true\n  },\n  \"server\": {\n    \"host\": \"localhost\",\n    \"port\": 9090\n  }\n}\n")
+	})
+
+	t.Run("should drop keys without a variable name", func(t *testing.T) {
+		output, err := executeCommand(cli.NewConvertCommand(), "", "keys.yaml", "--to", "env")
+		if err != nil {
+			t.Fatalf("Convert command failed: %v", err)
+		}
+
+		for _, expected := range []string{"1: 1 is not a valid variable name, the value is dropped", "PORT=80\n"} {
+			if !containsString(output, expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+			}
+		}
+	})
+
+	t.Run("should convert JSON5 infinities and NaN", func(t *testing.T) {
+		output, err := executeCommand(cli.NewConvertCommand(), "", "limits.json5", "--to", "json")
+		if err != nil {
+			t.Fatalf("Convert command failed: %v", err)
+		}
+
+		expected := "{\n  \"max\": \"Infinity\",\n  \"min\": \"-Infinity\",\n  \"ratio\": \"NaN\"\n}\n"
+		if output != expected {
+			t.Errorf("Converted =\n%s\nwant\n%s", output, expected)
+		}
+	})
+
+	t.Run("should refuse invalid arguments", func(t *testing.T) {
+		if _, err := executeCommand(cli.NewConvertCommand(), "", "app.properties"); err == nil || !containsString(err.Error(), "--to is required") {
+			t.Errorf("Convert command error = %v, want --to to be required", err)
+		}
+		if _, err := executeCommand(cli.NewConvertCommand(), "", "app.properties", "--to", "yaml", "app.properties"); err == nil {
+			t.Error("Expected converting a file onto itself to fail")
+		}
+		assertFileContent(t, "app.properties", source)
+	})
+}
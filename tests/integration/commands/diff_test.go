@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestDiffCommandIntegration tests comparing files across formats and the exit codes
+func TestDiffCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	writeFiles(t, map[string]string{
+		"app.yaml":  "database:\n  host: db\n  port: 5432\n",
+		"app.json":  `{"database": {"host": "db", "port": 5432}}`,
+		"prod.yaml": "database:\n  host: prod-db\n  port: 5432\ncache: redis\n",
+		".env":      "DATABASE_HOST=db\nDATABASE_PORT=5433\n",
+		"a.json5":   "{ratio: NaN, max: Infinity}\n",
+		"b.json5":   "// same limits\n{ratio: NaN, max: +Infinity}\n",
+	})
+
+	t.Run("should exit 0 when files match", func(t *testing.T) {
+		output, err := executeCommand(cli.NewDiffCommand(), "", "app.yaml", "app.json")
+		if exitCode(err) != 0 {
+			t.Fatalf("Diff command failed: %v", err)
+		}
+		if !containsString(output, "No differences in 2 keys") {
+			t.Errorf("Expected no differences, got:\n%s", output)
+		}
+	})
+
+	t.Run("should match JSON5 infinities and NaN", func(t *testing.T) {
+		output, err := executeCommand(cli.NewDiffCommand(), "", "a.json5", "b.json5", "--output", "json")
+		if exitCode(err) != 0 {
+			t.Fatalf("Diff command failed: %v\n%s", err, output)
+		}
+	})
+
+	t.Run("should exit 1 when files differ", func(t *testing.T) {
+		output, err := executeCommand(cli.NewDiffCommand(), "", "app.yaml", "prod.yaml")
+		if code := exitCode(err); code != 1 {
+			t.Fatalf("Diff command exit code = %d, want 1 (%v)", code, err)
+		}
+		for _, expected := range []string{"+  cache", "~  database.host", "2 keys differ: 1 added, 0 removed, 1 changed"} {
+			if !containsString(output, expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+			}
+		}
+	})
+
+	t.Run("should match variable names with the key flags", func(t *testing.T) {
+		output, err := executeCommand(cli.NewDiffCommand(), "", "app.yaml", ".env", "-i", "--separators", "_", "--output", "json")
+		if code := exitCode(err); code != 1 {
+			t.Fatalf("Diff command exit code = %d, want 1 (%v)", code, err)
+		}
+
+		var result struct {
+			Entries []struct {
+				Key    string `json:"key"`
+				Status string `json:"status"`
+			} `json:"entries"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to decode the JSON output: %v\n%s", err, output)
+		}
+		if len(result.Entries) != 1 || result.Entries[0].Key != "database.port" || result.Entries[0].Status != "changed" {
+			t.Errorf("Expected only database.port to change, got %+v", result.Entries)
+		}
+	})
+
+	t.Run("should exit 2 on errors", func(t *testing.T) {
+		if _, err := executeCommand(cli.NewDiffCommand(), "", "app.yaml", "missing.yaml"); exitCode(err) != 2 {
+			t.Errorf("Diff command error = %v, want exit code 2 for a missing file", err)
+		}
+		if _, err := executeCommand(cli.NewDiffCommand(), "", "app.yaml"); exitCode(err) != 2 {
+			t.Errorf("Diff command error = %v, want exit code 2 for a single file", err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestFixCommandIntegration tests showing, confirming and applying fixes
+func TestFixCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	dev := "# dev\ndatabase:\n  host: localhost\n\napp:\n  debug: true\n  enabled: yes # toggle\n\n  name: web\n"
+	prod := "app:\n  name: api\n"
+	writeFiles(t, map[string]string{
+		"praetorian.yaml":  "version: \"2.0\"\nfiles:\n  include: [\"config/*.yaml\"]\nrules:\n  structure:\n    required_keys: [database.host]\n    forbidden_keys: [app.debug]\n    boolean_keys: [\"*.enabled\"]\n",
+		"config/dev.yaml":  dev,
+		"config/prod.yaml": prod,
+	})
+
+	t.Run("should only print the diffs on dry runs", func(t *testing.T) {
+		output, err := executeCommand(cli.NewFixCommand(), "", "--dry-run")
+		if exitCode(err) != 0 {
+			t.Fatalf("Fix command failed: %v", err)
+		}
+
+		for _, expected := range []string{
+			"--- a/config/dev.yaml\n+++ b/config/dev.yaml\n",
+			"-  debug: true\n",
+			"-  enabled: yes # toggle\n+  enabled: true # toggle\n",
+			"+database:\n+  host: CHANGE_ME\n",
+			"Dry run: 3 fixes in 2 files not applied",
+		} {
+			if !containsString(output, expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+			}
+		}
+		assertFileContent(t, "config/dev.yaml", dev)
+		assertFileContent(t, "config/prod.yaml", prod)
+	})
+
+	t.Run("should not write without confirmation", func(t *testing.T) {
+		output, err := executeCommand(cli.NewFixCommand(), "n\n")
+		if exitCode(err) != 0 {
+			t.Fatalf("Fix command failed: %v", err)
+		}
+
+		if !containsString(output, "Apply 3 fixes to 2 files? [y/N] Aborted, no files changed") {
+			t.Errorf("Expected the output to report the abort, got:\n%s", output)
+		}
+		assertFileContent(t, "config/dev.yaml", dev)
+	})
+
+	t.Run("should apply fixes with yes", func(t *testing.T) {
+		output, err := executeCommand(cli.NewFixCommand(), "", "--yes")
+		if exitCode(err) != 0 {
+			t.Fatalf("Fix command failed: %v", err)
+		}
+
+		if !containsString(output, "Applied 3 fixes to 2 files") {
+			t.Errorf("Expected the output to report the fixes, got:\n%s", output)
+		}
+		assertFileContent(t, "config/dev.yaml", "# dev\ndatabase:\n  host: localhost\n\napp:\n  enabled: true # toggle\n\n  name: web\n")
+		assertFileContent(t, "config/prod.yaml", "app:\n  name: api\ndatabase:\n  host: CHANGE_ME\n")
+
+		output, err = executeCommand(cli.NewFixCommand(), "", "--yes")
+		if exitCode(err) != 0 || !containsString(output, "No automatic fixes to apply") {
+			t.Errorf("Expected nothing left to fix, got %v:\n%s", err, output)
+		}
+	})
+
+	t.Run("should fail without configuration", func(t *testing.T) {
+		if _, err := executeCommand(cli.NewFixCommand(), "", "--config", "missing.yaml"); exitCode(err) == 0 {
+			t.Error("Expected a missing configuration to fail")
+		}
+	})
+}
+
+// TestFixCommandEncodingIntegration tests that encodings are checked per file
+func TestFixCommandEncodingIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	writeFiles(t, map[string]string{
+		"praetorian.yaml": "version: \"2.0\"\n",
+		"a.yaml":          "a: 1\n",
+		"b.yaml":          "b: 1\n",
+		"deploy.yaml":     "a: 1\r\n---\r\nb: 2\r\n---\r\nc: 3\r\n",
+		// UTF-16LE with a byte order mark, in a format only known from its content
+		"settings": "\xff\xfe{\x00\"\x00a\x00\"\x00:\x00 \x001\x00}\x00\n\x00",
+	})
+
+	t.Run("should count a multi-document file once", func(t *testing.T) {
+		output, err := executeCommand(cli.NewFixCommand(), "", "--dry-run", "a.yaml", "b.yaml", "deploy.yaml")
+		if err != nil {
+			t.Fatalf("Fix command failed: %v", err)
+		}
+
+		if !containsString(output, "1 fixes must be made manually") || !containsString(output, "deploy.yaml: convert the line endings to lf") {
+			t.Errorf("Expected one line-ending fix for deploy.yaml, got:\n%s", output)
+		}
+	})
+
+	t.Run("should detect the format of decoded content", func(t *testing.T) {
+		output, err := executeCommand(cli.NewFixCommand(), "", "--dry-run", "settings")
+		if err != nil {
+			t.Fatalf("Fix command failed: %v", err)
+		}
+
+		if !containsString(output, "No automatic fixes to apply") {
+			t.Errorf("Expected no fixes, got:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestGetCommandIntegration tests querying keys across environments
+func TestGetCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	writeFiles(t, map[string]string{
+		"praetorian.yaml":  "version: \"2.0\"\nenvironments:\n  dev: config/dev.yaml\n  prod: [config/prod.yaml, config/.env.prod]\n",
+		"config/dev.yaml":  "database:\n  host: localhost\n  password: hunter2\napp:\n  name: web\n",
+		"config/prod.yaml": "database:\n  host: db\n",
+		"config/.env.prod": "DATABASE_PASSWORD=s3cret\n",
+	})
+
+	t.Run("should show values by environment with masked secrets", func(t *testing.T) {
+		output, err := executeCommand(cli.NewGetCommand(), "", "database")
+		if exitCode(err) != 0 {
+			t.Fatalf("Get command failed: %v", err)
+		}
+
+		for _, expected := range []string{"KEY", "dev", "prod", "database.host", "localhost", "********"} {
+			if !containsString(output, expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+			}
+		}
+		if containsString(output, "hunter2") {
+			t.Errorf("Expected secrets to be masked, got:\n%s", output)
+		}
+	})
+
+	t.Run("should match variable names with the key flags", func(t *testing.T) {
+		output, err := executeCommand(cli.NewGetCommand(), "", "database.password", "-i", "--separators", "_", "--show-secrets", "--env", "prod", "--output", "json")
+		if exitCode(err) != 0 {
+			t.Fatalf("Get command failed: %v", err)
+		}
+
+		var result struct {
+			Rows []struct {
+				Key    string `json:"key"`
+				Values []struct {
+					Value interface{} `json:"value"`
+					File  string      `json:"file"`
+				} `json:"values"`
+			} `json:"keys"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to decode the JSON output: %v\n%s", err, output)
+		}
+		if len(result.Rows) != 1 || len(result.Rows[0].Values) != 1 || result.Rows[0].Values[0].Value != "s3cret" {
+			t.Errorf("Expected the prod password, got %+v", result.Rows)
+		}
+	})
+
+	t.Run("should exit 1 when no key matches", func(t *testing.T) {
+		if _, err := executeCommand(cli.NewGetCommand(), "", "cache.*"); exitCode(err) != 1 {
+			t.Errorf("Get command error = %v, want exit code 1", err)
+		}
+	})
+
+	t.Run("should exit 2 on errors", func(t *testing.T) {
+		if _, err := executeCommand(cli.NewGetCommand(), "", "app", "--env", "qa"); exitCode(err) != 2 {
+			t.Errorf("Get command error = %v, want exit code 2 for an unknown environment", err)
+		}
+		if _, err := executeCommand(cli.NewGetCommand(), "", "app", "--output", "xml"); exitCode(err) != 2 {
+			t.Errorf("Get command error = %v, want exit code 2 for an invalid output", err)
+		}
+	})
+}
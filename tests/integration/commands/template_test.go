@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestTemplateCommandIntegration tests generating templates and checking stale ones
+func TestTemplateCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	writeFiles(t, map[string]string{
+		"praetorian.yaml":  "version: \"2.0\"\nenvironments:\n  dev: config/dev.yaml\n  prod: config/prod.yaml\n",
+		"config/dev.yaml":  "database:\n  host: localhost\n  password: hunter2\ndebug: true\n",
+		"config/prod.yaml": "database:\n  host: db\n  port: 5432\n",
+	})
+
+	t.Run("should print the keys of every environment", func(t *testing.T) {
+		output, err := executeCommand(cli.NewTemplateCommand(), "", "generate", "--format", "yaml", "--from", "prod")
+		if exitCode(err) != 0 {
+			t.Fatalf("Template command failed: %v", err)
+		}
+
+		expected := "database:\n  host: <string>\n  password: \"\"\n  port: <int>\ndebug: <bool>\n"
+		if output != expected {
+			t.Errorf("Template =\n%s\nwant\n%s", output, expected)
+		}
+	})
+
+	t.Run("should write the template with blanked secrets", func(t *testing.T) {
+		output, err := executeCommand(cli.NewTemplateCommand(), "", "generate", "--format", "env", ".env.example")
+		if exitCode(err) != 0 {
+			t.Fatalf("Template command failed: %v", err)
+		}
+
+		if !containsString(output, "Wrote .env.example: 4 keys, 1 secrets blanked") {
+			t.Errorf("Expected the output to report the template, got:\n%s", output)
+		}
+		assertFileContent(t, ".env.example", "DATABASE_HOST=<string>\nDATABASE_PASSWORD=\nDATABASE_PORT=<int>\nDEBUG=<bool>\n")
+	})
+
+	t.Run("should exit 1 when the template is stale", func(t *testing.T) {
+		output, err := executeCommand(cli.NewTemplateCommand(), "", "generate", "--format", "env", ".env.example", "--check")
+		if exitCode(err) != 0 || !containsString(output, ".env.example matches the 4 keys of the environments") {
+			t.Fatalf("Expected the template to match, got %v:\n%s", err, output)
+		}
+
+		writeFiles(t, map[string]string{"config/prod.yaml": "database:\n  host: db\n  port: 5432\ncache: redis\n"})
+		output, err = executeCommand(cli.NewTemplateCommand(), "", "generate", "--format", "env", ".env.example", "--check")
+		if code := exitCode(err); code != 1 {
+			t.Fatalf("Template check exit code = %d, want 1 (%v)", code, err)
+		}
+		if !containsString(output, "CACHE is missing from .env.example") {
+			t.Errorf("Expected the missing key to be reported, got:\n%s", output)
+		}
+		assertFileContent(t, ".env.example", "DATABASE_HOST=<string>\nDATABASE_PASSWORD=\nDATABASE_PORT=<int>\nDEBUG=<bool>\n")
+	})
+}
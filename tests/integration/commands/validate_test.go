@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/syntropysoft/praetorian-go/internal/cli"
+)
+
+// TestValidateCommandIntegration tests validating every document of the configured files
+func TestValidateCommandIntegration(t *testing.T) {
+	testDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	os.Chdir(testDir)
+	writeFiles(t, map[string]string{
+		"praetorian.yaml": "version: \"2.0\"\nfiles:\n  include: [\"*.yaml\"]\nrules:\n  structure:\n    required_keys: [app.name]\n",
+		"app.yaml":        "app:\n  name: web\n",
+		"deploy.yaml":     "app:\n  name: api\n---\n---\nkind: ConfigMap\nmetadata:\n  name: worker\n",
+	})
+
+	t.Run("should report the issues of each document", func(t *testing.T) {
+		output, err := executeCommand(cli.NewValidateCommand(), "", "--pipeline")
+		if code := exitCode(err); code != 1 {
+			t.Fatalf("Validate exit code = %d, want 1 (%v)", code, err)
+		}
+
+		for _, expected := range []string{
+			`deploy.yaml#ConfigMap/worker: error STRUCTURE_MISSING_KEY required key "app.name" is missing`,
+			"Validation failed: 1 errors and 0 warnings in 2 files",
+			"PRAETORIAN_VALIDATION_STATUS=failure",
+		} {
+			if !containsString(output, expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+			}
+		}
+	})
+
+	t.Run("should pass valid files", func(t *testing.T) {
+		output, err := executeCommand(cli.NewValidateCommand(), "", "app.yaml")
+		if exitCode(err) != 0 {
+			t.Fatalf("Validate command failed: %v", err)
+		}
+		if !containsString(output, "Validation completed successfully! 1 files, 0 warnings") {
+			t.Errorf("Expected the file to be valid, got:\n%s", output)
+		}
+	})
+
+	t.Run("should print JSON results", func(t *testing.T) {
+		output, err := executeCommand(cli.NewValidateCommand(), "", "--output", "json", "deploy.yaml")
+		if exitCode(err) != 1 {
+			t.Fatalf("Validate command error = %v, want exit code 1", err)
+		}
+		if !containsString(output, `"file": "deploy.yaml#ConfigMap/worker"`) || containsString(output, "Validating") {
+			t.Errorf("Expected only the JSON result, got:\n%s", output)
+		}
+	})
+}